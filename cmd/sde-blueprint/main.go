@@ -0,0 +1,133 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Sternrassler/eve-sde/pkg/evedb/industry"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const appVersion = "0.1.0"
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: sde-blueprint [flags] <command> <type>
+
+Commands:
+  used-in      Blueprints that consume the type as material
+  produced-by  Blueprints that produce the type (manufacturing, reaction, invention)
+
+<type> is a type ID or an English type name (case-insensitive).
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	var (
+		dbPath      = flag.String("db", "data/sqlite/eve-sde.db", "SQLite database path")
+		asJSON      = flag.Bool("json", false, "Print results as JSON")
+		showVersion = flag.Bool("version", false, "Show version")
+	)
+	flag.Usage = usage
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("sde-blueprint v%s\n", appVersion)
+		return
+	}
+	if flag.NArg() != 2 {
+		usage()
+		os.Exit(2)
+	}
+	command, ref := flag.Arg(0), flag.Arg(1)
+
+	if _, err := os.Stat(*dbPath); err != nil {
+		log.Fatalf("Database not found: %s", *dbPath)
+	}
+	db, err := sql.Open("sqlite3", *dbPath+"?mode=ro")
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	typeID, name, err := industry.ResolveType(db, ref)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var result interface{}
+	switch command {
+	case "used-in":
+		usages, err := industry.UsedIn(db, typeID)
+		if err != nil {
+			log.Fatalf("Lookup failed: %v", err)
+		}
+		result = usages
+		if !*asJSON {
+			printUsages(name, typeID, usages)
+		}
+	case "produced-by":
+		sources, err := industry.ProducedBy(db, typeID)
+		if err != nil {
+			log.Fatalf("Lookup failed: %v", err)
+		}
+		result = sources
+		if !*asJSON {
+			printSources(name, typeID, sources)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			log.Fatalf("Failed to encode result: %v", err)
+		}
+	}
+}
+
+// printUsages gibt die Verwendungen eines Materials als Tabelle aus
+func printUsages(name string, typeID int64, usages []industry.Usage) {
+	fmt.Printf("%s (%d) is used in %d blueprint activities\n\n", name, typeID, len(usages))
+	if len(usages) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTIVITY\tQUANTITY\tBLUEPRINT\tPRODUCT")
+	for _, u := range usages {
+		product := "-"
+		if u.ProductTypeID != 0 {
+			product = fmt.Sprintf("%s (%d)", u.ProductName, u.ProductTypeID)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s (%d)\t%s\n", u.Activity, u.Quantity, u.BlueprintName, u.BlueprintTypeID, product)
+	}
+	w.Flush()
+}
+
+// printSources gibt die herstellenden Blueprints eines Typs als Tabelle aus
+func printSources(name string, typeID int64, sources []industry.Source) {
+	fmt.Printf("%s (%d) is produced by %d blueprint activities\n\n", name, typeID, len(sources))
+	if len(sources) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTIVITY\tQUANTITY\tPROBABILITY\tBLUEPRINT")
+	for _, s := range sources {
+		probability := "-"
+		if s.Probability > 0 {
+			probability = fmt.Sprintf("%.0f%%", s.Probability*100)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s (%d)\n", s.Activity, s.Quantity, probability, s.BlueprintName, s.BlueprintTypeID)
+	}
+	w.Flush()
+}
@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 )
 
+// MaxEnumValues ist die maximale Anzahl unterschiedlicher Werte, bis zu der
+// ein String-Feld als Enum erkannt wird
+const MaxEnumValues = 32
+
 // Schema repräsentiert ein analysiertes JSONL-Schema
 type Schema struct {
 	Fields map[string]*FieldInfo
@@ -18,6 +23,49 @@ type FieldInfo struct {
 	IsRequired   bool
 	IsLocalized  bool
 	SampleValues []interface{}
+	// EnumValues enthält die sortierten Werte eines Low-Cardinality String-Felds
+	// (nil = kein Enum)
+	EnumValues []string
+}
+
+// enumCandidate sammelt die Werte eines String-Felds über die gesamte Datei
+type enumCandidate struct {
+	counts       map[string]int
+	occurrences  int
+	disqualified bool
+}
+
+// observe registriert einen Wert; Nicht-Strings oder zu viele Werte disqualifizieren
+func (c *enumCandidate) observe(value interface{}) {
+	if c.disqualified || value == nil {
+		return
+	}
+	s, ok := value.(string)
+	if !ok {
+		c.disqualified = true
+		return
+	}
+	c.counts[s]++
+	c.occurrences++
+	if len(c.counts) > MaxEnumValues {
+		c.disqualified = true
+		c.counts = nil
+	}
+}
+
+// values liefert die Enum-Werte, falls das Feld als Enum gilt.
+// Jeder Wert muss im Schnitt mindestens zweimal vorkommen, sonst handelt es
+// sich eher um Namen/Bezeichner als um eine Werteliste.
+func (c *enumCandidate) values() []string {
+	if c.disqualified || len(c.counts) == 0 || c.occurrences < 2*len(c.counts) {
+		return nil
+	}
+	values := make([]string, 0, len(c.counts))
+	for v := range c.counts {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
 }
 
 // AnalyzeJSONL analysiert eine JSONL-Datei und extrahiert Schema-Informationen
@@ -34,15 +82,34 @@ func AnalyzeJSONL(path string, maxLines int) (*Schema, error) {
 
 	scanner := bufio.NewScanner(file)
 	lineCount := 0
+	enums := make(map[string]*enumCandidate)
 
-	for scanner.Scan() && lineCount < maxLines {
-		lineCount++
+	for scanner.Scan() {
+		// Typ-Analyse nur für die ersten maxLines Zeilen, Enum-Erkennung über alle
+		inSample := lineCount < maxLines
+		if inSample {
+			lineCount++
+		}
 
 		var data map[string]interface{}
 		if err := json.Unmarshal(scanner.Bytes(), &data); err != nil {
 			continue // Skip fehlerhafte Zeilen
 		}
 
+		// Enum-Kandidaten über die gesamte Datei sammeln
+		for key, value := range data {
+			c, exists := enums[key]
+			if !exists {
+				c = &enumCandidate{counts: make(map[string]int)}
+				enums[key] = c
+			}
+			c.observe(value)
+		}
+
+		if !inSample {
+			continue
+		}
+
 		// Analysiere jedes Feld
 		for key, value := range data {
 			field, exists := schema.Fields[key]
@@ -112,6 +179,16 @@ func AnalyzeJSONL(path string, maxLines int) (*Schema, error) {
 		}
 	}
 
+	// Low-Cardinality String-Felder als Enum markieren
+	for key, field := range schema.Fields {
+		if key == "_key" || field.GoType != "string" || field.IsLocalized {
+			continue
+		}
+		if c, ok := enums[key]; ok {
+			field.EnumValues = c.values()
+		}
+	}
+
 	return schema, nil
 }
 
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -84,3 +85,35 @@ func toSnakeCase(s string) string {
 	}
 	return strings.ToLower(string(result))
 }
+
+// EnumConstant beschreibt eine generierte Enum-Konstante
+type EnumConstant struct {
+	Name  string
+	Value string
+}
+
+// EnumConstants erzeugt eindeutige Go-Konstantennamen für Enum-Werte
+// MapSolarSystemsSecurityClass + "B1" → MapSolarSystemsSecurityClassB1
+func EnumConstants(typeName string, values []string) []EnumConstant {
+	constants := make([]EnumConstant, 0, len(values))
+	used := make(map[string]bool, len(values))
+
+	for i, value := range values {
+		suffix := ToCamelCase(nonIdentChars.ReplaceAllString(value, "_"), true)
+		if suffix == "" {
+			suffix = fmt.Sprintf("Value%d", i)
+		}
+
+		name := typeName + suffix
+		if used[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+		used[name] = true
+
+		constants = append(constants, EnumConstant{Name: name, Value: value})
+	}
+	return constants
+}
+
+// nonIdentChars matcht Zeichen, die in Go-Bezeichnern nicht erlaubt sind
+var nonIdentChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
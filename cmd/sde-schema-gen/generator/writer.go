@@ -45,6 +45,23 @@ type {{ .TypeName }} struct {
 	{{ .Name }} {{ .Type }} ` + "`json:\"{{ .JSONTag }}{{ if not .Required }},omitempty{{ end }}\"`" + `
 {{- end }}
 }
+{{- range $enum := .Enums }}
+
+// {{ $enum.TypeName }} enumerates the values of {{ $.SourceFile }} field {{ $enum.JSONTag }}
+type {{ $enum.TypeName }} string
+
+// {{ $enum.TypeName }} values
+const (
+{{- range $enum.Constants }}
+	{{ .Name }} {{ $enum.TypeName }} = {{ printf "%q" .Value }}
+{{- end }}
+)
+
+// Values returns all known values of {{ $enum.TypeName }}
+func ({{ $enum.TypeName }}) Values() []string {
+	return []string{ {{- range $i, $c := $enum.Constants }}{{ if $i }}, {{ end }}{{ printf "%q" $c.Value }}{{ end -}} }
+}
+{{- end }}
 `
 
 	// Bereite Template-Daten vor
@@ -55,11 +72,18 @@ type {{ .TypeName }} struct {
 		Required bool
 	}
 
+	type EnumData struct {
+		TypeName  string
+		JSONTag   string
+		Constants []EnumConstant
+	}
+
 	type TemplateData struct {
 		TypeName         string
 		SourceFile       string
 		HasLocalizedText bool
 		Fields           []FieldData
+		Enums            []EnumData
 	}
 
 	data := TemplateData{
@@ -88,6 +112,18 @@ type {{ .TypeName }} struct {
 			goType = "LocalizedText"
 		}
 
+		// Low-Cardinality Strings als typisiertes Enum
+		if len(field.EnumValues) > 0 {
+			enumName := typeName + goName
+			goType = enumName
+
+			data.Enums = append(data.Enums, EnumData{
+				TypeName:  enumName,
+				JSONTag:   jsonName,
+				Constants: EnumConstants(enumName, field.EnumValues),
+			})
+		}
+
 		// _key ist immer required (primary key)
 		isRequired := field.IsRequired || jsonName == "_key"
 
@@ -20,6 +20,7 @@ func main() {
 		dataDir     = flag.String("data", "data", "Data directory (contains jsonl/, yaml/, sqlite/)")
 		forceUpdate = flag.Bool("force", false, "Force update even if current")
 		skipImport  = flag.Bool("skip-import", false, "Skip SQLite import (download + schema-gen only)")
+		strict      = flag.Bool("strict", false, "Create STRICT tables during import")
 		showVersion = flag.Bool("version", false, "Show version")
 		verbose     = flag.Bool("v", false, "Verbose output")
 	)
@@ -88,6 +89,9 @@ func main() {
 		if *verbose {
 			args = append(args, "-v")
 		}
+		if *strict {
+			args = append(args, "--strict")
+		}
 		if err := runCommand("go", args, *verbose); err != nil {
 			log.Fatalf("Failed to import SQLite: %v", err)
 		}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"flag"
 	"fmt"
 	"log"
@@ -11,7 +12,12 @@ import (
 
 	"github.com/Sternrassler/eve-sde/internal/schema/types"
 	sdeversion "github.com/Sternrassler/eve-sde/internal/sde/version"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/blueprints"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/graph"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/hubs"
 	"github.com/Sternrassler/eve-sde/internal/sqlite/importer"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/migrate"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/planetary"
 	"github.com/Sternrassler/eve-sde/internal/sqlite/schema"
 	"github.com/Sternrassler/eve-sde/internal/sqlite/views"
 )
@@ -23,50 +29,68 @@ type SchemaMapping struct {
 	Name       string
 	JSONLFile  string
 	StructType reflect.Type
-	Indices    []string
+	Indices    []schema.Index
 }
 
 var schemaMappings = []SchemaMapping{
 	{"agentTypes", "agentTypes.jsonl", reflect.TypeOf(types.AgentTypes{}), nil},
-	{"ancestries", "ancestries.jsonl", reflect.TypeOf(types.Ancestries{}), []string{"bloodlineID"}},
-	{"bloodlines", "bloodlines.jsonl", reflect.TypeOf(types.Bloodlines{}), []string{"raceID"}},
-	{"blueprints", "blueprints.jsonl", reflect.TypeOf(types.Blueprints{}), []string{"blueprintTypeID"}},
+	{"ancestries", "ancestries.jsonl", reflect.TypeOf(types.Ancestries{}), schema.Indices("bloodlineID")},
+	{"bloodlines", "bloodlines.jsonl", reflect.TypeOf(types.Bloodlines{}), schema.Indices("raceID")},
+	{"blueprints", "blueprints.jsonl", reflect.TypeOf(types.Blueprints{}), schema.Indices("blueprintTypeID")},
 	{"categories", "categories.jsonl", reflect.TypeOf(types.Categories{}), nil},
-	{"certificates", "certificates.jsonl", reflect.TypeOf(types.Certificates{}), []string{"groupID"}},
+	{"certificates", "certificates.jsonl", reflect.TypeOf(types.Certificates{}), schema.Indices("groupID")},
 	{"characterAttributes", "characterAttributes.jsonl", reflect.TypeOf(types.CharacterAttributes{}), nil},
-	{"contrabandTypes", "contrabandTypes.jsonl", reflect.TypeOf(types.ContrabandTypes{}), []string{"factionID"}},
-	{"controlTowerResources", "controlTowerResources.jsonl", reflect.TypeOf(types.ControlTowerResources{}), []string{"controlTowerTypeID"}},
+	{"contrabandTypes", "contrabandTypes.jsonl", reflect.TypeOf(types.ContrabandTypes{}), nil},
+	{"controlTowerResources", "controlTowerResources.jsonl", reflect.TypeOf(types.ControlTowerResources{}), nil},
 	{"corporationActivities", "corporationActivities.jsonl", reflect.TypeOf(types.CorporationActivities{}), nil},
 	{"dogmaAttributeCategories", "dogmaAttributeCategories.jsonl", reflect.TypeOf(types.DogmaAttributeCategories{}), nil},
-	{"dogmaAttributes", "dogmaAttributes.jsonl", reflect.TypeOf(types.DogmaAttributes{}), []string{"categoryID"}},
+	{"dogmaAttributes", "dogmaAttributes.jsonl", reflect.TypeOf(types.DogmaAttributes{}), schema.Indices("attributeCategoryID")},
 	{"dogmaEffects", "dogmaEffects.jsonl", reflect.TypeOf(types.DogmaEffects{}), nil},
-	{"factions", "factions.jsonl", reflect.TypeOf(types.Factions{}), []string{"solarSystemID"}},
+	{"factions", "factions.jsonl", reflect.TypeOf(types.Factions{}), schema.Indices("solarSystemID")},
 	{"graphics", "graphics.jsonl", reflect.TypeOf(types.Graphics{}), nil},
-	{"groups", "groups.jsonl", reflect.TypeOf(types.Groups{}), []string{"categoryID"}},
+	{"groups", "groups.jsonl", reflect.TypeOf(types.Groups{}), schema.Indices("categoryID")},
 	{"icons", "icons.jsonl", reflect.TypeOf(types.Icons{}), nil},
-	{"marketGroups", "marketGroups.jsonl", reflect.TypeOf(types.MarketGroups{}), []string{"parentGroupID"}},
+	{"marketGroups", "marketGroups.jsonl", reflect.TypeOf(types.MarketGroups{}), schema.Indices("parentGroupID")},
 	{"metaGroups", "metaGroups.jsonl", reflect.TypeOf(types.MetaGroups{}), nil},
-	{"npcCorporationDivisions", "npcCorporationDivisions.jsonl", reflect.TypeOf(types.NpcCorporationDivisions{}), []string{"corporationID"}},
-	{"npcCorporations", "npcCorporations.jsonl", reflect.TypeOf(types.NpcCorporations{}), []string{"factionID"}},
+	{"npcCorporationDivisions", "npcCorporationDivisions.jsonl", reflect.TypeOf(types.NpcCorporationDivisions{}), nil},
+	{"npcCorporations", "npcCorporations.jsonl", reflect.TypeOf(types.NpcCorporations{}), schema.Indices("factionID")},
+	{"planetResources", "planetResources.jsonl", reflect.TypeOf(types.PlanetResources{}), nil},
 	{"planetSchematics", "planetSchematics.jsonl", reflect.TypeOf(types.PlanetSchematics{}), nil},
 	{"races", "races.jsonl", reflect.TypeOf(types.Races{}), nil},
-	{"skinLicenses", "skinLicenses.jsonl", reflect.TypeOf(types.SkinLicenses{}), []string{"skinID"}},
-	{"skinMaterials", "skinMaterials.jsonl", reflect.TypeOf(types.SkinMaterials{}), []string{"skinID"}},
+	{"skinLicenses", "skinLicenses.jsonl", reflect.TypeOf(types.SkinLicenses{}), schema.Indices("skinID")},
+	{"skinMaterials", "skinMaterials.jsonl", reflect.TypeOf(types.SkinMaterials{}), schema.Indices("materialSetID")},
 	{"skins", "skins.jsonl", reflect.TypeOf(types.Skins{}), nil},
 	{"stationOperations", "stationOperations.jsonl", reflect.TypeOf(types.StationOperations{}), nil},
 	{"stationServices", "stationServices.jsonl", reflect.TypeOf(types.StationServices{}), nil},
 	{"translationLanguages", "translationLanguages.jsonl", reflect.TypeOf(types.TranslationLanguages{}), nil},
-	{"typeDogma", "typeDogma.jsonl", reflect.TypeOf(types.TypeDogma{}), []string{"typeID"}},
-	{"typeMaterials", "typeMaterials.jsonl", reflect.TypeOf(types.TypeMaterials{}), []string{"typeID", "materialTypeID"}},
-	{"types", "types.jsonl", reflect.TypeOf(types.Types{}), []string{"groupID", "marketGroupID"}},
+	{"typeDogma", "typeDogma.jsonl", reflect.TypeOf(types.TypeDogma{}), nil},
+	{"typeMaterials", "typeMaterials.jsonl", reflect.TypeOf(types.TypeMaterials{}), nil},
+	{"types", "types.jsonl", reflect.TypeOf(types.Types{}), []schema.Index{
+		{Columns: []string{"groupID"}},
+		{Columns: []string{"marketGroupID"}},
+		{Columns: []string{"groupID"}, Where: "published = 1"},
+		{Name: "idx_types_name_en", Columns: []string{"json_extract(name, '$.en')"}},
+	}},
 	{"dogmaUnits", "dogmaUnits.jsonl", reflect.TypeOf(types.DogmaUnits{}), nil},
-	{"mapConstellations", "mapConstellations.jsonl", reflect.TypeOf(types.MapConstellations{}), []string{"regionID"}},
-	{"mapMoons", "mapMoons.jsonl", reflect.TypeOf(types.MapMoons{}), []string{"solarSystemID"}},
-	{"mapPlanets", "mapPlanets.jsonl", reflect.TypeOf(types.MapPlanets{}), []string{"solarSystemID"}},
+	{"mapConstellations", "mapConstellations.jsonl", reflect.TypeOf(types.MapConstellations{}), schema.Indices("regionID")},
+	{"mapMoons", "mapMoons.jsonl", reflect.TypeOf(types.MapMoons{}), schema.Indices("solarSystemID")},
+	{"mapPlanets", "mapPlanets.jsonl", reflect.TypeOf(types.MapPlanets{}), schema.Indices("solarSystemID")},
 	{"mapRegions", "mapRegions.jsonl", reflect.TypeOf(types.MapRegions{}), nil},
-	{"mapSolarSystems", "mapSolarSystems.jsonl", reflect.TypeOf(types.MapSolarSystems{}), []string{"constellationID", "securityClass"}},
-	{"mapStargates", "mapStargates.jsonl", reflect.TypeOf(types.MapStargates{}), []string{"solarSystemID", "destination"}},
-	{"npcStations", "npcStations.jsonl", reflect.TypeOf(types.NpcStations{}), []string{"solarSystemID", "typeID"}},
+	{"mapSolarSystems", "mapSolarSystems.jsonl", reflect.TypeOf(types.MapSolarSystems{}), []schema.Index{
+		{Columns: []string{"constellationID"}},
+		{Columns: []string{"securityClass"}},
+		{Columns: []string{"securityZone"}},
+		{Name: "idx_mapSolarSystems_name_en", Columns: []string{"json_extract(name, '$.en')"}},
+	}},
+	{"mapStargates", "mapStargates.jsonl", reflect.TypeOf(types.MapStargates{}), []schema.Index{
+		{Columns: []string{"solarSystemID"}},
+		{Name: "idx_mapStargates_destination_system", Columns: []string{"json_extract(destination, '$.solarSystemID')"}},
+	}},
+	{"mapStars", "mapStars.jsonl", reflect.TypeOf(types.MapStars{}), schema.Indices("solarSystemID")},
+	{"npcStations", "npcStations.jsonl", reflect.TypeOf(types.NpcStations{}), []schema.Index{
+		{Columns: []string{"solarSystemID", "typeID"}},
+		{Columns: []string{"typeID"}},
+	}},
 	{"_sde", "_sde.jsonl", reflect.TypeOf(types.SDE{}), nil},
 }
 
@@ -80,6 +104,14 @@ func main() {
 		showVersion   = flag.Bool("version", false, "Show version")
 		checkVersion  = flag.Bool("check-version", false, "Check for SDE updates and exit")
 		skipIfCurrent = flag.Bool("skip-if-current", false, "Skip import if database is up-to-date")
+		relationsDoc  = flag.String("relations-doc", "", "Write relationship graph document to path and exit")
+		verify        = flag.Bool("verify", true, "Verify row counts and content hashes against source JSONL")
+		strict        = flag.Bool("strict", false, "Create STRICT tables with type, JSON and boolean CHECK constraints")
+		jumpTables    = flag.Bool("jump-tables", true, "Precompute all-pairs k-space jump distances after map imports")
+		graphAnalysis = flag.Bool("graph-analysis", true, "Compute chokepoints, bridges, betweenness and security gateways after map imports")
+		jumpRangeLY   = flag.Float64("jump-range-ly", graph.DefaultMaxRangeLY, "Max light-year distance for the jump drive range table (0 = disabled)")
+		hubsFile      = flag.String("hubs", "", "Trade hub definitions (YAML or JSON), default: Jita, Amarr, Dodixie, Rens, Hek")
+		materialize   = flag.String("materialize", "", "Materialize views into mv_* tables (\"default\" = annotated views, or comma-separated names)")
 	)
 	flag.Parse()
 
@@ -88,6 +120,14 @@ func main() {
 		return
 	}
 
+	if *relationsDoc != "" {
+		if err := writeRelationsDoc(*relationsDoc); err != nil {
+			log.Fatalf("Failed to write relationship graph: %v", err)
+		}
+		log.Printf("✓ Relationship graph written to %s", *relationsDoc)
+		return
+	}
+
 	log.Printf("EVE SDE to SQLite Converter v%s", appVersion)
 
 	// Version Check
@@ -130,7 +170,7 @@ func main() {
 	}
 
 	// Initialisiere Schema
-	if err := initializeSchema(*dbPath); err != nil {
+	if err := initializeSchema(*dbPath, *strict); err != nil {
 		log.Fatalf("Failed to initialize schema: %v", err)
 	}
 	log.Println("✓ Schema initialized")
@@ -161,46 +201,146 @@ func main() {
 		jsonlPath := filepath.Join(*jsonlDir, mapping.JSONLFile)
 		log.Printf("Importing %s from %s...", mapping.Name, mapping.JSONLFile)
 
-		if err := imp.ImportJSONL(mapping.Name, jsonlPath, mapping.StructType); err != nil {
+		stats, err := imp.ImportJSONLWithStats(mapping.Name, jsonlPath, mapping.StructType)
+		if err != nil {
 			log.Fatalf("Failed to import %s: %v", mapping.Name, err)
 		}
 
 		log.Printf("✓ Imported %s", mapping.Name)
+
+		if *verify {
+			v, err := imp.VerifyImport(mapping.Name, mapping.StructType, stats)
+			if err != nil {
+				log.Fatalf("Failed to verify %s: %v", mapping.Name, err)
+			}
+			if !v.OK() {
+				log.Fatalf("Verification failed: %s", v)
+			}
+			log.Printf("✓ Verified %s (%d rows)", mapping.Name, v.RowCount)
+		}
 	}
 
-	// Initialize navigation views if we imported map data
-	if *importTable == "" || strings.HasPrefix(*importTable, "map") {
-		log.Println("Initializing navigation views...")
-		if err := views.InitializeNavigationViews(imp.DB()); err != nil {
-			log.Printf("Warning: Failed to initialize navigation views: %v", err)
-		} else {
-			log.Println("✓ Navigation views initialized")
+	// Trade Hub Registry (vor den Views, v_trade_hubs liest trade_hubs)
+	hubDefs := hubs.Default()
+	if *hubsFile != "" {
+		if hubDefs, err = hubs.Load(*hubsFile); err != nil {
+			log.Fatalf("Failed to load trade hubs: %v", err)
 		}
 	}
+	if hubDefs, err = hubs.WriteHubs(imp.DB(), hubDefs); err != nil {
+		log.Fatalf("Failed to write trade hubs: %v", err)
+	}
+	log.Printf("✓ Trade hubs: %d", len(hubDefs))
 
-	// Initialize cargo views if we imported types/dogma data
-	if *importTable == "" || *importTable == "types" || *importTable == "typeDogma" {
-		log.Println("Initializing cargo views...")
-		if err := views.InitializeCargoViews(imp.DB()); err != nil {
-			log.Printf("Warning: Failed to initialize cargo views: %v", err)
-		} else {
-			log.Println("✓ Cargo views initialized")
+	// Views neu erstellen: alle bei Vollimport, sonst nur die betroffenen
+	registry, err := views.DefaultRegistry()
+	if err != nil {
+		log.Fatalf("Failed to load views: %v", err)
+	}
+
+	imported := make([]string, len(schemasToImport))
+	for i, mapping := range schemasToImport {
+		imported[i] = mapping.Name
+	}
+	imported = append(imported, hubs.HubsTable)
+
+	// Blueprint Reverse-Lookup (vor den Views, v_material_usage/v_product_sources lesen die Tabellen);
+	// immer neu aufgebaut, damit die Tabellen auch nach einem Teilimport in eine neue Datenbank existieren
+	bpStats, err := blueprints.BuildLookupTables(imp.DB())
+	if err != nil {
+		log.Fatalf("Failed to build blueprint lookup tables: %v", err)
+	}
+	log.Printf("✓ Blueprint lookup: %d material usages, %d product sources", bpStats.Usages, bpStats.Sources)
+	imported = append(imported, blueprints.UsageTable, blueprints.SourcesTable)
+
+	// PI-Tabellen (Schematic-Inputs/-Outputs, Pins, Rohstoffe je Planetentyp) für die Planetary-Views
+	piStats, err := planetary.BuildTables(imp.DB(), planetary.DefaultResources())
+	if err != nil {
+		log.Fatalf("Failed to build PI tables: %v", err)
+	}
+	log.Printf("✓ PI tables: %d schematic types, %d schematic pins, %d planet resources",
+		piStats.SchematicTypes, piStats.SchematicPins, piStats.Resources)
+	imported = append(imported, planetary.SchematicTypesTable, planetary.SchematicPinsTable, planetary.ResourcesTable)
+
+	rebuilt, err := registry.Rebuild(imp.DB(), imported...)
+	if err != nil {
+		log.Fatalf("Failed to rebuild views: %v", err)
+	}
+	log.Printf("✓ Rebuilt %d views", len(rebuilt))
+
+	if *jumpTables && containsAny(imported, "mapSolarSystems", "mapStargates") {
+		log.Println("Building jump distance tables...")
+		stats, err := graph.BuildJumpTables(imp.DB())
+		if err != nil {
+			log.Fatalf("Failed to build jump tables: %v", err)
+		}
+		log.Printf("✓ Jump tables: %d systems, %d connections, %d policies (%d bytes)",
+			stats.Systems, stats.Edges, stats.Policies, stats.Bytes)
+
+		log.Println("Building trade hub distances...")
+		hubRows, err := hubs.BuildDistances(imp.DB())
+		if err != nil {
+			log.Fatalf("Failed to build trade hub distances: %v", err)
+		}
+		log.Printf("✓ Trade hub distances: %d rows", hubRows)
+	}
+
+	if *graphAnalysis && containsAny(imported, "mapSolarSystems", "mapStargates") {
+		log.Println("Building graph analysis tables...")
+		stats, err := graph.BuildAnalysisTables(imp.DB())
+		if err != nil {
+			log.Fatalf("Failed to build graph analysis tables: %v", err)
+		}
+		log.Printf("✓ Graph analysis: %d articulation points, %d bridges, %d gateways",
+			stats.ArticulationPoints, stats.Bridges, stats.Gateways)
+	}
+
+	if *jumpRangeLY > 0 && containsAny(imported, "mapSolarSystems") {
+		log.Println("Building jump drive range table...")
+		pairs, err := graph.BuildJumpRangeTable(imp.DB(), *jumpRangeLY)
+		if err != nil {
+			log.Fatalf("Failed to build jump range table: %v", err)
+		}
+		log.Printf("✓ Jump ranges: %d system pairs within %.1f LY", pairs, *jumpRangeLY)
+	}
+
+	if *materialize != "" {
+		if err := materializeViews(imp.DB(), registry, *materialize); err != nil {
+			log.Fatalf("Failed to materialize views: %v", err)
 		}
 	}
 
 	log.Println("✓ Import completed successfully")
 }
 
-// initializeSchema erstellt DB-Schema
-func initializeSchema(dbPath string) error {
+// initializeSchema erstellt bzw. migriert das DB-Schema
+func initializeSchema(dbPath string, strict bool) error {
 	imp, err := importer.NewImporter(dbPath)
 	if err != nil {
 		return err
 	}
 	defer imp.Close()
 
-	gen := schema.NewGenerator()
+	gen := newGenerator()
+	gen.Strict = strict
+
+	// Bestehende Tabellen an das aktuelle Schema angleichen
+	tables := make([]migrate.Table, len(schemaMappings))
+	for i, mapping := range schemaMappings {
+		tables[i] = migrate.Table{Name: mapping.Name, StructType: mapping.StructType}
+	}
+	report, err := migrate.Migrate(imp.DB(), gen, tables)
+	if err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+	for _, m := range report.Applied {
+		if m.Kind != migrate.KindCreate {
+			log.Printf("  Migrated %s", m)
+		}
+	}
+	log.Printf("  Schema version %d (%s)", report.Version, report.SchemaHash[:12])
 
+	// Indices (Tabellen existieren bereits, CREATE … IF NOT EXISTS)
 	for _, mapping := range schemaMappings {
 		statements, err := gen.GenerateSchema(mapping.Name, mapping.StructType, mapping.Indices)
 		if err != nil {
@@ -217,6 +357,71 @@ func initializeSchema(dbPath string) error {
 	return nil
 }
 
+// containsAny prüft ob eine der Tabellen importiert wurde
+func containsAny(imported []string, tables ...string) bool {
+	for _, name := range imported {
+		for _, t := range tables {
+			if name == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// materializeViews schreibt Snapshots der ausgewählten Views ("default" = annotierte Views)
+func materializeViews(db *sql.DB, registry *views.Registry, spec string) error {
+	var names []string
+	if spec == "default" {
+		var err error
+		if names, err = registry.Materializable(); err != nil {
+			return err
+		}
+	} else {
+		for _, name := range strings.Split(spec, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	snapshots, err := registry.Materialize(db, names...)
+	if err != nil {
+		return err
+	}
+	for _, s := range snapshots {
+		log.Printf("✓ Materialized %s → %s (%d rows)", s.View, s.Table, s.Rows)
+	}
+	return nil
+}
+
+// newGenerator erstellt den Schema-Generator mit allen bekannten Tabellen
+func newGenerator() *schema.Generator {
+	gen := schema.NewGenerator()
+	for _, mapping := range schemaMappings {
+		gen.Tables = append(gen.Tables, mapping.Name)
+	}
+	return gen
+}
+
+// writeRelationsDoc schreibt den Beziehungsgraphen aller Tabellen als Markdown
+func writeRelationsDoc(path string) error {
+	gen := newGenerator()
+
+	var refs []schema.Reference
+	for _, mapping := range schemaMappings {
+		refs = append(refs, gen.References(mapping.Name, mapping.StructType)...)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return schema.WriteRelationshipGraph(file, refs)
+}
+
 // filterSchemas filtert Schemas nach Name
 func filterSchemas(all []SchemaMapping, name string) []SchemaMapping {
 	for _, s := range all {
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/validate"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const appVersion = "0.1.0"
+
+func main() {
+	var (
+		dbPath      = flag.String("db", "data/sqlite/eve-sde.db", "SQLite database path")
+		failOn      = flag.String("fail-on", "error", "Lowest severity that fails the run (info, warning, error, none)")
+		samples     = flag.Int("samples", 5, "Number of sample keys per violated rule")
+		showVersion = flag.Bool("version", false, "Show version")
+	)
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("sde-validate v%s\n", appVersion)
+		return
+	}
+
+	log.Printf("EVE SDE Validator v%s", appVersion)
+
+	if _, err := os.Stat(*dbPath); err != nil {
+		log.Fatalf("Database not found: %s", *dbPath)
+	}
+
+	db, err := sql.Open("sqlite3", *dbPath+"?mode=ro")
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	fkRules, err := validate.ForeignKeyRules(db)
+	if err != nil {
+		log.Fatalf("Failed to derive foreign key rules: %v", err)
+	}
+	rules := append(fkRules, validate.DefaultRules()...)
+
+	results, err := validate.Run(db, rules, *samples)
+	if err != nil {
+		log.Fatalf("Validation failed: %v", err)
+	}
+
+	failed := report(results, *failOn)
+	if failed > 0 {
+		log.Fatalf("✗ %d rule(s) failed (fail-on: %s)", failed, *failOn)
+	}
+	log.Println("✓ Validation passed")
+}
+
+// report gibt alle Ergebnisse aus und liefert die Anzahl fehlgeschlagener Regeln
+func report(results []validate.Result, failOn string) int {
+	threshold, gate := validate.SeverityError, true
+	if failOn == "none" {
+		gate = false
+	} else if s, err := validate.ParseSeverity(failOn); err == nil {
+		threshold = s
+	} else {
+		log.Printf("Warning: %v, using error", err)
+	}
+
+	var passed, skipped, failed int
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			skipped++
+			log.Printf("  - [%s] %s: skipped (missing tables)", r.Rule.Severity, r.Rule.Name)
+		case r.Violations == 0:
+			passed++
+		default:
+			marker := "!"
+			if gate && r.Failed(threshold) {
+				marker = "✗"
+				failed++
+			}
+			log.Printf("  %s [%s] %s: %d violation(s) – %s", marker, r.Rule.Severity, r.Rule.Name, r.Violations, r.Rule.Description)
+			log.Printf("      samples: %s", strings.Join(r.Samples, ", "))
+		}
+	}
+
+	log.Printf("Rules: %d total, %d passed, %d with violations, %d skipped",
+		len(results), passed, len(results)-passed-skipped, skipped)
+	return failed
+}
@@ -2,4 +2,7 @@ module github.com/Sternrassler/eve-sde
 
 go 1.25.0
 
-require github.com/mattn/go-sqlite3 v1.14.32
+require (
+	github.com/mattn/go-sqlite3 v1.14.32
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -7,13 +7,63 @@ package types
 // DbuffCollections represents the schema for dbuffCollections.jsonl
 type DbuffCollections struct {
 	Key int64 `json:"_key"`
-	AggregateMode string `json:"aggregateMode,omitempty"`
+	AggregateMode DbuffCollectionsAggregateMode `json:"aggregateMode,omitempty"`
 	DeveloperDescription string `json:"developerDescription,omitempty"`
 	DisplayName LocalizedText `json:"displayName,omitempty"`
 	ItemModifiers []map[string]interface{} `json:"itemModifiers,omitempty"`
 	LocationGroupModifiers []map[string]interface{} `json:"locationGroupModifiers,omitempty"`
 	LocationModifiers []map[string]interface{} `json:"locationModifiers,omitempty"`
 	LocationRequiredSkillModifiers []map[string]interface{} `json:"locationRequiredSkillModifiers,omitempty"`
-	OperationName string `json:"operationName,omitempty"`
-	ShowOutputValueInUI string `json:"showOutputValueInUI,omitempty"`
+	OperationName DbuffCollectionsOperationName `json:"operationName,omitempty"`
+	ShowOutputValueInUI DbuffCollectionsShowOutputValueInUI `json:"showOutputValueInUI,omitempty"`
+}
+
+// DbuffCollectionsAggregateMode enumerates the values of dbuffCollections.jsonl field aggregateMode
+type DbuffCollectionsAggregateMode string
+
+// DbuffCollectionsAggregateMode values
+const (
+	DbuffCollectionsAggregateModeMaximum DbuffCollectionsAggregateMode = "Maximum"
+	DbuffCollectionsAggregateModeMinimum DbuffCollectionsAggregateMode = "Minimum"
+)
+
+// Values returns all known values of DbuffCollectionsAggregateMode
+func (DbuffCollectionsAggregateMode) Values() []string {
+	return []string{"Maximum", "Minimum"}
+}
+
+// DbuffCollectionsOperationName enumerates the values of dbuffCollections.jsonl field operationName
+type DbuffCollectionsOperationName string
+
+// DbuffCollectionsOperationName values
+const (
+	DbuffCollectionsOperationNameModAdd DbuffCollectionsOperationName = "ModAdd"
+	DbuffCollectionsOperationNameModSub DbuffCollectionsOperationName = "ModSub"
+	DbuffCollectionsOperationNamePostAssignment DbuffCollectionsOperationName = "PostAssignment"
+	DbuffCollectionsOperationNamePostDiv DbuffCollectionsOperationName = "PostDiv"
+	DbuffCollectionsOperationNamePostMul DbuffCollectionsOperationName = "PostMul"
+	DbuffCollectionsOperationNamePostPercent DbuffCollectionsOperationName = "PostPercent"
+	DbuffCollectionsOperationNamePreAssignment DbuffCollectionsOperationName = "PreAssignment"
+	DbuffCollectionsOperationNamePreDiv DbuffCollectionsOperationName = "PreDiv"
+	DbuffCollectionsOperationNamePreMul DbuffCollectionsOperationName = "PreMul"
+)
+
+// Values returns all known values of DbuffCollectionsOperationName
+func (DbuffCollectionsOperationName) Values() []string {
+	return []string{"ModAdd", "ModSub", "PostAssignment", "PostDiv", "PostMul", "PostPercent", "PreAssignment", "PreDiv", "PreMul"}
+}
+
+// DbuffCollectionsShowOutputValueInUI enumerates the values of dbuffCollections.jsonl field showOutputValueInUI
+type DbuffCollectionsShowOutputValueInUI string
+
+// DbuffCollectionsShowOutputValueInUI values
+const (
+	DbuffCollectionsShowOutputValueInUIHide DbuffCollectionsShowOutputValueInUI = "Hide"
+	DbuffCollectionsShowOutputValueInUIShowInverted DbuffCollectionsShowOutputValueInUI = "ShowInverted"
+	DbuffCollectionsShowOutputValueInUIShowNormal DbuffCollectionsShowOutputValueInUI = "ShowNormal"
+)
+
+// Values returns all known values of DbuffCollectionsShowOutputValueInUI
+func (DbuffCollectionsShowOutputValueInUI) Values() []string {
+	return []string{"Hide", "ShowInverted", "ShowNormal"}
 }
@@ -20,10 +20,51 @@ type MapSolarSystems struct {
 	Radius int64 `json:"radius,omitempty"`
 	RegionID int64 `json:"regionID,omitempty"`
 	Regional bool `json:"regional,omitempty"`
-	SecurityClass string `json:"securityClass,omitempty"`
+	SecurityClass MapSolarSystemsSecurityClass `json:"securityClass,omitempty"`
 	SecurityStatus float64 `json:"securityStatus,omitempty"`
 	StarID int64 `json:"starID,omitempty"`
 	StargateIDs []int64 `json:"stargateIDs,omitempty"`
 	VisualEffect string `json:"visualEffect,omitempty"`
 	WormholeClassID int64 `json:"wormholeClassID,omitempty"`
 }
+
+// MapSolarSystemsSecurityClass enumerates the values of mapSolarSystems.jsonl field securityClass
+type MapSolarSystemsSecurityClass string
+
+// MapSolarSystemsSecurityClass values
+const (
+	MapSolarSystemsSecurityClassA MapSolarSystemsSecurityClass = "A"
+	MapSolarSystemsSecurityClassB MapSolarSystemsSecurityClass = "B"
+	MapSolarSystemsSecurityClassB1 MapSolarSystemsSecurityClass = "B1"
+	MapSolarSystemsSecurityClassB2 MapSolarSystemsSecurityClass = "B2"
+	MapSolarSystemsSecurityClassB3 MapSolarSystemsSecurityClass = "B3"
+	MapSolarSystemsSecurityClassC MapSolarSystemsSecurityClass = "C"
+	MapSolarSystemsSecurityClassC1 MapSolarSystemsSecurityClass = "C1"
+	MapSolarSystemsSecurityClassC2 MapSolarSystemsSecurityClass = "C2"
+	MapSolarSystemsSecurityClassC3 MapSolarSystemsSecurityClass = "C3"
+	MapSolarSystemsSecurityClassD MapSolarSystemsSecurityClass = "D"
+	MapSolarSystemsSecurityClassD1 MapSolarSystemsSecurityClass = "D1"
+	MapSolarSystemsSecurityClassD2 MapSolarSystemsSecurityClass = "D2"
+	MapSolarSystemsSecurityClassD3 MapSolarSystemsSecurityClass = "D3"
+	MapSolarSystemsSecurityClassE MapSolarSystemsSecurityClass = "E"
+	MapSolarSystemsSecurityClassE1 MapSolarSystemsSecurityClass = "E1"
+	MapSolarSystemsSecurityClassE2 MapSolarSystemsSecurityClass = "E2"
+	MapSolarSystemsSecurityClassE3 MapSolarSystemsSecurityClass = "E3"
+	MapSolarSystemsSecurityClassF MapSolarSystemsSecurityClass = "F"
+	MapSolarSystemsSecurityClassF1 MapSolarSystemsSecurityClass = "F1"
+	MapSolarSystemsSecurityClassF2 MapSolarSystemsSecurityClass = "F2"
+	MapSolarSystemsSecurityClassF3 MapSolarSystemsSecurityClass = "F3"
+	MapSolarSystemsSecurityClassG MapSolarSystemsSecurityClass = "G"
+	MapSolarSystemsSecurityClassG1 MapSolarSystemsSecurityClass = "G1"
+	MapSolarSystemsSecurityClassG2 MapSolarSystemsSecurityClass = "G2"
+	MapSolarSystemsSecurityClassH MapSolarSystemsSecurityClass = "H"
+	MapSolarSystemsSecurityClassH1 MapSolarSystemsSecurityClass = "H1"
+	MapSolarSystemsSecurityClassH2 MapSolarSystemsSecurityClass = "H2"
+	MapSolarSystemsSecurityClassH3 MapSolarSystemsSecurityClass = "H3"
+	MapSolarSystemsSecurityClassL MapSolarSystemsSecurityClass = "L"
+)
+
+// Values returns all known values of MapSolarSystemsSecurityClass
+func (MapSolarSystemsSecurityClass) Values() []string {
+	return []string{"A", "B", "B1", "B2", "B3", "C", "C1", "C2", "C3", "D", "D1", "D2", "D3", "E", "E1", "E2", "E3", "F", "F1", "F2", "F3", "G", "G1", "G2", "H", "H1", "H2", "H3", "L"}
+}
@@ -14,7 +14,7 @@ type NpcCorporations struct {
 	Description LocalizedText `json:"description,omitempty"`
 	Divisions []map[string]interface{} `json:"divisions,omitempty"`
 	EnemyID int64 `json:"enemyID,omitempty"`
-	Extent string `json:"extent,omitempty"`
+	Extent NpcCorporationsExtent `json:"extent,omitempty"`
 	FactionID int64 `json:"factionID,omitempty"`
 	FriendID int64 `json:"friendID,omitempty"`
 	HasPlayerPersonnelManager bool `json:"hasPlayerPersonnelManager,omitempty"`
@@ -31,7 +31,7 @@ type NpcCorporations struct {
 	SecondaryActivityID int64 `json:"secondaryActivityID,omitempty"`
 	SendCharTerminationMessage bool `json:"sendCharTerminationMessage,omitempty"`
 	Shares int64 `json:"shares,omitempty"`
-	Size string `json:"size,omitempty"`
+	Size NpcCorporationsSize `json:"size,omitempty"`
 	SizeFactor float64 `json:"sizeFactor,omitempty"`
 	SolarSystemID int64 `json:"solarSystemID,omitempty"`
 	StationID int64 `json:"stationID,omitempty"`
@@ -39,3 +39,37 @@ type NpcCorporations struct {
 	TickerName string `json:"tickerName,omitempty"`
 	UniqueName bool `json:"uniqueName,omitempty"`
 }
+
+// NpcCorporationsExtent enumerates the values of npcCorporations.jsonl field extent
+type NpcCorporationsExtent string
+
+// NpcCorporationsExtent values
+const (
+	NpcCorporationsExtentC NpcCorporationsExtent = "C"
+	NpcCorporationsExtentG NpcCorporationsExtent = "G"
+	NpcCorporationsExtentL NpcCorporationsExtent = "L"
+	NpcCorporationsExtentN NpcCorporationsExtent = "N"
+	NpcCorporationsExtentR NpcCorporationsExtent = "R"
+)
+
+// Values returns all known values of NpcCorporationsExtent
+func (NpcCorporationsExtent) Values() []string {
+	return []string{"C", "G", "L", "N", "R"}
+}
+
+// NpcCorporationsSize enumerates the values of npcCorporations.jsonl field size
+type NpcCorporationsSize string
+
+// NpcCorporationsSize values
+const (
+	NpcCorporationsSizeH NpcCorporationsSize = "H"
+	NpcCorporationsSizeL NpcCorporationsSize = "L"
+	NpcCorporationsSizeM NpcCorporationsSize = "M"
+	NpcCorporationsSizeS NpcCorporationsSize = "S"
+	NpcCorporationsSizeT NpcCorporationsSize = "T"
+)
+
+// Values returns all known values of NpcCorporationsSize
+func (NpcCorporationsSize) Values() []string {
+	return []string{"H", "L", "M", "S", "T"}
+}
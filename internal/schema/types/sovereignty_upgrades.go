@@ -10,7 +10,23 @@ type SovereigntyUpgrades struct {
 	FuelHourlyUpkeep int64 `json:"fuel_hourly_upkeep,omitempty"`
 	FuelStartupCost int64 `json:"fuel_startup_cost,omitempty"`
 	FuelTypeID int64 `json:"fuel_type_id,omitempty"`
-	MutuallyExclusiveGroup string `json:"mutually_exclusive_group,omitempty"`
+	MutuallyExclusiveGroup SovereigntyUpgradesMutuallyExclusiveGroup `json:"mutually_exclusive_group,omitempty"`
 	PowerAllocation int64 `json:"power_allocation,omitempty"`
 	WorkforceAllocation int64 `json:"workforce_allocation,omitempty"`
 }
+
+// SovereigntyUpgradesMutuallyExclusiveGroup enumerates the values of sovereigntyUpgrades.jsonl field mutually_exclusive_group
+type SovereigntyUpgradesMutuallyExclusiveGroup string
+
+// SovereigntyUpgradesMutuallyExclusiveGroup values
+const (
+	SovereigntyUpgradesMutuallyExclusiveGroupCyno SovereigntyUpgradesMutuallyExclusiveGroup = "cyno"
+	SovereigntyUpgradesMutuallyExclusiveGroupExplorationDetector SovereigntyUpgradesMutuallyExclusiveGroup = "exploration_detector"
+	SovereigntyUpgradesMutuallyExclusiveGroupMiningDetector SovereigntyUpgradesMutuallyExclusiveGroup = "mining_detector"
+	SovereigntyUpgradesMutuallyExclusiveGroupThreatDetection SovereigntyUpgradesMutuallyExclusiveGroup = "threat_detection"
+)
+
+// Values returns all known values of SovereigntyUpgradesMutuallyExclusiveGroup
+func (SovereigntyUpgradesMutuallyExclusiveGroup) Values() []string {
+	return []string{"cyno", "exploration_detector", "mining_detector", "threat_detection"}
+}
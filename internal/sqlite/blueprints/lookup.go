@@ -0,0 +1,84 @@
+// Package blueprints schreibt indizierte Reverse-Lookup-Tabellen aus blueprints.activities:
+// welche Blueprints ein Material verbrauchen und welche Blueprints einen Typ herstellen
+package blueprints
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Lookup-Tabellen
+const (
+	// UsageTable: Material → verbrauchende Blueprints je Aktivität
+	UsageTable = "blueprint_material_usage"
+	// SourcesTable: Produkt → herstellende Blueprints je Aktivität (inkl. Reaktionen und Invention)
+	SourcesTable = "blueprint_product_sources"
+)
+
+// LookupStats fasst die geschriebenen Lookup-Tabellen zusammen
+type LookupStats struct {
+	Usages  int
+	Sources int
+}
+
+// BuildLookupTables erstellt beide Lookup-Tabellen neu aus der blueprints-Tabelle
+func BuildLookupTables(db *sql.DB) (LookupStats, error) {
+	var stats LookupStats
+
+	tx, err := db.Begin()
+	if err != nil {
+		return stats, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + UsageTable,
+		`CREATE TABLE ` + UsageTable + ` (
+  material_type_id INTEGER NOT NULL,
+  activity TEXT NOT NULL,
+  blueprint_type_id INTEGER NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (material_type_id, activity, blueprint_type_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_blueprint_material_usage_blueprint ON ` + UsageTable + `(blueprint_type_id)`,
+		`INSERT INTO ` + UsageTable + ` (material_type_id, activity, blueprint_type_id, quantity)
+SELECT CAST(json_extract(m.value, '$.typeID') AS INTEGER), a.key, b._key, SUM(CAST(json_extract(m.value, '$.quantity') AS INTEGER))
+FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.materials') m
+WHERE json_extract(m.value, '$.typeID') IS NOT NULL
+GROUP BY 1, 2, 3`,
+
+		"DROP TABLE IF EXISTS " + SourcesTable,
+		`CREATE TABLE ` + SourcesTable + ` (
+  product_type_id INTEGER NOT NULL,
+  activity TEXT NOT NULL,
+  blueprint_type_id INTEGER NOT NULL,
+  quantity INTEGER NOT NULL,
+  probability REAL,
+  PRIMARY KEY (product_type_id, activity, blueprint_type_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_blueprint_product_sources_blueprint ON ` + SourcesTable + `(blueprint_type_id)`,
+		`INSERT INTO ` + SourcesTable + ` (product_type_id, activity, blueprint_type_id, quantity, probability)
+SELECT CAST(json_extract(p.value, '$.typeID') AS INTEGER), a.key, b._key,
+       MAX(CAST(json_extract(p.value, '$.quantity') AS INTEGER)), MAX(CAST(json_extract(p.value, '$.probability') AS REAL))
+FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.products') p
+WHERE json_extract(p.value, '$.typeID') IS NOT NULL
+GROUP BY 1, 2, 3`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return stats, fmt.Errorf("failed to build blueprint lookup tables: %w", err)
+		}
+	}
+
+	if err := tx.QueryRow("SELECT COUNT(*) FROM " + UsageTable).Scan(&stats.Usages); err != nil {
+		return stats, fmt.Errorf("failed to count %s: %w", UsageTable, err)
+	}
+	if err := tx.QueryRow("SELECT COUNT(*) FROM " + SourcesTable).Scan(&stats.Sources); err != nil {
+		return stats, fmt.Errorf("failed to count %s: %w", SourcesTable, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return stats, fmt.Errorf("failed to commit blueprint lookup tables: %w", err)
+	}
+	return stats, nil
+}
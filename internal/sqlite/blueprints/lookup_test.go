@@ -0,0 +1,57 @@
+package blueprints
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestBuildLookupTables(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "bp.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`
+		CREATE TABLE blueprints (_key INTEGER PRIMARY KEY, activities TEXT);
+		INSERT INTO blueprints VALUES
+			(681, '{"copying":{"materials":[],"time":480},"invention":{"materials":[{"quantity":2,"typeID":20410}],"products":[{"probability":0.3,"quantity":1,"typeID":11379}],"time":63900},"manufacturing":{"materials":[{"quantity":32000,"typeID":34},{"quantity":6000,"typeID":35}],"products":[{"quantity":1,"typeID":582}],"time":6000},"research_material":{"time":2100}}'),
+			(46166, '{"reaction":{"materials":[{"quantity":100,"typeID":16633},{"quantity":5,"typeID":4312}],"products":[{"quantity":200,"typeID":16670}],"time":10800}}'),
+			(1, '{}');
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+
+	stats, err := BuildLookupTables(db)
+	if err != nil {
+		t.Fatalf("BuildLookupTables failed: %v", err)
+	}
+	if stats.Usages != 5 || stats.Sources != 3 {
+		t.Errorf("stats = %+v, want 5 usages, 3 sources", stats)
+	}
+
+	var activity string
+	var quantity int64
+	if err := db.QueryRow(`SELECT activity, quantity FROM `+UsageTable+` WHERE material_type_id = 34`).Scan(&activity, &quantity); err != nil {
+		t.Fatalf("Query usage failed: %v", err)
+	}
+	if activity != "manufacturing" || quantity != 32000 {
+		t.Errorf("usage of 34 = %s/%d", activity, quantity)
+	}
+
+	var probability float64
+	if err := db.QueryRow(`SELECT probability FROM ` + SourcesTable + ` WHERE product_type_id = 11379 AND activity = 'invention'`).Scan(&probability); err != nil {
+		t.Fatalf("Query source failed: %v", err)
+	}
+	if probability != 0.3 {
+		t.Errorf("probability = %v, want 0.3", probability)
+	}
+
+	// Erneuter Aufbau ersetzt die Tabellen
+	if again, err := BuildLookupTables(db); err != nil || again != stats {
+		t.Errorf("rebuild = %+v, %v", again, err)
+	}
+}
@@ -0,0 +1,296 @@
+package graph
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+)
+
+// Tabellen der Netzwerkanalyse
+const (
+	// ArticulationPointsTable: Systeme, deren Ausfall das Netz (bzw. die Region) trennt
+	ArticulationPointsTable = "graph_articulation_points"
+	// BridgesTable: Stargate-Verbindungen, deren Ausfall das Netz (bzw. die Region) trennt
+	BridgesTable = "graph_bridges"
+	// BetweennessTable: Betweenness-Zentralität je System
+	BetweennessTable = "graph_betweenness"
+	// GatewaysTable: Stargate-Verbindungen zwischen unterschiedlichen Security-Zonen (inkl. Pochven)
+	GatewaysTable = "graph_gateways"
+)
+
+// GlobalScope kennzeichnet in region_id die Analyse des gesamten Netzes
+const GlobalScope = 0
+
+// Zone liefert die Security-Zone eines Systems (wie mapSolarSystems.securityZone)
+func (g *Graph) Zone(i int) security.Zone {
+	return security.Classify(g.Systems[i], g.Region[i], g.Security[i])
+}
+
+// Cut enthält Artikulationspunkte und Brücken eines (Teil-)Graphen als dichte Indizes
+type Cut struct {
+	// Points: Artikulationspunkt → Anzahl Komponenten, in die das Netz ohne ihn zerfällt
+	Points map[int32]int
+	// Bridges: Kanten (a < b)
+	Bridges [][2]int32
+}
+
+// Cut berechnet Artikulationspunkte und Brücken (Tarjan) auf dem von include induzierten Teilgraphen
+func (g *Graph) Cut(include func(int) bool) Cut {
+	n := len(g.Systems)
+	disc := make([]int32, n)
+	low := make([]int32, n)
+	cut := Cut{Points: make(map[int32]int)}
+	timer := int32(0)
+
+	var visit func(u, parent int32)
+	visit = func(u, parent int32) {
+		timer++
+		disc[u], low[u] = timer, timer
+		children := 0
+		split := 0
+
+		for _, v := range g.Adjacent[u] {
+			if !include(int(v)) || v == parent {
+				continue
+			}
+			if disc[v] != 0 {
+				if disc[v] < low[u] {
+					low[u] = disc[v]
+				}
+				continue
+			}
+
+			children++
+			visit(v, u)
+			if low[v] < low[u] {
+				low[u] = low[v]
+			}
+			if parent >= 0 && low[v] >= disc[u] {
+				split++
+			}
+			if low[v] > disc[u] {
+				a, b := u, v
+				if a > b {
+					a, b = b, a
+				}
+				cut.Bridges = append(cut.Bridges, [2]int32{a, b})
+			}
+		}
+
+		switch {
+		case parent < 0 && children > 1:
+			cut.Points[u] = children
+		case parent >= 0 && split > 0:
+			cut.Points[u] = split + 1
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if include(i) && disc[i] == 0 {
+			visit(int32(i), -1)
+		}
+	}
+
+	sort.Slice(cut.Bridges, func(i, j int) bool {
+		if cut.Bridges[i][0] != cut.Bridges[j][0] {
+			return cut.Bridges[i][0] < cut.Bridges[j][0]
+		}
+		return cut.Bridges[i][1] < cut.Bridges[j][1]
+	})
+	return cut
+}
+
+// Betweenness berechnet die Betweenness-Zentralität (Brandes, ungewichtet, ungerichtet)
+func (g *Graph) Betweenness() []float64 {
+	n := len(g.Systems)
+	bc := make([]float64, n)
+
+	sigma := make([]float64, n)
+	dist := make([]int32, n)
+	delta := make([]float64, n)
+	preds := make([][]int32, n)
+	stack := make([]int32, 0, n)
+	queue := make([]int32, 0, n)
+
+	for s := 0; s < n; s++ {
+		for i := range sigma {
+			sigma[i], dist[i], delta[i] = 0, -1, 0
+			preds[i] = preds[i][:0]
+		}
+		sigma[s], dist[s] = 1, 0
+		stack = stack[:0]
+		queue = append(queue[:0], int32(s))
+
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+			stack = append(stack, v)
+			for _, w := range g.Adjacent[v] {
+				if dist[w] < 0 {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					preds[w] = append(preds[w], v)
+				}
+			}
+		}
+
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if int(w) != s {
+				bc[w] += delta[w]
+			}
+		}
+	}
+
+	// Jedes Paar wurde in beide Richtungen gezählt
+	for i := range bc {
+		bc[i] /= 2
+	}
+	return bc
+}
+
+// AnalysisStats fasst die Netzwerkanalyse zusammen
+type AnalysisStats struct {
+	ArticulationPoints int
+	Bridges            int
+	Gateways           int
+}
+
+// BuildAnalysisTables berechnet Artikulationspunkte und Brücken (global und je Region),
+// Betweenness-Zentralität und Zonen-Übergänge und ersetzt die graph_* Tabellen
+func BuildAnalysisTables(db *sql.DB) (*AnalysisStats, error) {
+	g, err := LoadGraph(db)
+	if err != nil {
+		return nil, err
+	}
+	return WriteAnalysisTables(db, g)
+}
+
+// WriteAnalysisTables schreibt die Netzwerkanalyse eines Graphen
+func WriteAnalysisTables(db *sql.DB, g *Graph) (*AnalysisStats, error) {
+	stats := &AnalysisStats{}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + ArticulationPointsTable,
+		"DROP TABLE IF EXISTS " + BridgesTable,
+		"DROP TABLE IF EXISTS " + BetweennessTable,
+		"DROP TABLE IF EXISTS " + GatewaysTable,
+		`CREATE TABLE ` + ArticulationPointsTable + ` (
+  region_id INTEGER NOT NULL,
+  system_id INTEGER NOT NULL,
+  components INTEGER NOT NULL,
+  PRIMARY KEY (region_id, system_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_graph_articulation_points_system_id ON ` + ArticulationPointsTable + `(system_id)`,
+		`CREATE TABLE ` + BridgesTable + ` (
+  region_id INTEGER NOT NULL,
+  from_system_id INTEGER NOT NULL,
+  to_system_id INTEGER NOT NULL,
+  PRIMARY KEY (region_id, from_system_id, to_system_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_graph_bridges_to_system_id ON ` + BridgesTable + `(to_system_id)`,
+		`CREATE TABLE ` + BetweennessTable + ` (
+  system_id INTEGER PRIMARY KEY,
+  betweenness REAL NOT NULL,
+  normalized REAL NOT NULL,
+  rank INTEGER NOT NULL
+)`,
+		`CREATE TABLE ` + GatewaysTable + ` (
+  system_id INTEGER NOT NULL,
+  neighbor_system_id INTEGER NOT NULL,
+  from_zone TEXT NOT NULL,
+  to_zone TEXT NOT NULL,
+  PRIMARY KEY (system_id, neighbor_system_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_graph_gateways_zones ON ` + GatewaysTable + `(from_zone, to_zone)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to create analysis tables: %w", err)
+		}
+	}
+
+	// Artikulationspunkte und Brücken: global und je Region
+	scopes := map[int64]func(int) bool{GlobalScope: func(int) bool { return true }}
+	for _, region := range g.Region {
+		region := region
+		if _, ok := scopes[region]; !ok {
+			scopes[region] = func(i int) bool { return g.Region[i] == region }
+		}
+	}
+
+	for region, include := range scopes {
+		cut := g.Cut(include)
+		for p, components := range cut.Points {
+			if _, err := tx.Exec("INSERT INTO "+ArticulationPointsTable+" (region_id, system_id, components) VALUES (?, ?, ?)",
+				region, g.Systems[p], components); err != nil {
+				return nil, fmt.Errorf("failed to insert articulation point: %w", err)
+			}
+			stats.ArticulationPoints++
+		}
+		for _, b := range cut.Bridges {
+			if _, err := tx.Exec("INSERT INTO "+BridgesTable+" (region_id, from_system_id, to_system_id) VALUES (?, ?, ?)",
+				region, g.Systems[b[0]], g.Systems[b[1]]); err != nil {
+				return nil, fmt.Errorf("failed to insert bridge: %w", err)
+			}
+			stats.Bridges++
+		}
+	}
+
+	// Betweenness mit Rang (1 = zentralstes System)
+	bc := g.Betweenness()
+	order := make([]int, len(bc))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return bc[order[a]] > bc[order[b]] })
+
+	n := float64(len(bc))
+	norm := 1.0
+	if n > 2 {
+		norm = (n - 1) * (n - 2) / 2
+	}
+	for rank, i := range order {
+		if _, err := tx.Exec("INSERT INTO "+BetweennessTable+" (system_id, betweenness, normalized, rank) VALUES (?, ?, ?, ?)",
+			g.Systems[i], bc[i], bc[i]/norm, rank+1); err != nil {
+			return nil, fmt.Errorf("failed to insert betweenness: %w", err)
+		}
+	}
+
+	// Übergänge zwischen Security-Zonen (beide Richtungen)
+	zones := make([]security.Zone, len(g.Systems))
+	for i := range zones {
+		zones[i] = g.Zone(i)
+	}
+	for i, adj := range g.Adjacent {
+		for _, j := range adj {
+			from, to := zones[i], zones[j]
+			if from == to {
+				continue
+			}
+			if _, err := tx.Exec("INSERT INTO "+GatewaysTable+" (system_id, neighbor_system_id, from_zone, to_zone) VALUES (?, ?, ?, ?)",
+				g.Systems[i], g.Systems[j], string(from), string(to)); err != nil {
+				return nil, fmt.Errorf("failed to insert gateway: %w", err)
+			}
+			stats.Gateways++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit analysis tables: %w", err)
+	}
+	return stats, nil
+}
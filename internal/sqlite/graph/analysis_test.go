@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+)
+
+func TestCut(t *testing.T) {
+	g := NewGraph(fixtureNodes(), fixtureEdges)
+	idx := func(id int64) int32 {
+		i, _ := g.Index(id)
+		return int32(i)
+	}
+	edge := func(a, b int64) [2]int32 {
+		x, y := idx(a), idx(b)
+		if x > y {
+			x, y = y, x
+		}
+		return [2]int32{x, y}
+	}
+
+	tests := []struct {
+		name    string
+		include func(int) bool
+		points  map[int64]int
+		bridges [][2]int32
+	}{
+		{
+			name:    "global",
+			include: func(int) bool { return true },
+			points:  map[int64]int{30000003: 2},
+			bridges: [][2]int32{edge(30000003, 30000006), edge(30000007, 30000008)},
+		},
+		{
+			// Ohne 30000003 (andere Region) zerfällt der Ring in eine Kette 2-1-4-5
+			name:    "region 10000001",
+			include: func(i int) bool { return g.Region[i] == 10000001 },
+			points:  map[int64]int{30000001: 2, 30000004: 2},
+			bridges: [][2]int32{
+				edge(30000001, 30000002), edge(30000001, 30000004),
+				edge(30000004, 30000005), edge(30000007, 30000008),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cut := g.Cut(tt.include)
+
+			if len(cut.Points) != len(tt.points) {
+				t.Errorf("Points = %v, want %v", cut.Points, tt.points)
+			}
+			for id, components := range tt.points {
+				if got := cut.Points[idx(id)]; got != components {
+					t.Errorf("Points[%d] = %d, want %d", id, got, components)
+				}
+			}
+
+			if len(cut.Bridges) != len(tt.bridges) {
+				t.Fatalf("Bridges = %v, want %v", cut.Bridges, tt.bridges)
+			}
+			want := make(map[[2]int32]bool)
+			for _, b := range tt.bridges {
+				want[b] = true
+			}
+			for _, b := range cut.Bridges {
+				if !want[b] {
+					t.Errorf("unexpected bridge %d-%d", g.Systems[b[0]], g.Systems[b[1]])
+				}
+			}
+		})
+	}
+}
+
+func TestBetweenness(t *testing.T) {
+	g := NewGraph(fixtureNodes(), fixtureEdges)
+	bc := g.Betweenness()
+
+	// 30000003: Mittelpunkt von 2↔5 im Fünfer-Ring plus alle 4 Pfade von 30000006
+	want := map[int64]float64{
+		30000003: 5, 30000002: 2, 30000005: 2, 30000001: 1, 30000004: 1,
+		30000006: 0, 30000007: 0,
+	}
+	for id, w := range want {
+		i, _ := g.Index(id)
+		if math.Abs(bc[i]-w) > 1e-9 {
+			t.Errorf("betweenness[%d] = %v, want %v", id, bc[i], w)
+		}
+	}
+}
+
+func TestBuildAnalysisTables(t *testing.T) {
+	db := fixtureDB(t)
+
+	stats, err := BuildAnalysisTables(db)
+	if err != nil {
+		t.Fatalf("BuildAnalysisTables failed: %v", err)
+	}
+	// global: 1 Punkt, 2 Brücken; Region 10000001: 2 Punkte, 4 Brücken; Region 10000002: nichts
+	// Gateways: 1↔2, 2↔3, 3↔6 jeweils in beide Richtungen
+	if stats.ArticulationPoints != 3 || stats.Bridges != 6 || stats.Gateways != 6 {
+		t.Errorf("stats = %+v, want 3 points, 6 bridges, 6 gateways", stats)
+	}
+
+	var region, system int64
+	if err := db.QueryRow(`SELECT region_id, system_id FROM graph_articulation_points
+		WHERE region_id = ?`, GlobalScope).Scan(&region, &system); err != nil || system != 30000003 {
+		t.Errorf("global articulation point = %d, %v; want 30000003", system, err)
+	}
+
+	var from, to int64
+	if err := db.QueryRow(`SELECT from_system_id, to_system_id FROM graph_bridges
+		WHERE region_id = 0 AND from_system_id = 30000003`).Scan(&from, &to); err != nil || to != 30000006 {
+		t.Errorf("bridge = %d-%d, %v; want 30000003-30000006", from, to, err)
+	}
+
+	var top int64
+	var normalized float64
+	if err := db.QueryRow(`SELECT system_id, normalized FROM graph_betweenness WHERE rank = 1`).Scan(&top, &normalized); err != nil {
+		t.Fatalf("betweenness query failed: %v", err)
+	}
+	// 8 Systeme → 21 Paare
+	if top != 30000003 || math.Abs(normalized-5.0/21) > 1e-9 {
+		t.Errorf("rank 1 = %d (%v), want 30000003 (%v)", top, normalized, 5.0/21)
+	}
+
+	var zone string
+	if err := db.QueryRow(`SELECT to_zone FROM graph_gateways
+		WHERE system_id = 30000003 AND neighbor_system_id = 30000006`).Scan(&zone); err != nil || zone != string(security.ZoneLowSec) {
+		t.Errorf("gateway zone = %q, %v; want %q", zone, err, security.ZoneLowSec)
+	}
+
+	// Erneuter Build ersetzt die Tabellen
+	if _, err := BuildAnalysisTables(db); err != nil {
+		t.Fatalf("second BuildAnalysisTables failed: %v", err)
+	}
+}
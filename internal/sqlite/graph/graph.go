@@ -0,0 +1,335 @@
+// Package graph berechnet Sprungdistanzen über das Stargate-Netz des k-space
+// und speichert sie als kompakte Tabellen in der Datenbank
+package graph
+
+import (
+	"database/sql"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+)
+
+// K-Space Systeme liegen im ID-Bereich 30000000–30999999
+const (
+	KSpaceMinID = 30000000
+	KSpaceMaxID = 30999999
+)
+
+// Unreachable markiert in Distanzvektoren nicht erreichbare Systeme
+const Unreachable = 255
+
+// Policy bestimmt, welche Route zwischen zwei Systemen gewählt wird
+type Policy string
+
+const (
+	// PolicyShortest: Minimale Anzahl Sprünge
+	PolicyShortest Policy = "shortest"
+	// PolicyPreferHighSec: Möglichst wenige Low-/Null-Sec Systeme, dann minimale Sprünge
+	PolicyPreferHighSec Policy = "prefer-highsec"
+	// PolicyHighSecOnly: Nur Routen über High-Sec Systeme (inkl. Start und Ziel)
+	PolicyHighSecOnly Policy = "highsec-only"
+)
+
+// Policies enthält alle vorberechneten Route-Policies
+var Policies = []Policy{PolicyShortest, PolicyPreferHighSec, PolicyHighSecOnly}
+
+// ParsePolicy wandelt einen Namen in eine Policy
+func ParsePolicy(s string) (Policy, error) {
+	for _, p := range Policies {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown route policy %q", s)
+}
+
+// Node ist ein System des Stargate-Netzes
+type Node struct {
+	SystemID int64
+	RegionID int64
+	Security float64
+}
+
+// Graph ist das Stargate-Netz als Adjazenzliste über dichte Indizes
+type Graph struct {
+	// Systems: System-IDs aufsteigend sortiert; Position = Index
+	Systems []int64
+	// Security: Security-Status je Index
+	Security []float64
+	// Region: Region-ID je Index
+	Region []int64
+	// Adjacent: Nachbarn je Index (ohne Duplikate)
+	Adjacent [][]int32
+
+	index   map[int64]int32
+	highSec []bool
+}
+
+// NewGraph erstellt einen Graphen aus Systemen und Stargate-Kanten.
+// Systeme ohne Kante werden nicht aufgenommen.
+func NewGraph(nodes []Node, edges [][2]int64) *Graph {
+	g := &Graph{index: make(map[int64]int32)}
+
+	byID := make(map[int64]Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.SystemID] = n
+	}
+
+	// Nur Systeme mit mindestens einer Kante
+	connected := make(map[int64]bool)
+	for _, e := range edges {
+		_, okFrom := byID[e[0]]
+		_, okTo := byID[e[1]]
+		if okFrom && okTo && e[0] != e[1] {
+			connected[e[0]] = true
+			connected[e[1]] = true
+		}
+	}
+	for id := range connected {
+		g.Systems = append(g.Systems, id)
+	}
+	sort.Slice(g.Systems, func(i, j int) bool { return g.Systems[i] < g.Systems[j] })
+
+	g.Security = make([]float64, len(g.Systems))
+	g.Region = make([]int64, len(g.Systems))
+	g.Adjacent = make([][]int32, len(g.Systems))
+	g.highSec = make([]bool, len(g.Systems))
+	for i, id := range g.Systems {
+		g.index[id] = int32(i)
+		g.Security[i] = byID[id].Security
+		g.Region[i] = byID[id].RegionID
+		g.highSec[i] = security.HighSec(g.Security[i])
+	}
+
+	seen := make(map[[2]int32]bool)
+	for _, e := range edges {
+		from, okFrom := g.index[e[0]]
+		to, okTo := g.index[e[1]]
+		if !okFrom || !okTo || from == to {
+			continue
+		}
+		// Stargates sind bidirektional
+		for _, pair := range [][2]int32{{from, to}, {to, from}} {
+			if !seen[pair] {
+				seen[pair] = true
+				g.Adjacent[pair[0]] = append(g.Adjacent[pair[0]], pair[1])
+			}
+		}
+	}
+	for i := range g.Adjacent {
+		adj := g.Adjacent[i]
+		sort.Slice(adj, func(a, b int) bool { return adj[a] < adj[b] })
+	}
+
+	return g
+}
+
+// LoadGraph liest das k-space Stargate-Netz aus mapSolarSystems und mapStargates
+func LoadGraph(db *sql.DB) (*Graph, error) {
+	var nodes []Node
+	rows, err := db.Query(`SELECT _key, COALESCE(regionID, 0), COALESCE(securityStatus, 0)
+		FROM mapSolarSystems WHERE _key BETWEEN ? AND ?`,
+		KSpaceMinID, KSpaceMaxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query solar systems: %w", err)
+	}
+	for rows.Next() {
+		var n Node
+		if err := rows.Scan(&n.SystemID, &n.RegionID, &n.Security); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var edges [][2]int64
+	rows, err = db.Query(`SELECT solarSystemID, CAST(json_extract(destination, '$.solarSystemID') AS INTEGER)
+		FROM mapStargates
+		WHERE json_extract(destination, '$.solarSystemID') IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stargates: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e [2]int64
+		if err := rows.Scan(&e[0], &e[1]); err != nil {
+			return nil, err
+		}
+		edges = append(edges, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewGraph(nodes, edges), nil
+}
+
+// Index liefert den dichten Index eines Systems
+func (g *Graph) Index(systemID int64) (int, bool) {
+	i, ok := g.index[systemID]
+	return int(i), ok
+}
+
+// HighSec prüft ob das System am Index High-Sec ist (angezeigte Security ≥ 0.5)
+func (g *Graph) HighSec(i int) bool {
+	return g.highSec[i]
+}
+
+// Distances berechnet die Sprunganzahl vom Index src zu allen Systemen unter der Policy.
+// Nicht erreichbare Systeme (oder Distanzen ≥ 255) erhalten Unreachable.
+func (g *Graph) Distances(src int, policy Policy) ([]uint8, error) {
+	switch policy {
+	case PolicyShortest:
+		return g.bfs(src, func(int) bool { return true }), nil
+	case PolicyHighSecOnly:
+		if !g.HighSec(src) {
+			return g.bfs(src, func(int) bool { return false }), nil
+		}
+		return g.bfs(src, g.HighSec), nil
+	case PolicyPreferHighSec:
+		return g.preferHighSec(src), nil
+	default:
+		return nil, fmt.Errorf("unknown route policy %q", policy)
+	}
+}
+
+// Matrix berechnet die Distanzvektoren aller Systeme parallel (Zeile = Startindex)
+func (g *Graph) Matrix(policy Policy) ([][]uint8, error) {
+	if _, err := ParsePolicy(string(policy)); err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]uint8, len(g.Systems))
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				// Policy ist geprüft, Distances kann nicht fehlschlagen
+				matrix[i], _ = g.Distances(i, policy)
+			}
+		}()
+	}
+	for i := range g.Systems {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return matrix, nil
+}
+
+// bfs ist eine Breitensuche, die nur Systeme betritt, für die allowed gilt
+func (g *Graph) bfs(src int, allowed func(int) bool) []uint8 {
+	dist := newDistances(len(g.Systems))
+	dist[src] = 0
+
+	queue := []int32{int32(src)}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		next := dist[cur] + 1
+		if next >= Unreachable {
+			continue
+		}
+		for _, n := range g.Adjacent[cur] {
+			if dist[n] == Unreachable && allowed(int(n)) {
+				dist[n] = next
+				queue = append(queue, n)
+			}
+		}
+	}
+	return dist
+}
+
+// preferHighSec minimiert lexikographisch (betretene Low-/Null-Sec Systeme, Sprünge).
+// Bucket-Queue je Low-Sec-Stufe mit einem Bucket pro Sprunganzahl (Dial), O(V+E) pro Stufe.
+func (g *Graph) preferHighSec(src int) []uint8 {
+	n := len(g.Systems)
+	dist := newDistances(n)
+
+	bestLow := make([]int32, n)
+	bestJumps := make([]uint8, n)
+	settled := make([]bool, n)
+	for i := range bestLow {
+		bestLow[i] = -1
+	}
+
+	var cur, next [Unreachable][]int32
+	bestLow[src], bestJumps[src] = 0, 0
+	cur[0] = append(cur[0], int32(src))
+
+	better := func(node int32, low int32, jumps uint8) bool {
+		return bestLow[node] < 0 || low < bestLow[node] || (low == bestLow[node] && jumps < bestJumps[node])
+	}
+
+	for level := int32(0); ; level++ {
+		empty := true
+		for j := 0; j < Unreachable; j++ {
+			for k := 0; k < len(cur[j]); k++ {
+				empty = false
+				node := cur[j][k]
+				if settled[node] || bestLow[node] != level || int(bestJumps[node]) != j {
+					continue
+				}
+				settled[node] = true
+				dist[node] = uint8(j)
+
+				nj := j + 1
+				if nj >= Unreachable {
+					continue
+				}
+				for _, m := range g.Adjacent[node] {
+					if settled[m] {
+						continue
+					}
+					if g.HighSec(int(m)) {
+						if better(m, level, uint8(nj)) {
+							bestLow[m], bestJumps[m] = level, uint8(nj)
+							cur[nj] = append(cur[nj], m)
+						}
+					} else if better(m, level+1, uint8(nj)) {
+						bestLow[m], bestJumps[m] = level+1, uint8(nj)
+						next[nj] = append(next[nj], m)
+					}
+				}
+			}
+			cur[j] = cur[j][:0]
+		}
+
+		if empty {
+			hasNext := false
+			for j := range next {
+				if len(next[j]) > 0 {
+					hasNext = true
+					break
+				}
+			}
+			if !hasNext {
+				break
+			}
+		}
+		cur, next = next, cur
+	}
+
+	return dist
+}
+
+// newDistances erstellt einen Distanzvektor mit Unreachable
+func newDistances(n int) []uint8 {
+	dist := make([]uint8, n)
+	for i := range dist {
+		dist[i] = Unreachable
+	}
+	return dist
+}
@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+)
+
+// Fixture:
+//
+//	30000001 (1.0) ─ 30000002 (0.3) ─ 30000003 (0.9) ─ 30000006 (0.2)
+//	      │                                 │
+//	30000004 (0.8) ─────── 30000005 (0.7) ──┘
+//
+//	30000007 (0.9) ─ 30000008 (0.9)    (eigene Komponente)
+var (
+	fixtureSecurity = map[int64]float64{
+		30000001: 1.0, 30000002: 0.3, 30000003: 0.9, 30000004: 0.8,
+		30000005: 0.7, 30000006: 0.2, 30000007: 0.9, 30000008: 0.9,
+		30000009: 0.5, // ohne Stargates
+	}
+	fixtureEdges = [][2]int64{
+		{30000001, 30000002}, {30000002, 30000003}, {30000001, 30000004},
+		{30000004, 30000005}, {30000005, 30000003}, {30000003, 30000006},
+		{30000007, 30000008},
+		{30000002, 30000001}, // Rückrichtung als eigenes Gate
+	}
+)
+
+// fixtureNodes liefert die Fixture-Systeme (Region 10000001, 30000003 in 10000002)
+func fixtureNodes() []Node {
+	var nodes []Node
+	for id, sec := range fixtureSecurity {
+		region := int64(10000001)
+		if id == 30000003 {
+			region = 10000002
+		}
+		nodes = append(nodes, Node{SystemID: id, RegionID: region, Security: sec})
+	}
+	return nodes
+}
+
+func TestNewGraph(t *testing.T) {
+	g := NewGraph(fixtureNodes(), fixtureEdges)
+
+	if len(g.Systems) != 8 {
+		t.Errorf("Systems = %d, want 8 (isolated system excluded)", len(g.Systems))
+	}
+	i, ok := g.Index(30000001)
+	if !ok || len(g.Adjacent[i]) != 2 {
+		t.Errorf("30000001 adjacency = %v, want 2 neighbours without duplicates", g.Adjacent[i])
+	}
+}
+
+func TestDistances(t *testing.T) {
+	g := NewGraph(fixtureNodes(), fixtureEdges)
+
+	tests := []struct {
+		policy   Policy
+		from, to int64
+		want     int
+	}{
+		{PolicyShortest, 30000001, 30000003, 2},
+		{PolicyPreferHighSec, 30000001, 30000003, 3},
+		{PolicyHighSecOnly, 30000001, 30000003, 3},
+		{PolicyShortest, 30000001, 30000006, 3},
+		{PolicyPreferHighSec, 30000001, 30000006, 4},
+		{PolicyHighSecOnly, 30000001, 30000006, Unreachable},
+		{PolicyHighSecOnly, 30000006, 30000003, Unreachable},
+		{PolicyHighSecOnly, 30000006, 30000006, 0},
+		{PolicyShortest, 30000001, 30000007, Unreachable},
+		{PolicyShortest, 30000001, 30000001, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d-%d", tt.policy, tt.from, tt.to), func(t *testing.T) {
+			src, _ := g.Index(tt.from)
+			dst, _ := g.Index(tt.to)
+			dist, err := g.Distances(src, tt.policy)
+			if err != nil {
+				t.Fatalf("Distances failed: %v", err)
+			}
+			if int(dist[dst]) != tt.want {
+				t.Errorf("jumps = %d, want %d", dist[dst], tt.want)
+			}
+		})
+	}
+
+	if _, err := g.Distances(0, Policy("fastest")); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+// fixtureDB legt mapSolarSystems/mapStargates mit der Fixture (plus einem J-Space System) an
+func fixtureDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t, "mapSolarSystems", "mapStargates")
+	// J-Space, ignoriert
+	if _, err := db.Exec(`INSERT INTO mapSolarSystems (_key, regionID, securityStatus) VALUES (31000001, 11000001, -1.0)`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	for _, n := range fixtureNodes() {
+		if _, err := db.Exec(`INSERT INTO mapSolarSystems (_key, regionID, securityStatus) VALUES (?, ?, ?)`,
+			n.SystemID, n.RegionID, n.Security); err != nil {
+			t.Fatalf("Insert system failed: %v", err)
+		}
+	}
+	for i, e := range append(fixtureEdges, [2]int64{30000003, 31000001}) {
+		dest := fmt.Sprintf(`{"solarSystemID": %d, "stargateID": %d}`, e[1], 50000000+i)
+		if _, err := db.Exec(`INSERT INTO mapStargates (_key, solarSystemID, destination) VALUES (?, ?, ?)`,
+			50000000+i, e[0], dest); err != nil {
+			t.Fatalf("Insert gate failed: %v", err)
+		}
+	}
+	return db
+}
+
+func TestBuildJumpTables(t *testing.T) {
+	db := fixtureDB(t)
+
+	stats, err := BuildJumpTables(db)
+	if err != nil {
+		t.Fatalf("BuildJumpTables failed: %v", err)
+	}
+	if stats.Systems != 8 || stats.Edges != 7 || stats.Bytes != 8*8*3 {
+		t.Errorf("stats = %+v, want 8 systems, 7 edges, 192 bytes", stats)
+	}
+
+	jumps, ok, err := Jumps(db, PolicyPreferHighSec, 30000001, 30000006)
+	if err != nil || !ok || jumps != 4 {
+		t.Errorf("Jumps = %d, %v, %v; want 4", jumps, ok, err)
+	}
+	if _, ok, _ := Jumps(db, PolicyHighSecOnly, 30000001, 30000006); ok {
+		t.Error("highsec-only route to low-sec system should be unreachable")
+	}
+	if _, ok, _ := Jumps(db, PolicyShortest, 30000001, 31000001); ok {
+		t.Error("J-space system should not be part of the jump tables")
+	}
+
+	// View dekodiert dieselben Werte per SQL
+	var viewJumps sql.NullInt64
+	err = db.QueryRow(`SELECT jumps FROM v_jump_distance
+		WHERE policy = 'shortest' AND from_system_id = 30000001 AND to_system_id = 30000006`).Scan(&viewJumps)
+	if err != nil || !viewJumps.Valid || viewJumps.Int64 != 3 {
+		t.Errorf("view jumps = %v, %v; want 3", viewJumps, err)
+	}
+	err = db.QueryRow(`SELECT jumps FROM v_jump_distance
+		WHERE policy = 'shortest' AND from_system_id = 30000001 AND to_system_id = 30000007`).Scan(&viewJumps)
+	if err != nil || viewJumps.Valid {
+		t.Errorf("view jumps = %v, %v; want NULL for unreachable", viewJumps, err)
+	}
+
+	// Erneuter Build ersetzt die Tabellen
+	if _, err := BuildJumpTables(db); err != nil {
+		t.Fatalf("second BuildJumpTables failed: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package graph
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+)
+
+// MetersPerLightYear rechnet SDE-Positionen (Meter) in Lichtjahre um
+const MetersPerLightYear = 9460730472580800.0
+
+// DefaultMaxRangeLY ist die größte vorberechnete Sprungreichweite (Jump Drive Calibration V, Black Ops u. a. liegen darunter)
+const DefaultMaxRangeLY = 10.0
+
+// Tabellen der Jump-Drive Reichweiten
+const (
+	// JumpRangesTable enthält jedes Systempaar innerhalb der maximalen Reichweite (beide Richtungen)
+	JumpRangesTable = "jump_ranges"
+	// JumpRangeSettingsTable speichert die verwendete maximale Reichweite
+	JumpRangeSettingsTable = "jump_range_settings"
+)
+
+// ZarzakhSystemID: Null-Sec System ohne Jump-Drive Zugang
+const ZarzakhSystemID = 30100000
+
+// JumpSystem ist ein System mit Position für die Reichweitenberechnung
+type JumpSystem struct {
+	SystemID       int64
+	RegionID       int64
+	SecurityStatus float64
+	X, Y, Z        float64
+}
+
+// JumpDriveAllowed prüft ob ein System Start oder Ziel eines Jump-Drive Sprungs sein kann:
+// k-space Low- oder Null-Sec (kein Pochven, keine Jove-Region), nicht Zarzakh
+func JumpDriveAllowed(s JumpSystem) bool {
+	if s.SystemID < KSpaceMinID || s.SystemID > KSpaceMaxID || s.SystemID == ZarzakhSystemID {
+		return false
+	}
+	switch security.Classify(s.SystemID, s.RegionID, s.SecurityStatus) {
+	case security.ZoneLowSec, security.ZoneNullSec:
+		return true
+	default:
+		return false
+	}
+}
+
+// DistanceLY liefert die Luftlinie zweier Systeme in Lichtjahren
+func DistanceLY(a, b JumpSystem) float64 {
+	dx, dy, dz := a.X-b.X, a.Y-b.Y, a.Z-b.Z
+	return math.Sqrt(dx*dx+dy*dy+dz*dz) / MetersPerLightYear
+}
+
+// JumpRange ist ein Systempaar innerhalb der Reichweite
+type JumpRange struct {
+	FromSystemID int64
+	ToSystemID   int64
+	DistanceLY   float64
+}
+
+// JumpRanges berechnet alle gerichteten Paare erlaubter Systeme mit Distanz ≤ maxLY
+func JumpRanges(systems []JumpSystem, maxLY float64) []JumpRange {
+	var allowed []JumpSystem
+	for _, s := range systems {
+		if JumpDriveAllowed(s) {
+			allowed = append(allowed, s)
+		}
+	}
+
+	// Nach x sortieren, damit die innere Schleife früh abbrechen kann
+	sort.Slice(allowed, func(i, j int) bool { return allowed[i].X < allowed[j].X })
+	maxMeters := maxLY * MetersPerLightYear
+
+	var ranges []JumpRange
+	for i := range allowed {
+		for j := i + 1; j < len(allowed) && allowed[j].X-allowed[i].X <= maxMeters; j++ {
+			d := DistanceLY(allowed[i], allowed[j])
+			if d > maxLY {
+				continue
+			}
+			ranges = append(ranges,
+				JumpRange{allowed[i].SystemID, allowed[j].SystemID, d},
+				JumpRange{allowed[j].SystemID, allowed[i].SystemID, d})
+		}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].FromSystemID != ranges[j].FromSystemID {
+			return ranges[i].FromSystemID < ranges[j].FromSystemID
+		}
+		return ranges[i].ToSystemID < ranges[j].ToSystemID
+	})
+	return ranges
+}
+
+// LoadJumpSystems liest Positionen, Region und Security aller Systeme
+func LoadJumpSystems(db *sql.DB) ([]JumpSystem, error) {
+	rows, err := db.Query(`SELECT _key, COALESCE(regionID, 0), COALESCE(securityStatus, 0),
+		json_extract(position, '$.x'), json_extract(position, '$.y'), json_extract(position, '$.z')
+		FROM mapSolarSystems
+		WHERE json_extract(position, '$.x') IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query solar system positions: %w", err)
+	}
+	defer rows.Close()
+
+	var systems []JumpSystem
+	for rows.Next() {
+		var s JumpSystem
+		if err := rows.Scan(&s.SystemID, &s.RegionID, &s.SecurityStatus, &s.X, &s.Y, &s.Z); err != nil {
+			return nil, fmt.Errorf("failed to scan solar system: %w", err)
+		}
+		systems = append(systems, s)
+	}
+	return systems, rows.Err()
+}
+
+// BuildJumpRangeTable berechnet alle Systempaare innerhalb maxLY und ersetzt jump_ranges
+func BuildJumpRangeTable(db *sql.DB, maxLY float64) (int, error) {
+	systems, err := LoadJumpSystems(db)
+	if err != nil {
+		return 0, err
+	}
+	ranges := JumpRanges(systems, maxLY)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + JumpRangesTable,
+		"DROP TABLE IF EXISTS " + JumpRangeSettingsTable,
+		`CREATE TABLE ` + JumpRangesTable + ` (
+  from_system_id INTEGER NOT NULL,
+  to_system_id INTEGER NOT NULL,
+  distance_ly REAL NOT NULL,
+  PRIMARY KEY (from_system_id, to_system_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_jump_ranges_from_distance ON ` + JumpRangesTable + `(from_system_id, distance_ly)`,
+		`CREATE TABLE ` + JumpRangeSettingsTable + ` (max_range_ly REAL NOT NULL)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return 0, fmt.Errorf("failed to create jump range tables: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec("INSERT INTO "+JumpRangeSettingsTable+" (max_range_ly) VALUES (?)", maxLY); err != nil {
+		return 0, fmt.Errorf("failed to store jump range settings: %w", err)
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO " + JumpRangesTable + " (from_system_id, to_system_id, distance_ly) VALUES (?, ?, ?)")
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range ranges {
+		if _, err := stmt.Exec(r.FromSystemID, r.ToSystemID, r.DistanceLY); err != nil {
+			return 0, fmt.Errorf("failed to insert jump range %d -> %d: %w", r.FromSystemID, r.ToSystemID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit jump ranges: %w", err)
+	}
+	return len(ranges), nil
+}
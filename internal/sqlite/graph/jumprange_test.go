@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+)
+
+func TestJumpDriveAllowed(t *testing.T) {
+	tests := []struct {
+		name string
+		sys  JumpSystem
+		want bool
+	}{
+		{"low-sec", JumpSystem{SystemID: 30002813, RegionID: 10000033, SecurityStatus: 0.3}, true},
+		{"null-sec", JumpSystem{SystemID: 30004759, RegionID: 10000060, SecurityStatus: -0.5}, true},
+		{"high-sec", JumpSystem{SystemID: 30000142, RegionID: 10000002, SecurityStatus: 0.95}, false},
+		{"high-sec rounded", JumpSystem{SystemID: 30000001, RegionID: 10000001, SecurityStatus: 0.46}, false},
+		{"pochven", JumpSystem{SystemID: 30000021, RegionID: security.PochvenRegionID, SecurityStatus: -1.0}, false},
+		{"jove", JumpSystem{SystemID: 30000380, RegionID: 10000004, SecurityStatus: -1.0}, false},
+		{"zarzakh", JumpSystem{SystemID: ZarzakhSystemID, RegionID: 10001000, SecurityStatus: -1.0}, false},
+		{"j-space", JumpSystem{SystemID: 31000005, RegionID: 11000001, SecurityStatus: -1.0}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := JumpDriveAllowed(tt.sys); got != tt.want {
+				t.Errorf("JumpDriveAllowed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// lySystem platziert ein Low-Sec System auf der x-Achse
+func lySystem(id int64, x float64) JumpSystem {
+	return JumpSystem{SystemID: id, RegionID: 10000001, SecurityStatus: 0.2, X: x * MetersPerLightYear}
+}
+
+func TestJumpRanges(t *testing.T) {
+	systems := []JumpSystem{
+		lySystem(30000001, 0),
+		lySystem(30000002, 4),
+		lySystem(30000003, 9),
+		lySystem(30000004, 25),
+		{SystemID: 30000005, RegionID: 10000001, SecurityStatus: 0.9, X: 1 * MetersPerLightYear}, // High-Sec
+	}
+
+	ranges := JumpRanges(systems, 10)
+	// 1↔2 (4 LY), 1↔3 (9 LY), 2↔3 (5 LY); 4 außer Reichweite, 5 High-Sec
+	if len(ranges) != 6 {
+		t.Fatalf("ranges = %v, want 6 directed pairs", ranges)
+	}
+	if r := ranges[1]; r.FromSystemID != 30000001 || r.ToSystemID != 30000003 || math.Abs(r.DistanceLY-9) > 1e-9 {
+		t.Errorf("ranges[1] = %+v, want 30000001 -> 30000003 at 9 LY", r)
+	}
+}
+
+func TestBuildJumpRangeTable(t *testing.T) {
+	db := sqlitetest.Open(t, "mapSolarSystems")
+	for i, x := range []float64{0, 3, 7} {
+		pos := fmt.Sprintf(`{"x": %g, "y": 0, "z": 0}`, x*MetersPerLightYear)
+		if _, err := db.Exec(`INSERT INTO mapSolarSystems (_key, regionID, securityStatus, position) VALUES (?, 10000001, -0.3, ?)`,
+			30000001+i, pos); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	n, err := BuildJumpRangeTable(db, 5)
+	if err != nil {
+		t.Fatalf("BuildJumpRangeTable failed: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("pairs = %d, want 4 (1↔2, 2↔3)", n)
+	}
+
+	var dist, maxRange float64
+	if err := db.QueryRow(`SELECT distance_ly FROM jump_ranges WHERE from_system_id = 30000003 AND to_system_id = 30000002`).Scan(&dist); err != nil || math.Abs(dist-4) > 1e-9 {
+		t.Errorf("distance = %v, %v; want 4", dist, err)
+	}
+	if err := db.QueryRow(`SELECT max_range_ly FROM jump_range_settings`).Scan(&maxRange); err != nil || maxRange != 5 {
+		t.Errorf("max_range_ly = %v, %v; want 5", maxRange, err)
+	}
+}
@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Tabellen der vorberechneten Sprungdistanzen
+const (
+	// SystemsTable ordnet jedem System seine Position im Distanzvektor zu
+	SystemsTable = "jump_systems"
+	// DistancesTable enthält je Policy und Startsystem einen BLOB mit einem Byte pro Zielsystem
+	DistancesTable = "jump_distances"
+	// DistanceView löst einzelne Distanzen per SQL auf (NULL = nicht erreichbar)
+	DistanceView = "v_jump_distance"
+)
+
+var jumpTablesDDL = []string{
+	"DROP VIEW IF EXISTS " + DistanceView,
+	"DROP TABLE IF EXISTS " + DistancesTable,
+	"DROP TABLE IF EXISTS " + SystemsTable,
+	`CREATE TABLE ` + SystemsTable + ` (
+  idx INTEGER PRIMARY KEY,
+  system_id INTEGER NOT NULL UNIQUE,
+  security_status REAL NOT NULL
+)`,
+	`CREATE TABLE ` + DistancesTable + ` (
+  policy TEXT NOT NULL,
+  from_system_id INTEGER NOT NULL,
+  jumps BLOB NOT NULL,
+  PRIMARY KEY (policy, from_system_id)
+) WITHOUT ROWID`,
+	// Byte aus dem BLOB per hex() dekodieren (SQLite hat keine Byte-Zugriffsfunktion)
+	`CREATE VIEW ` + DistanceView + ` AS
+SELECT
+    d.policy,
+    d.from_system_id,
+    s.system_id AS to_system_id,
+    NULLIF(
+        (instr('0123456789ABCDEF', substr(hex(substr(d.jumps, s.idx + 1, 1)), 1, 1)) - 1) * 16
+        + instr('0123456789ABCDEF', substr(hex(substr(d.jumps, s.idx + 1, 1)), 2, 1)) - 1,
+        255
+    ) AS jumps
+FROM ` + DistancesTable + ` d
+JOIN ` + SystemsTable + ` s`,
+}
+
+// BuildStats fasst die Berechnung der Sprungtabellen zusammen
+type BuildStats struct {
+	Systems  int
+	Edges    int
+	Policies int
+	Bytes    int64
+}
+
+// BuildJumpTables berechnet alle Distanzen des k-space unter allen Policies
+// und ersetzt die Tabellen jump_systems und jump_distances
+func BuildJumpTables(db *sql.DB) (*BuildStats, error) {
+	g, err := LoadGraph(db)
+	if err != nil {
+		return nil, err
+	}
+	return WriteJumpTables(db, g)
+}
+
+// WriteJumpTables schreibt die Distanzvektoren eines Graphen
+func WriteJumpTables(db *sql.DB, g *Graph) (*BuildStats, error) {
+	stats := &BuildStats{Systems: len(g.Systems), Policies: len(Policies)}
+	for _, adj := range g.Adjacent {
+		stats.Edges += len(adj)
+	}
+	stats.Edges /= 2
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range jumpTablesDDL {
+		if _, err := tx.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to create jump tables: %w", err)
+		}
+	}
+
+	sysStmt, err := tx.Prepare("INSERT INTO " + SystemsTable + " (idx, system_id, security_status) VALUES (?, ?, ?)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer sysStmt.Close()
+
+	for i, id := range g.Systems {
+		if _, err := sysStmt.Exec(i, id, g.Security[i]); err != nil {
+			return nil, fmt.Errorf("failed to insert system %d: %w", id, err)
+		}
+	}
+
+	distStmt, err := tx.Prepare("INSERT INTO " + DistancesTable + " (policy, from_system_id, jumps) VALUES (?, ?, ?)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer distStmt.Close()
+
+	for _, policy := range Policies {
+		matrix, err := g.Matrix(policy)
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range g.Systems {
+			dist := matrix[i]
+			if _, err := distStmt.Exec(string(policy), id, dist); err != nil {
+				return nil, fmt.Errorf("failed to insert distances from %d: %w", id, err)
+			}
+			stats.Bytes += int64(len(dist))
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit jump tables: %w", err)
+	}
+	return stats, nil
+}
+
+// Jumps liefert die Sprunganzahl zwischen zwei Systemen unter einer Policy.
+// ok ist false, wenn das Ziel nicht erreichbar oder eines der Systeme unbekannt ist.
+func Jumps(db *sql.DB, policy Policy, fromSystemID, toSystemID int64) (jumps int, ok bool, err error) {
+	var b []byte
+	err = db.QueryRow(`SELECT substr(d.jumps, s.idx + 1, 1)
+		FROM `+DistancesTable+` d, `+SystemsTable+` s
+		WHERE d.policy = ? AND d.from_system_id = ? AND s.system_id = ?`,
+		string(policy), fromSystemID, toSystemID).Scan(&b)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to look up jumps: %w", err)
+	}
+	if len(b) != 1 || b[0] == Unreachable {
+		return 0, false, nil
+	}
+	return int(b[0]), true, nil
+}
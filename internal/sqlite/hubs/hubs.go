@@ -0,0 +1,115 @@
+// Package hubs verwaltet die Handelszentren (Trade Hubs) der Datenbank:
+// Definition als YAML/JSON, Tabelle trade_hubs und Sprungdistanzen aller Systeme zu jedem Hub
+package hubs
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Tier unterscheidet große von regionalen Handelszentren
+type Tier string
+
+const (
+	TierPrimary   Tier = "primary"
+	TierSecondary Tier = "secondary"
+)
+
+// Hub ist ein Handelszentrum. SystemID darf fehlen, wenn StationID gesetzt ist
+// (das System wird dann aus npcStations aufgelöst).
+type Hub struct {
+	Name      string `json:"name" yaml:"name"`
+	SystemID  int64  `json:"system_id,omitempty" yaml:"system_id,omitempty"`
+	StationID int64  `json:"station_id,omitempty" yaml:"station_id,omitempty"`
+	Tier      Tier   `json:"tier,omitempty" yaml:"tier,omitempty"`
+}
+
+// File ist das Format der Hub-Definitionsdatei
+type File struct {
+	Hubs []Hub `json:"hubs" yaml:"hubs"`
+}
+
+//go:embed default.yaml
+var defaultYAML []byte
+
+// Default liefert die Standard-Hubs (Jita, Amarr, Dodixie, Rens, Hek)
+func Default() []Hub {
+	hubs, err := Parse(defaultYAML, "yaml")
+	if err != nil {
+		panic(fmt.Sprintf("invalid default hubs: %v", err))
+	}
+	return hubs
+}
+
+// Load liest Hubs aus einer YAML- (.yaml, .yml) oder JSON-Datei (.json)
+func Load(path string) ([]Hub, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hub file: %w", err)
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	hubs, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return hubs, nil
+}
+
+// Parse dekodiert und validiert Hub-Definitionen im Format "yaml", "yml" oder "json"
+func Parse(data []byte, format string) ([]Hub, error) {
+	var file File
+	switch format {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse hub YAML: %w", err)
+		}
+	case "json":
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse hub JSON: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported hub file format %q", format)
+	}
+
+	for i := range file.Hubs {
+		if file.Hubs[i].Tier == "" {
+			file.Hubs[i].Tier = TierPrimary
+		}
+	}
+	if err := Validate(file.Hubs); err != nil {
+		return nil, err
+	}
+	return file.Hubs, nil
+}
+
+// Validate prüft Namen, IDs und Tiers
+func Validate(hubs []Hub) error {
+	if len(hubs) == 0 {
+		return fmt.Errorf("no hubs defined")
+	}
+
+	names := make(map[string]bool, len(hubs))
+	for i, h := range hubs {
+		switch {
+		case strings.TrimSpace(h.Name) == "":
+			return fmt.Errorf("hub %d: name is required", i)
+		case names[h.Name]:
+			return fmt.Errorf("hub %s: duplicate name", h.Name)
+		case h.SystemID == 0 && h.StationID == 0:
+			return fmt.Errorf("hub %s: system_id or station_id is required", h.Name)
+		case h.SystemID < 0 || h.StationID < 0:
+			return fmt.Errorf("hub %s: negative ID", h.Name)
+		case h.Tier != TierPrimary && h.Tier != TierSecondary:
+			return fmt.Errorf("hub %s: unknown tier %q", h.Name, h.Tier)
+		}
+		names[h.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package hubs
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/graph"
+)
+
+func TestDefault(t *testing.T) {
+	hubs := Default()
+	if len(hubs) != 5 || hubs[0].Name != "Jita" || hubs[0].SystemID != 30000142 || hubs[0].StationID != 60003760 {
+		t.Errorf("Default = %+v", hubs)
+	}
+	for _, h := range hubs {
+		if h.Tier != TierPrimary {
+			t.Errorf("%s tier = %q, want primary", h.Name, h.Tier)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"hubs.yaml": "hubs:\n  - name: Jita\n    station_id: 60003760\n  - name: Perimeter\n    system_id: 30000144\n    tier: secondary\n",
+		"hubs.json": `{"hubs": [{"name": "Jita", "station_id": 60003760}, {"name": "Perimeter", "system_id": 30000144, "tier": "secondary"}]}`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			hubs, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if len(hubs) != 2 || hubs[0].StationID != 60003760 || hubs[0].Tier != TierPrimary ||
+				hubs[1].SystemID != 30000144 || hubs[1].Tier != TierSecondary {
+				t.Errorf("hubs = %+v", hubs)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(dir, "hubs.toml")); err == nil {
+		t.Error("Load of missing file should fail")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{"empty", `{"hubs": []}`, "no hubs"},
+		{"missing name", `{"hubs": [{"system_id": 1}]}`, "name is required"},
+		{"duplicate", `{"hubs": [{"name": "A", "system_id": 1}, {"name": "A", "system_id": 2}]}`, "duplicate"},
+		{"no location", `{"hubs": [{"name": "A"}]}`, "system_id or station_id"},
+		{"tier", `{"hubs": [{"name": "A", "system_id": 1, "tier": "tertiary"}]}`, "unknown tier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.data), "json"); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+	if _, err := Parse([]byte("hubs: []"), "toml"); err == nil {
+		t.Error("unsupported format should fail")
+	}
+}
+
+// openMap legt ein Stargate-Netz an:
+//
+//	30000001 (0.9) ─ 30000002 (0.9) ─ 30000003 (0.9)
+//	      └───────── 30000004 (0.2) ────────┘
+//
+// Station 60000003 liegt in 30000003
+func openMap(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "hubs.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec(`
+		CREATE TABLE mapSolarSystems (_key INTEGER PRIMARY KEY, regionID INTEGER, securityStatus REAL);
+		CREATE TABLE mapStargates (_key INTEGER PRIMARY KEY, solarSystemID INTEGER, destination TEXT);
+		CREATE TABLE npcStations (_key INTEGER PRIMARY KEY, solarSystemID INTEGER);
+		INSERT INTO mapSolarSystems VALUES (30000001, 10000001, 0.9), (30000002, 10000001, 0.9),
+			(30000003, 10000001, 0.9), (30000004, 10000001, 0.2);
+		INSERT INTO npcStations VALUES (60000003, 30000003);
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	edges := [][2]int64{{30000001, 30000002}, {30000002, 30000003}, {30000001, 30000004}, {30000004, 30000003}}
+	for i, e := range edges {
+		dest := fmt.Sprintf(`{"solarSystemID": %d}`, e[1])
+		if _, err := db.Exec(`INSERT INTO mapStargates VALUES (?, ?, ?)`, 50000000+i, e[0], dest); err != nil {
+			t.Fatalf("Insert gate failed: %v", err)
+		}
+	}
+	return db
+}
+
+func TestWriteHubs_Stations(t *testing.T) {
+	db := openMap(t)
+
+	resolved, err := WriteHubs(db, []Hub{
+		{Name: "Station Hub", StationID: 60000003, Tier: TierPrimary},
+		{Name: "Unverified", SystemID: 30000002, StationID: 60009999, Tier: TierSecondary},
+	})
+	if err != nil {
+		t.Fatalf("WriteHubs failed: %v", err)
+	}
+	if resolved[0].SystemID != 30000003 {
+		t.Errorf("resolved system = %d, want 30000003 from npcStations", resolved[0].SystemID)
+	}
+
+	loaded, err := LoadHubs(db)
+	if err != nil || len(loaded) != 2 || loaded[0] != resolved[0] || loaded[1].StationID != 60009999 {
+		t.Errorf("LoadHubs = %+v, %v", loaded, err)
+	}
+
+	if _, err := WriteHubs(db, []Hub{{Name: "Wrong", SystemID: 30000001, StationID: 60000003, Tier: TierPrimary}}); err == nil ||
+		!strings.Contains(err.Error(), "is in system 30000003") {
+		t.Errorf("conflicting station error = %v", err)
+	}
+	if _, err := WriteHubs(db, []Hub{{Name: "Unknown", StationID: 60009999, Tier: TierPrimary}}); err == nil {
+		t.Error("unknown station without system should fail")
+	}
+}
+
+func TestBuildDistances(t *testing.T) {
+	db := openMap(t)
+	if _, err := WriteHubs(db, []Hub{
+		{Name: "Hub 1", SystemID: 30000001, Tier: TierPrimary},
+		{Name: "Low Hub", SystemID: 30000004, Tier: TierSecondary},
+	}); err != nil {
+		t.Fatalf("WriteHubs failed: %v", err)
+	}
+
+	if _, err := BuildDistances(db); err == nil {
+		t.Error("BuildDistances without jump tables should fail")
+	}
+	if _, err := graph.BuildJumpTables(db); err != nil {
+		t.Fatalf("BuildJumpTables failed: %v", err)
+	}
+
+	rows, err := BuildDistances(db)
+	if err != nil {
+		t.Fatalf("BuildDistances failed: %v", err)
+	}
+	// Hub 1: 4 Systeme × 2 Policies + 3 High-Sec Systeme (highsec-only)
+	// Low Hub: 4 Systeme × 2 Policies, highsec-only nur das System selbst (wie jump_distances)
+	if rows != 11+9 {
+		t.Errorf("rows = %d, want 20", rows)
+	}
+
+	tests := []struct {
+		hub, policy string
+		system      int64
+		want        int
+	}{
+		{"Hub 1", "shortest", 30000003, 2},
+		{"Hub 1", "highsec-only", 30000003, 2},
+		{"Hub 1", "shortest", 30000001, 0},
+		{"Low Hub", "prefer-highsec", 30000002, 2},
+	}
+	for _, tt := range tests {
+		var jumps int
+		err := db.QueryRow(`SELECT jumps FROM trade_hub_distances WHERE hub_name = ? AND policy = ? AND system_id = ?`,
+			tt.hub, tt.policy, tt.system).Scan(&jumps)
+		if err != nil || jumps != tt.want {
+			t.Errorf("%s/%s/%d = %d, %v; want %d", tt.hub, tt.policy, tt.system, jumps, err, tt.want)
+		}
+	}
+
+	var hub string
+	err = db.QueryRow(`SELECT group_concat(hub_name) FROM trade_hub_distances
+		WHERE system_id = 30000004 AND policy = 'highsec-only'`).Scan(&hub)
+	if err != nil || hub != "Low Hub" {
+		t.Errorf("highsec-only hubs of low-sec system = %q, %v; want only itself", hub, err)
+	}
+}
@@ -0,0 +1,180 @@
+package hubs
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/graph"
+)
+
+// Tabellen der Hub-Registry
+const (
+	// HubsTable enthält die konfigurierten Handelszentren
+	HubsTable = "trade_hubs"
+	// DistancesTable enthält die Sprungdistanz jedes Systems zu jedem Hub je Policy
+	DistancesTable = "trade_hub_distances"
+)
+
+// WriteHubs ersetzt trade_hubs. Stationen werden gegen npcStations geprüft (sofern importiert);
+// fehlt die SystemID, wird sie aus der Station aufgelöst.
+func WriteHubs(db *sql.DB, hubs []Hub) ([]Hub, error) {
+	if err := Validate(hubs); err != nil {
+		return nil, err
+	}
+
+	resolved, err := resolveStations(db, hubs)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + HubsTable,
+		`CREATE TABLE ` + HubsTable + ` (
+  name TEXT PRIMARY KEY,
+  system_id INTEGER NOT NULL,
+  station_id INTEGER,
+  tier TEXT NOT NULL CHECK (tier IN ('primary', 'secondary')),
+  sort_order INTEGER NOT NULL
+)`,
+		`CREATE INDEX idx_trade_hubs_system_id ON ` + HubsTable + `(system_id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("failed to create hub table: %w", err)
+		}
+	}
+
+	for i, h := range resolved {
+		var station interface{}
+		if h.StationID != 0 {
+			station = h.StationID
+		}
+		if _, err := tx.Exec("INSERT INTO "+HubsTable+" (name, system_id, station_id, tier, sort_order) VALUES (?, ?, ?, ?, ?)",
+			h.Name, h.SystemID, station, string(h.Tier), i); err != nil {
+			return nil, fmt.Errorf("failed to insert hub %s: %w", h.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit hubs: %w", err)
+	}
+	return resolved, nil
+}
+
+// resolveStations ergänzt fehlende SystemIDs aus npcStations und erkennt Widersprüche
+func resolveStations(db *sql.DB, hubs []Hub) ([]Hub, error) {
+	var hasStations bool
+	if err := db.QueryRow("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = 'npcStations'").
+		Scan(&hasStations); err != nil {
+		return nil, fmt.Errorf("failed to check npcStations: %w", err)
+	}
+
+	resolved := make([]Hub, len(hubs))
+	for i, h := range hubs {
+		resolved[i] = h
+		if h.StationID == 0 {
+			continue
+		}
+
+		var systemID int64
+		err := sql.ErrNoRows
+		if hasStations {
+			err = db.QueryRow("SELECT solarSystemID FROM npcStations WHERE _key = ?", h.StationID).Scan(&systemID)
+		}
+		switch {
+		case err == sql.ErrNoRows && h.SystemID == 0:
+			return nil, fmt.Errorf("hub %s: station %d not found in npcStations", h.Name, h.StationID)
+		case err == sql.ErrNoRows:
+			// Station (noch) nicht importiert: SystemID aus der Definition
+		case err != nil:
+			return nil, fmt.Errorf("failed to resolve station %d: %w", h.StationID, err)
+		case h.SystemID == 0:
+			resolved[i].SystemID = systemID
+		case h.SystemID != systemID:
+			return nil, fmt.Errorf("hub %s: station %d is in system %d, not %d", h.Name, h.StationID, systemID, h.SystemID)
+		}
+	}
+	return resolved, nil
+}
+
+// LoadHubs liest die Hubs aus trade_hubs
+func LoadHubs(db *sql.DB) ([]Hub, error) {
+	rows, err := db.Query("SELECT name, system_id, COALESCE(station_id, 0), tier FROM " + HubsTable + " ORDER BY sort_order")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query hubs: %w", err)
+	}
+	defer rows.Close()
+
+	var hubs []Hub
+	for rows.Next() {
+		var h Hub
+		if err := rows.Scan(&h.Name, &h.SystemID, &h.StationID, &h.Tier); err != nil {
+			return nil, fmt.Errorf("failed to scan hub: %w", err)
+		}
+		hubs = append(hubs, h)
+	}
+	return hubs, rows.Err()
+}
+
+// BuildDistances übernimmt für jeden Hub aus trade_hubs die Sprunganzahlen aller erreichbaren
+// k-space Systeme je Policy aus den vorberechneten Sprungtabellen (graph.BuildJumpTables) und
+// ersetzt trade_hub_distances. Liefert die Anzahl Zeilen.
+func BuildDistances(db *sql.DB) (int, error) {
+	var hasJumpTables bool
+	if err := db.QueryRow("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?", graph.DistancesTable).
+		Scan(&hasJumpTables); err != nil {
+		return 0, fmt.Errorf("failed to check %s: %w", graph.DistancesTable, err)
+	}
+	if !hasJumpTables {
+		return 0, fmt.Errorf("%s not found: build jump tables first", graph.DistancesTable)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + DistancesTable,
+		`CREATE TABLE ` + DistancesTable + ` (
+  system_id INTEGER NOT NULL,
+  hub_name TEXT NOT NULL,
+  policy TEXT NOT NULL,
+  jumps INTEGER NOT NULL,
+  PRIMARY KEY (system_id, policy, hub_name)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_trade_hub_distances_hub ON ` + DistancesTable + `(hub_name, policy, jumps)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return 0, fmt.Errorf("failed to create hub distance table: %w", err)
+		}
+	}
+
+	// Sprunganzahlen sind symmetrisch: Hub → System = System → Hub. Hubs ohne
+	// Stargate-Verbindung haben keinen Distanzvektor und entfallen.
+	res, err := tx.Exec(`INSERT INTO ` + DistancesTable + ` (system_id, hub_name, policy, jumps)
+SELECT d.to_system_id, h.name, d.policy, d.jumps
+FROM ` + HubsTable + ` h
+JOIN ` + graph.DistanceView + ` d ON d.from_system_id = h.system_id
+WHERE d.jumps IS NOT NULL`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert hub distances: %w", err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count hub distances: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit hub distances: %w", err)
+	}
+	return int(count), nil
+}
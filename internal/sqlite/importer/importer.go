@@ -2,9 +2,13 @@ package importer
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strings"
@@ -57,82 +61,94 @@ func (imp *Importer) Close() error {
 
 // ImportJSONL importiert JSONL-Datei in Tabelle
 func (imp *Importer) ImportJSONL(tableName, jsonlPath string, structType reflect.Type) error {
+	_, err := imp.ImportJSONLWithStats(tableName, jsonlPath, structType)
+	return err
+}
+
+// ImportJSONLWithStats importiert JSONL-Datei in Tabelle und liefert Kennzahlen
+// der Quelldatei für die anschließende Verifikation (VerifyImport)
+func (imp *Importer) ImportJSONLWithStats(tableName, jsonlPath string, structType reflect.Type) (*SourceStats, error) {
 	file, err := os.Open(jsonlPath)
 	if err != nil {
-		return fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
 	// Transaction für Performance
 	tx, err := imp.db.Begin()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Prepare Insert Statement
 	insertSQL, err := imp.buildInsertSQL(tableName, structType)
 	if err != nil {
-		return fmt.Errorf("failed to build insert SQL: %w", err)
+		return nil, fmt.Errorf("failed to build insert SQL: %w", err)
 	}
 
 	stmt, err := tx.Prepare(insertSQL)
 	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %w", err)
+		return nil, fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	// Stream JSONL
-	scanner := bufio.NewScanner(file)
-	count := 0
+	// Stream JSONL (Datei-Hash wird nebenbei berechnet)
+	fileHash := sha256.New()
+	scanner := bufio.NewScanner(io.TeeReader(file, fileHash))
+	stats := &SourceStats{File: jsonlPath}
+	var rowHash rowHasher
+	columns := columnNames(structType)
 
 	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue // Leerzeilen zählen nicht als Datensatz
+		}
+		stats.Lines++
+
 		// Parse JSON
 		data := make(map[string]interface{})
 		if err := json.Unmarshal(scanner.Bytes(), &data); err != nil {
+			stats.SkippedLines++
 			continue // Skip fehlerhafte Zeilen
 		}
 
 		// Werte extrahieren und einfügen
 		values, err := imp.extractValues(data, structType)
 		if err != nil {
-			return fmt.Errorf("failed to extract values: %w", err)
+			return nil, fmt.Errorf("failed to extract values: %w", err)
 		}
 
 		if _, err := stmt.Exec(values...); err != nil {
-			return fmt.Errorf("failed to insert row: %w", err)
+			return nil, fmt.Errorf("failed to insert row: %w", err)
 		}
 
-		count++
+		// Quell-Hash unabhängig von extractValues aus dem Datensatz selbst
+		source, err := sourceRow(scanner.Bytes(), columns)
+		if err != nil {
+			return nil, err
+		}
+		rowHash.add(source)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("scanner error: %w", err)
+		return nil, fmt.Errorf("scanner error: %w", err)
 	}
 
 	// Commit
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit: %w", err)
+		return nil, fmt.Errorf("failed to commit: %w", err)
 	}
 
-	return nil
+	stats.SHA256 = hex.EncodeToString(fileHash.Sum(nil))
+	stats.RowHash = rowHash.String()
+
+	return stats, nil
 }
 
 // buildInsertSQL erstellt INSERT Statement
 func (imp *Importer) buildInsertSQL(tableName string, structType reflect.Type) (string, error) {
-	var columns []string
-
-	for i := 0; i < structType.NumField(); i++ {
-		field := structType.Field(i)
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" || jsonTag == "-" {
-			continue
-		}
-
-		parts := strings.Split(jsonTag, ",")
-		columnName := parts[0]
-		columns = append(columns, columnName)
-	}
+	columns := columnNames(structType)
 
 	placeholders := make([]string, len(columns))
 	for i := range placeholders {
@@ -147,6 +163,24 @@ func (imp *Importer) buildInsertSQL(tableName string, structType reflect.Type) (
 	return sql, nil
 }
 
+// columnNames liefert die Spaltennamen (JSON-Tags) eines Structs in Feld-Reihenfolge
+func columnNames(structType reflect.Type) []string {
+	var columns []string
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+
+		parts := strings.Split(jsonTag, ",")
+		columns = append(columns, parts[0])
+	}
+
+	return columns
+}
+
 // extractValues extrahiert Werte aus JSON-Map für Insert
 func (imp *Importer) extractValues(data map[string]interface{}, structType reflect.Type) ([]interface{}, error) {
 	values := make([]interface{}, 0, structType.NumField())
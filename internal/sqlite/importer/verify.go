@@ -0,0 +1,337 @@
+package importer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VerificationTable speichert die Verifikationsergebnisse aller Importe
+const VerificationTable = "_import_verification"
+
+// SourceStats beschreibt die JSONL-Quelldatei eines Imports
+type SourceStats struct {
+	File         string
+	Lines        int    // Nicht-leere Zeilen
+	SkippedLines int    // Nicht parsebare Zeilen (nicht importiert)
+	SHA256       string // Hash der gesamten Datei
+	RowHash      string // Reihenfolgeunabhängiger Hash der dekodierten Quelldatensätze (gemappte Spalten)
+}
+
+// Verification vergleicht Quelldatei und importierte Tabelle
+type Verification struct {
+	Table        string
+	SourceFile   string
+	SourceSHA256 string
+	SourceLines  int
+	SkippedLines int
+	RowCount     int
+	SourceHash   string
+	DBHash       string
+	VerifiedAt   time.Time
+}
+
+// OK prüft ob Zeilenzahl und Inhalt übereinstimmen
+func (v *Verification) OK() bool {
+	return v.SkippedLines == 0 && v.SourceLines == v.RowCount && v.SourceHash == v.DBHash
+}
+
+// String beschreibt das Ergebnis für Logs
+func (v *Verification) String() string {
+	status := "OK"
+	if !v.OK() {
+		status = "MISMATCH"
+	}
+	return fmt.Sprintf("%s: %s (lines=%d skipped=%d rows=%d hash=%s/%s)",
+		v.Table, status, v.SourceLines, v.SkippedLines, v.RowCount, v.SourceHash, v.DBHash)
+}
+
+// VerifyImport vergleicht die Quelldatei-Kennzahlen mit COUNT(*) und einem
+// reihenfolgeunabhängigen Hash der Tabelleninhalte und speichert das Ergebnis
+// in VerificationTable
+func (imp *Importer) VerifyImport(tableName string, structType reflect.Type, stats *SourceStats) (*Verification, error) {
+	v := &Verification{
+		Table:        tableName,
+		SourceFile:   stats.File,
+		SourceSHA256: stats.SHA256,
+		SourceLines:  stats.Lines,
+		SkippedLines: stats.SkippedLines,
+		SourceHash:   stats.RowHash,
+		VerifiedAt:   time.Now().UTC(),
+	}
+
+	columns := columnNames(structType)
+	jsonCols := jsonColumns(structType)
+	rows, err := imp.db.Query(fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	values := make([]interface{}, len(columns))
+	ptrs := make([]interface{}, len(columns))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	var hash rowHasher
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", tableName, err)
+		}
+		hash.add(dbRow(values, jsonCols))
+		v.RowCount++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	v.DBHash = hash.String()
+
+	if err := imp.storeVerification(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// storeVerification schreibt ein Ergebnis nach VerificationTable
+func (imp *Importer) storeVerification(v *Verification) error {
+	ddl := `CREATE TABLE IF NOT EXISTS ` + VerificationTable + ` (
+  table_name TEXT PRIMARY KEY,
+  source_file TEXT NOT NULL,
+  source_sha256 TEXT NOT NULL,
+  source_lines INTEGER NOT NULL,
+  skipped_lines INTEGER NOT NULL,
+  row_count INTEGER NOT NULL,
+  source_row_hash TEXT NOT NULL,
+  db_row_hash TEXT NOT NULL,
+  ok INTEGER NOT NULL,
+  verified_at TEXT NOT NULL
+);`
+	if _, err := imp.db.Exec(ddl); err != nil {
+		return fmt.Errorf("failed to create %s: %w", VerificationTable, err)
+	}
+
+	ok := 0
+	if v.OK() {
+		ok = 1
+	}
+
+	_, err := imp.db.Exec(`INSERT OR REPLACE INTO `+VerificationTable+` VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		v.Table, v.SourceFile, v.SourceSHA256, v.SourceLines, v.SkippedLines, v.RowCount,
+		v.SourceHash, v.DBHash, ok, v.VerifiedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to store verification: %w", err)
+	}
+	return nil
+}
+
+// rowHasher summiert Zeilen-Hashes (mod 2^64) – reihenfolgeunabhängig,
+// aber empfindlich für doppelte oder fehlende Zeilen
+type rowHasher struct {
+	sum uint64
+}
+
+// add fügt eine Zeile aus kanonischen Spaltenwerten hinzu
+func (h *rowHasher) add(values []string) {
+	digest := sha256.Sum256([]byte(strings.Join(values, "\x1f")))
+	h.sum += binary.BigEndian.Uint64(digest[:8])
+}
+
+// String liefert den Hash als Hex-String
+func (h *rowHasher) String() string {
+	return fmt.Sprintf("%016x", h.sum)
+}
+
+// Kanonische Darstellung: NULL, Zahl ("n:"), Text ("s:") und JSON ("j:") sind getrennt,
+// sodass "1" und 1 verschieden hashen. Bool wird wie in SQLite als Zahl 0/1 geführt.
+const canonicalNull = "\x00"
+
+// sourceRow dekodiert eine JSONL-Zeile unabhängig vom Importer und liefert die
+// kanonischen Werte der gemappten Spalten (fehlende Felder = NULL)
+func sourceRow(line []byte, columns []string) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+
+	var record map[string]interface{}
+	if err := dec.Decode(&record); err != nil {
+		return nil, fmt.Errorf("failed to decode source record: %w", err)
+	}
+
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = canonicalSource(record[column])
+	}
+	return values, nil
+}
+
+// canonicalSource normalisiert einen mit UseNumber dekodierten JSON-Wert
+func canonicalSource(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return canonicalNull
+	case bool:
+		if x {
+			return "n:1"
+		}
+		return "n:0"
+	case json.Number:
+		return "n:" + canonicalNumber(x)
+	case string:
+		return "s:" + x
+	default:
+		var b strings.Builder
+		writeCanonicalJSON(&b, x)
+		return "j:" + b.String()
+	}
+}
+
+// jsonColumn beschreibt, ob der Importer eine Spalte als JSON-Text speichert
+type jsonColumn int
+
+const (
+	jsonNever     jsonColumn = iota // Primitive Typen
+	jsonAlways                      // Structs, Slices, Maps
+	jsonComposite                   // interface{}: nur Objekte/Arrays, Primitive direkt
+)
+
+// dbRow liefert die kanonischen Werte einer gelesenen Tabellenzeile; JSON-Spalten
+// werden dekodiert und wie die Quelle normalisiert
+func dbRow(values []interface{}, jsonCols []jsonColumn) []string {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = canonicalDB(v, jsonCols[i])
+	}
+	return row
+}
+
+// canonicalDB normalisiert einen von SQLite gelesenen Wert
+func canonicalDB(v interface{}, col jsonColumn) string {
+	switch x := v.(type) {
+	case nil:
+		return canonicalNull
+	case int64:
+		return "n:" + strconv.FormatInt(x, 10)
+	case float64:
+		return "n:" + canonicalFloat(x)
+	case []byte:
+		return canonicalDBText(string(x), col)
+	case string:
+		return canonicalDBText(x, col)
+	default:
+		return "s:" + fmt.Sprint(x)
+	}
+}
+
+// canonicalDBText dekodiert Text aus JSON-Spalten, alle anderen Texte bleiben Text
+func canonicalDBText(s string, col jsonColumn) string {
+	switch col {
+	case jsonNever:
+		return "s:" + s
+	case jsonComposite:
+		if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+			return "s:" + s
+		}
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var decoded interface{}
+	if err := dec.Decode(&decoded); err != nil {
+		return "s:" + s
+	}
+	return canonicalSource(decoded)
+}
+
+// jsonColumns klassifiziert die Spalten eines Structs wie convertValueForSQL
+func jsonColumns(structType reflect.Type) []jsonColumn {
+	var cols []jsonColumn
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+
+		t := field.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Map:
+			cols = append(cols, jsonAlways)
+		case reflect.Interface:
+			cols = append(cols, jsonComposite)
+		default:
+			cols = append(cols, jsonNever)
+		}
+	}
+	return cols
+}
+
+// writeCanonicalJSON schreibt JSON mit sortierten Schlüsseln und normalisierten Zahlen
+func writeCanonicalJSON(b *strings.Builder, v interface{}) {
+	switch x := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(x))
+	case json.Number:
+		b.WriteString(canonicalNumber(x))
+	case string:
+		b.WriteString(strconv.Quote(x))
+	case []interface{}:
+		b.WriteByte('[')
+		for i, item := range x {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCanonicalJSON(b, item)
+		}
+		b.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(k))
+			b.WriteByte(':')
+			writeCanonicalJSON(b, x[k])
+		}
+		b.WriteByte('}')
+	default:
+		b.WriteString(fmt.Sprint(x))
+	}
+}
+
+// canonicalNumber stellt JSON-Zahlen wie SQLite-Werte dar (42.0 ≙ 42)
+func canonicalNumber(n json.Number) string {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return strconv.FormatInt(i, 10)
+	}
+	if f, err := strconv.ParseFloat(string(n), 64); err == nil {
+		return canonicalFloat(f)
+	}
+	return string(n)
+}
+
+// canonicalFloat stellt ganzzahlige Floats als Integer dar
+func canonicalFloat(f float64) string {
+	if f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
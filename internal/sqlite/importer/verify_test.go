@@ -0,0 +1,238 @@
+package importer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupVerifyImport importiert JSONL in eine frische Tabelle und liefert die Quell-Kennzahlen
+func setupVerifyImport(t *testing.T, jsonlContent string) (*Importer, *SourceStats) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	tmpJSONL := filepath.Join(tmpDir, "test.jsonl")
+	if err := os.WriteFile(tmpJSONL, []byte(jsonlContent), 0644); err != nil {
+		t.Fatalf("Failed to write JSONL: %v", err)
+	}
+
+	imp, err := NewImporter(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("NewImporter failed: %v", err)
+	}
+	t.Cleanup(func() { imp.Close() })
+
+	createSQL := `CREATE TABLE test_items (
+		_key INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		active INTEGER NOT NULL,
+		value INTEGER
+	)`
+	if _, err := imp.db.Exec(createSQL); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	stats, err := imp.ImportJSONLWithStats("test_items", tmpJSONL, reflect.TypeOf(TestType{}))
+	if err != nil {
+		t.Fatalf("ImportJSONLWithStats failed: %v", err)
+	}
+	return imp, stats
+}
+
+func TestVerifyImport_OK(t *testing.T) {
+	// 200.0 ≙ 200 (INTEGER); nicht gemappte Felder gehen nicht in den Hash ein
+	imp, stats := setupVerifyImport(t, `{"_key":1,"name":"Item1","active":true,"value":100}
+
+{"_key":2,"name":"Item2","active":false,"extra":"x"}
+{"_key":3,"name":"Item3","active":true,"value":200.0}
+`)
+
+	if stats.Lines != 3 {
+		t.Errorf("Lines = %d, want 3 (empty line ignored)", stats.Lines)
+	}
+	if len(stats.SHA256) != 64 {
+		t.Errorf("SHA256 = %q, want 64 hex chars", stats.SHA256)
+	}
+
+	v, err := imp.VerifyImport("test_items", reflect.TypeOf(TestType{}), stats)
+	if err != nil {
+		t.Fatalf("VerifyImport failed: %v", err)
+	}
+	if !v.OK() {
+		t.Errorf("Verification not OK: %s", v)
+	}
+
+	var ok, rowCount int
+	err = imp.db.QueryRow("SELECT ok, row_count FROM "+VerificationTable+" WHERE table_name = 'test_items'").
+		Scan(&ok, &rowCount)
+	if err != nil {
+		t.Fatalf("Failed to query %s: %v", VerificationTable, err)
+	}
+	if ok != 1 || rowCount != 3 {
+		t.Errorf("stored ok=%d row_count=%d, want 1 and 3", ok, rowCount)
+	}
+}
+
+func TestVerifyImport_SkippedLines(t *testing.T) {
+	imp, stats := setupVerifyImport(t, `{"_key":1,"name":"Item1","active":true}
+{invalid json}
+`)
+
+	v, err := imp.VerifyImport("test_items", reflect.TypeOf(TestType{}), stats)
+	if err != nil {
+		t.Fatalf("VerifyImport failed: %v", err)
+	}
+	if v.OK() {
+		t.Error("Verification should fail with skipped lines")
+	}
+	if v.SkippedLines != 1 || v.RowCount != 1 || v.SourceLines != 2 {
+		t.Errorf("got skipped=%d rows=%d lines=%d, want 1/1/2", v.SkippedLines, v.RowCount, v.SourceLines)
+	}
+}
+
+func TestVerifyImport_ContentMismatch(t *testing.T) {
+	imp, stats := setupVerifyImport(t, `{"_key":1,"name":"Item1","active":true}
+{"_key":2,"name":"Item2","active":false}
+`)
+
+	if _, err := imp.db.Exec("UPDATE test_items SET name = 'Changed' WHERE _key = 2"); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+
+	v, err := imp.VerifyImport("test_items", reflect.TypeOf(TestType{}), stats)
+	if err != nil {
+		t.Fatalf("VerifyImport failed: %v", err)
+	}
+	if v.OK() {
+		t.Error("Verification should detect changed content")
+	}
+	if v.RowCount != v.SourceLines {
+		t.Errorf("RowCount = %d, SourceLines = %d, want equal", v.RowCount, v.SourceLines)
+	}
+}
+
+func TestVerifyImport_DroppedField(t *testing.T) {
+	imp, stats := setupVerifyImport(t, `{"_key":1,"name":"Item1","active":true,"value":100}
+{"_key":2,"name":"Item2","active":false,"value":200}
+`)
+
+	// Simuliert einen Importer, der ein gemapptes Feld verliert
+	if _, err := imp.db.Exec("UPDATE test_items SET value = NULL WHERE _key = 2"); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+
+	v, err := imp.VerifyImport("test_items", reflect.TypeOf(TestType{}), stats)
+	if err != nil {
+		t.Fatalf("VerifyImport failed: %v", err)
+	}
+	if v.OK() {
+		t.Error("Verification should detect dropped field")
+	}
+}
+
+func TestVerifyImport_TypeMismatch(t *testing.T) {
+	// "100" (Text) landet per INTEGER-Affinität als 100 in der Tabelle
+	imp, stats := setupVerifyImport(t, `{"_key":1,"name":"Item1","active":true,"value":"100"}
+`)
+
+	v, err := imp.VerifyImport("test_items", reflect.TypeOf(TestType{}), stats)
+	if err != nil {
+		t.Fatalf("VerifyImport failed: %v", err)
+	}
+	if v.OK() {
+		t.Error("Verification should detect string stored as number")
+	}
+}
+
+func TestVerifyImport_JSONColumns(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpJSONL := filepath.Join(tmpDir, "test.jsonl")
+	content := `{"_key":1,"data":{"b":1.0,"a":[true,"x"]},"tags":["t1","t2"]}
+{"_key":2,"tags":[]}
+`
+	if err := os.WriteFile(tmpJSONL, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write JSONL: %v", err)
+	}
+
+	imp, err := NewImporter(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		t.Fatalf("NewImporter failed: %v", err)
+	}
+	defer imp.Close()
+
+	if _, err := imp.db.Exec(`CREATE TABLE complex_items (_key INTEGER PRIMARY KEY, data TEXT, tags TEXT)`); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	stats, err := imp.ImportJSONLWithStats("complex_items", tmpJSONL, reflect.TypeOf(ComplexType{}))
+	if err != nil {
+		t.Fatalf("ImportJSONLWithStats failed: %v", err)
+	}
+
+	v, err := imp.VerifyImport("complex_items", reflect.TypeOf(ComplexType{}), stats)
+	if err != nil {
+		t.Fatalf("VerifyImport failed: %v", err)
+	}
+	if !v.OK() {
+		t.Errorf("Verification not OK: %s", v)
+	}
+}
+
+func TestRowHasher_OrderIndependent(t *testing.T) {
+	var a, b rowHasher
+	a.add([]string{"n:1", "s:x"})
+	a.add([]string{"n:2", "s:y"})
+	b.add([]string{"n:2", "s:y"})
+	b.add([]string{"n:1", "s:x"})
+
+	if a.String() != b.String() {
+		t.Errorf("hash differs for reordered rows: %s != %s", a.String(), b.String())
+	}
+
+	// Doppelte Zeilen dürfen sich nicht aufheben
+	var c rowHasher
+	c.add([]string{"n:1", "s:x"})
+	c.add([]string{"n:1", "s:x"})
+	if c.String() == (&rowHasher{}).String() {
+		t.Error("duplicate rows cancel out")
+	}
+}
+
+func TestCanonicalSourceAndDB(t *testing.T) {
+	equal := []struct {
+		source interface{}
+		db     interface{}
+		col    jsonColumn
+	}{
+		{json.Number("42"), int64(42), jsonNever},
+		{json.Number("42.0"), int64(42), jsonNever},
+		{json.Number("1.5"), float64(1.5), jsonNever},
+		{true, int64(1), jsonNever},
+		{"abc", []byte("abc"), jsonNever},
+		{"123", "123", jsonComposite},
+		{map[string]interface{}{"b": json.Number("1.0"), "a": "x"}, `{"a":"x","b":1}`, jsonAlways},
+		{[]interface{}{json.Number("2")}, `[2]`, jsonComposite},
+	}
+	for _, tt := range equal {
+		if got, want := canonicalSource(tt.source), canonicalDB(tt.db, tt.col); got != want {
+			t.Errorf("canonicalSource(%v) = %q, canonicalDB(%v) = %q, want equal", tt.source, got, tt.db, want)
+		}
+	}
+
+	differ := []struct {
+		source interface{}
+		db     interface{}
+	}{
+		{"1", int64(1)},
+		{json.Number("1"), "1"},
+		{nil, ""},
+		{"", nil},
+	}
+	for _, tt := range differ {
+		if canonicalSource(tt.source) == canonicalDB(tt.db, jsonNever) {
+			t.Errorf("canonicalSource(%v) and canonicalDB(%v) must differ", tt.source, tt.db)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+// Package migrate gleicht das Schema einer bestehenden Datenbank an das
+// gewünschte Schema aus schema.Generator an
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/schema"
+)
+
+// VersionTable speichert die Historie der Schema-Versionen
+const VersionTable = "_schema_version"
+
+// Kind beschreibt die Art einer Migration
+type Kind int
+
+const (
+	// KindNone: Tabelle entspricht dem gewünschten Schema
+	KindNone Kind = iota
+	// KindCreate: Tabelle existiert noch nicht
+	KindCreate
+	// KindAddColumns: Fehlende Spalten per ALTER TABLE ADD COLUMN
+	KindAddColumns
+	// KindRebuild: Tabelle wird neu aufgebaut (entfernte/geänderte Spalten, Constraints, STRICT)
+	KindRebuild
+)
+
+// String implementiert Stringer für Kind
+func (k Kind) String() string {
+	switch k {
+	case KindNone:
+		return "none"
+	case KindCreate:
+		return "create"
+	case KindAddColumns:
+		return "add-columns"
+	case KindRebuild:
+		return "rebuild"
+	default:
+		return fmt.Sprintf("kind(%d)", int(k))
+	}
+}
+
+// Table beschreibt eine gewünschte Tabelle
+type Table struct {
+	Name       string
+	StructType reflect.Type
+}
+
+// Migration ist die geplante Änderung einer Tabelle
+type Migration struct {
+	Table      string
+	Kind       Kind
+	AddColumns []schema.Column
+	Reason     string
+	// Reimport: Neuaufbau konnte die Daten nicht übernehmen (neue Pflichtspalte)
+	Reimport bool
+}
+
+// String beschreibt die Migration für Logs und die Versionshistorie
+func (m Migration) String() string {
+	s := fmt.Sprintf("%s: %s", m.Table, m.Kind)
+	if m.Reason != "" {
+		s += " (" + m.Reason + ")"
+	}
+	if m.Reimport {
+		s += ", reimport required"
+	}
+	return s
+}
+
+// Report fasst einen Migrationslauf zusammen
+type Report struct {
+	Version    int
+	SchemaHash string
+	Applied    []Migration
+}
+
+// Plan vergleicht das gewünschte Schema einer Tabelle mit sqlite_master und
+// PRAGMA table_xinfo
+func Plan(db *sql.DB, gen *schema.Generator, table Table) (Migration, error) {
+	m := Migration{Table: table.Name}
+
+	var existingSQL string
+	err := db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table.Name).Scan(&existingSQL)
+	if err == sql.ErrNoRows {
+		m.Kind = KindCreate
+		return m, nil
+	}
+	if err != nil {
+		return m, fmt.Errorf("failed to read schema of %s: %w", table.Name, err)
+	}
+
+	desired, err := gen.Columns(table.Name, table.StructType)
+	if err != nil {
+		return m, err
+	}
+
+	existingNames, err := columnNames(db, table.Name)
+	if err != nil {
+		return m, err
+	}
+	existingDefs, existingOptions := parseTableSQL(existingSQL)
+
+	if normalize(existingOptions) != normalize(gen.TableOptions()) {
+		m.Kind, m.Reason = KindRebuild, "table options changed"
+		return m, nil
+	}
+
+	desiredNames := make(map[string]bool, len(desired))
+	for _, col := range desired {
+		desiredNames[col.Name] = true
+
+		if !existingNames[col.Name] {
+			if !addable(col) {
+				m.Kind, m.Reason = KindRebuild, "column "+col.Name+" cannot be added"
+				return m, nil
+			}
+			m.AddColumns = append(m.AddColumns, col)
+			continue
+		}
+
+		if normalize(existingDefs[col.Name]) != normalize(col.Definition) {
+			m.Kind, m.Reason = KindRebuild, "column "+col.Name+" changed"
+			return m, nil
+		}
+	}
+
+	for name := range existingNames {
+		if !desiredNames[name] {
+			m.Kind, m.Reason = KindRebuild, "column "+name+" removed"
+			return m, nil
+		}
+	}
+
+	if len(m.AddColumns) > 0 {
+		m.Kind = KindAddColumns
+		names := make([]string, len(m.AddColumns))
+		for i, col := range m.AddColumns {
+			names[i] = col.Name
+		}
+		m.Reason = strings.Join(names, ", ")
+	}
+
+	return m, nil
+}
+
+// Migrate plant und führt alle Migrationen in einer Transaktion aus und
+// protokolliert die Schema-Version in VersionTable
+func Migrate(db *sql.DB, gen *schema.Generator, tables []Table) (*Report, error) {
+	ctx := context.Background()
+
+	// Eigene Verbindung, da legacy_alter_table pro Verbindung gilt
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Rename ohne Umschreiben/Prüfen abhängiger Views (werden danach neu erstellt),
+	// Fremdschlüssel dürfen beim Neuaufbau kurzzeitig ins Leere zeigen
+	for _, pragma := range []string{"PRAGMA legacy_alter_table=ON", "PRAGMA foreign_keys=OFF"} {
+		if _, err := conn.ExecContext(ctx, pragma); err != nil {
+			return nil, fmt.Errorf("failed to execute %s: %w", pragma, err)
+		}
+	}
+	defer conn.ExecContext(ctx, "PRAGMA legacy_alter_table=OFF")
+
+	var migrations []Migration
+	for _, table := range tables {
+		m, err := Plan(db, gen, table)
+		if err != nil {
+			return nil, err
+		}
+		if m.Kind != KindNone {
+			migrations = append(migrations, m)
+		}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i := range migrations {
+		if err := apply(tx, gen, tables, &migrations[i]); err != nil {
+			return nil, fmt.Errorf("migration %s failed: %w", migrations[i], err)
+		}
+	}
+
+	report, err := recordVersion(tx, gen, tables, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit migrations: %w", err)
+	}
+
+	return report, nil
+}
+
+// CurrentVersion liefert die zuletzt protokollierte Schema-Version (0 = keine)
+func CurrentVersion(db *sql.DB) (int, string, error) {
+	var version int
+	var hash string
+	err := db.QueryRow("SELECT version, schema_hash FROM "+VersionTable+" ORDER BY version DESC LIMIT 1").
+		Scan(&version, &hash)
+	if err == sql.ErrNoRows || (err != nil && strings.Contains(err.Error(), "no such table")) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, hash, nil
+}
+
+// apply führt eine einzelne Migration aus
+func apply(tx *sql.Tx, gen *schema.Generator, tables []Table, m *Migration) error {
+	table := findTable(tables, m.Table)
+
+	switch m.Kind {
+	case KindCreate:
+		ddl, err := gen.GenerateTable(table.Name, table.StructType)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ddl)
+		return err
+
+	case KindAddColumns:
+		for _, col := range m.AddColumns {
+			if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", m.Table, col.Definition)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case KindRebuild:
+		reimport, err := rebuild(tx, gen, table)
+		m.Reimport = reimport
+		return err
+
+	default:
+		return nil
+	}
+}
+
+// rebuild baut eine Tabelle nach dem SQLite-Verfahren neu auf:
+// neue Tabelle anlegen, gemeinsame Spalten kopieren, alte löschen, umbenennen.
+// Indices werden mit der alten Tabelle gelöscht und vom Aufrufer neu erstellt.
+// Fehlt einer neuen Pflichtspalte die Quelle, bleibt die Tabelle leer (reimport = true).
+func rebuild(tx *sql.Tx, gen *schema.Generator, table Table) (reimport bool, err error) {
+	tmpName := table.Name + "__migrate"
+
+	desired, err := gen.Columns(table.Name, table.StructType)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := columnNames(tx, table.Name)
+	if err != nil {
+		return false, err
+	}
+
+	var common []string
+	for _, col := range desired {
+		switch {
+		case col.Generated:
+			continue
+		case existing[col.Name]:
+			common = append(common, col.Name)
+		case col.PrimaryKey || col.NotNull:
+			reimport = true
+		}
+	}
+	if reimport {
+		common = nil
+	}
+
+	// Self-References zeigen bereits auf den finalen Tabellennamen
+	ddl, err := gen.GenerateTable(table.Name, table.StructType)
+	if err != nil {
+		return false, err
+	}
+	ddl = strings.Replace(ddl, "CREATE TABLE IF NOT EXISTS "+table.Name+" (", "CREATE TABLE "+tmpName+" (", 1)
+
+	stmts := []string{"DROP TABLE IF EXISTS " + tmpName, ddl}
+	if len(common) > 0 {
+		cols := strings.Join(common, ", ")
+		stmts = append(stmts, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s", tmpName, cols, cols, table.Name))
+	}
+	stmts = append(stmts,
+		"DROP TABLE "+table.Name,
+		fmt.Sprintf("ALTER TABLE %s RENAME TO %s", tmpName, table.Name),
+	)
+
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return false, fmt.Errorf("%s: %w", strings.SplitN(stmt, "(", 2)[0], err)
+		}
+	}
+	return reimport, nil
+}
+
+// recordVersion schreibt eine neue Version, wenn sich das Schema geändert hat
+func recordVersion(tx *sql.Tx, gen *schema.Generator, tables []Table, migrations []Migration) (*Report, error) {
+	ddl := `CREATE TABLE IF NOT EXISTS ` + VersionTable + ` (
+  version INTEGER PRIMARY KEY,
+  schema_hash TEXT NOT NULL,
+  applied_at TEXT NOT NULL,
+  changes TEXT NOT NULL
+);`
+	if _, err := tx.Exec(ddl); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", VersionTable, err)
+	}
+
+	hash, err := schemaHash(gen, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{SchemaHash: hash, Applied: migrations}
+
+	var lastHash string
+	err = tx.QueryRow("SELECT version, schema_hash FROM "+VersionTable+" ORDER BY version DESC LIMIT 1").
+		Scan(&report.Version, &lastHash)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if lastHash == hash && len(migrations) == 0 {
+		return report, nil
+	}
+
+	changes := make([]string, len(migrations))
+	for i, m := range migrations {
+		changes[i] = m.String()
+	}
+
+	report.Version++
+	_, err = tx.Exec("INSERT INTO "+VersionTable+" (version, schema_hash, applied_at, changes) VALUES (?, ?, ?, ?)",
+		report.Version, hash, time.Now().UTC().Format(time.RFC3339), strings.Join(changes, "; "))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return report, nil
+}
+
+// schemaHash ist der SHA-256 aller gewünschten CREATE TABLE Statements
+func schemaHash(gen *schema.Generator, tables []Table) (string, error) {
+	h := sha256.New()
+	for _, table := range tables {
+		ddl, err := gen.GenerateTable(table.Name, table.StructType)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte(ddl))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// queryer abstrahiert *sql.DB und *sql.Tx
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// columnNames liefert die Spalten einer Tabelle (inkl. generierter Spalten)
+func columnNames(q queryer, table string) (map[string]bool, error) {
+	rows, err := q.Query(fmt.Sprintf("PRAGMA table_xinfo(%s)", table))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid, notNull, pk, hidden int
+			name, colType            string
+			defaultValue             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk, &hidden); err != nil {
+			return nil, err
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
+
+// addable prüft ob eine Spalte per ALTER TABLE ADD COLUMN ergänzt werden kann
+func addable(col schema.Column) bool {
+	return !col.PrimaryKey && !col.NotNull && !strings.Contains(strings.ToUpper(col.Definition), " STORED")
+}
+
+// parseTableSQL zerlegt ein gespeichertes CREATE TABLE in Spaltendefinitionen
+// (Name → Definition) und Tabellen-Optionen nach der schließenden Klammer
+func parseTableSQL(ddl string) (map[string]string, string) {
+	defs := make(map[string]string)
+
+	open := strings.Index(ddl, "(")
+	if open < 0 {
+		return defs, ""
+	}
+
+	depth, start, closeIdx := 0, open+1, -1
+	inQuote := byte(0)
+	var parts []string
+
+	for i := open; i < len(ddl) && closeIdx < 0; i++ {
+		c := ddl[i]
+		switch {
+		case inQuote != 0:
+			if c == inQuote {
+				inQuote = 0
+			}
+		case c == '\'' || c == '"':
+			inQuote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				parts = append(parts, ddl[start:i])
+				closeIdx = i
+			}
+		case c == ',' && depth == 1:
+			parts = append(parts, ddl[start:i])
+			start = i + 1
+		}
+	}
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name := strings.Trim(strings.Fields(part)[0], "\"`[]")
+		defs[name] = part
+	}
+
+	options := ""
+	if closeIdx >= 0 {
+		options = strings.TrimSuffix(strings.TrimSpace(ddl[closeIdx+1:]), ";")
+	}
+	return defs, options
+}
+
+var (
+	whitespace  = regexp.MustCompile(`\s+`)
+	punctSpaces = regexp.MustCompile(`\s*([(),])\s*`)
+)
+
+// normalize entfernt Formatierungsunterschiede in SQL-Fragmenten
+func normalize(s string) string {
+	s = whitespace.ReplaceAllString(strings.TrimSpace(s), " ")
+	return punctSpaces.ReplaceAllString(s, "$1")
+}
+
+// findTable sucht eine Tabelle nach Namen
+func findTable(tables []Table, name string) Table {
+	for _, t := range tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return Table{Name: name}
+}
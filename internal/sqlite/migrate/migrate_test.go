@@ -0,0 +1,213 @@
+package migrate
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/schema"
+)
+
+type itemV1 struct {
+	Key   int64  `json:"_key"`
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// itemV2 ergänzt eine optionale Spalte (ADD COLUMN)
+type itemV2 struct {
+	Key    int64   `json:"_key"`
+	Name   string  `json:"name"`
+	Value  string  `json:"value,omitempty"`
+	Volume float64 `json:"volume,omitempty"`
+}
+
+// itemV3 entfernt value und ergänzt eine Pflichtspalte (Rebuild)
+type itemV3 struct {
+	Key    int64   `json:"_key"`
+	Name   string  `json:"name"`
+	Volume float64 `json:"volume,omitempty"`
+	Mass   float64 `json:"mass"`
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func migrate(t *testing.T, db *sql.DB, gen *schema.Generator, structType interface{}) *Report {
+	t.Helper()
+	report, err := Migrate(db, gen, []Table{{Name: "items", StructType: reflect.TypeOf(structType)}})
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	return report
+}
+
+func columns(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	names, err := columnNames(db, "items")
+	if err != nil {
+		t.Fatalf("columnNames failed: %v", err)
+	}
+	var list []string
+	for _, n := range []string{"_key", "name", "value", "volume", "mass"} {
+		if names[n] {
+			list = append(list, n)
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+func TestMigrate_CreateAddRebuild(t *testing.T) {
+	db := openDB(t)
+	gen := schema.NewGenerator()
+
+	report := migrate(t, db, gen, itemV1{})
+	if report.Version != 1 || len(report.Applied) != 1 || report.Applied[0].Kind != KindCreate {
+		t.Fatalf("v1 report = %+v, want version 1 with create", report)
+	}
+
+	if _, err := db.Exec(`INSERT INTO items (_key, name, value) VALUES (1, 'Tritanium', 'x')`); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	// Unverändertes Schema: keine neue Version
+	report = migrate(t, db, gen, itemV1{})
+	if report.Version != 1 || len(report.Applied) != 0 {
+		t.Errorf("unchanged report = %+v, want version 1 without migrations", report)
+	}
+
+	report = migrate(t, db, gen, itemV2{})
+	if report.Version != 2 || len(report.Applied) != 1 || report.Applied[0].Kind != KindAddColumns {
+		t.Fatalf("v2 report = %+v, want add-columns", report)
+	}
+	if got := columns(t, db); got != "_key,name,value,volume" {
+		t.Errorf("v2 columns = %s", got)
+	}
+
+	report = migrate(t, db, gen, itemV3{})
+	if report.Version != 3 || len(report.Applied) != 1 || report.Applied[0].Kind != KindRebuild {
+		t.Fatalf("v3 report = %+v, want rebuild", report)
+	}
+	if got := columns(t, db); got != "_key,name,volume,mass" {
+		t.Errorf("v3 columns = %s", got)
+	}
+
+	// Neue Pflichtspalte ohne Quelle: Daten können nicht übernommen werden
+	if !report.Applied[0].Reimport {
+		t.Error("Reimport = false, want true for new NOT NULL column")
+	}
+
+	version, hash, err := CurrentVersion(db)
+	if err != nil || version != 3 || hash != report.SchemaHash {
+		t.Errorf("CurrentVersion = %d, %q, %v; want 3, %q", version, hash, err, report.SchemaHash)
+	}
+}
+
+func TestMigrate_StrictRebuild(t *testing.T) {
+	db := openDB(t)
+	gen := schema.NewGenerator()
+	migrate(t, db, gen, itemV1{})
+	if _, err := db.Exec(`INSERT INTO items (_key, name) VALUES (1, 'Tritanium')`); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	gen.Strict = true
+	m, err := Plan(db, gen, Table{Name: "items", StructType: reflect.TypeOf(itemV1{})})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if m.Kind != KindRebuild {
+		t.Errorf("Kind = %s, want rebuild for STRICT change", m.Kind)
+	}
+
+	report := migrate(t, db, gen, itemV1{})
+	if report.Applied[0].Reimport {
+		t.Error("Reimport = true, want data carried over")
+	}
+
+	var name string
+	if err := db.QueryRow(`SELECT name FROM items WHERE _key = 1`).Scan(&name); err != nil || name != "Tritanium" {
+		t.Errorf("row after rebuild = %q, %v; want Tritanium", name, err)
+	}
+
+	var ddl string
+	if err := db.QueryRow(`SELECT sql FROM sqlite_master WHERE name = 'items'`).Scan(&ddl); err != nil {
+		t.Fatalf("Failed to read DDL: %v", err)
+	}
+	if !strings.HasSuffix(ddl, "STRICT") {
+		t.Errorf("DDL = %q, want STRICT table", ddl)
+	}
+}
+
+func TestMigrate_GeneratedColumns(t *testing.T) {
+	db := openDB(t)
+	gen := schema.NewGenerator()
+	migrate(t, db, gen, itemV1{})
+	if _, err := db.Exec(`INSERT INTO items (_key, name) VALUES (1, 'Tritanium')`); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	// Virtuelle Spalte wird per ADD COLUMN ergänzt
+	gen.Generated = map[string][]schema.GeneratedColumn{
+		"items": {{Name: "upperName", Type: "TEXT", Expr: "upper(name)"}},
+	}
+	report := migrate(t, db, gen, itemV1{})
+	if len(report.Applied) != 1 || report.Applied[0].Kind != KindAddColumns {
+		t.Fatalf("report = %+v, want add-columns", report)
+	}
+
+	// Beim Neuaufbau werden generierte Spalten nicht kopiert
+	gen.Strict = true
+	report = migrate(t, db, gen, itemV1{})
+	if len(report.Applied) != 1 || report.Applied[0].Kind != KindRebuild || report.Applied[0].Reimport {
+		t.Fatalf("report = %+v, want rebuild without reimport", report)
+	}
+
+	var upper string
+	if err := db.QueryRow(`SELECT upperName FROM items WHERE _key = 1`).Scan(&upper); err != nil || upper != "TRITANIUM" {
+		t.Errorf("upperName = %q, %v; want TRITANIUM", upper, err)
+	}
+
+	if report := migrate(t, db, gen, itemV1{}); len(report.Applied) != 0 {
+		t.Errorf("second run applied %+v, want no migrations", report.Applied)
+	}
+}
+
+func TestCurrentVersion_Empty(t *testing.T) {
+	version, hash, err := CurrentVersion(openDB(t))
+	if err != nil || version != 0 || hash != "" {
+		t.Errorf("CurrentVersion = %d, %q, %v; want 0", version, hash, err)
+	}
+}
+
+func TestParseTableSQL(t *testing.T) {
+	defs, options := parseTableSQL(`CREATE TABLE t (
+  _key INTEGER PRIMARY KEY,
+  kind TEXT CHECK (kind IN ('a,b', 'c')),
+  data TEXT
+, extra REAL) STRICT`)
+
+	if options != "STRICT" {
+		t.Errorf("options = %q, want STRICT", options)
+	}
+	if len(defs) != 4 {
+		t.Fatalf("defs = %v, want 4 columns", defs)
+	}
+	if normalize(defs["kind"]) != normalize("kind TEXT CHECK (kind IN ('a,b', 'c'))") {
+		t.Errorf("kind = %q", defs["kind"])
+	}
+	if defs["extra"] != "extra REAL" {
+		t.Errorf("extra = %q", defs["extra"])
+	}
+}
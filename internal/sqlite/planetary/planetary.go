@@ -0,0 +1,65 @@
+// Package planetary schreibt normalisierte Tabellen für Planetary Interaction (PI):
+// Ein- und Ausgaben sowie Pins der planetSchematics und die förderbaren Rohstoffe (P0) je Planetentyp
+package planetary
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Planet ordnet einem Planetentyp die förderbaren Rohstoffe (P0) zu
+type Planet struct {
+	// Planet: Typname ohne Präfix ("Barren" für "Planet (Barren)")
+	Planet    string   `yaml:"planet"`
+	Resources []string `yaml:"resources"`
+}
+
+// File ist das Format der Rohstoff-Definitionsdatei
+type File struct {
+	Planets []Planet `yaml:"planets"`
+}
+
+//go:embed resources.yaml
+var resourcesYAML []byte
+
+// DefaultResources liefert die Rohstoffe der acht PI-Planetentypen
+func DefaultResources() []Planet {
+	planets, err := ParseResources(resourcesYAML)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default planet resources: %v", err))
+	}
+	return planets
+}
+
+// ParseResources dekodiert und validiert Rohstoff-Definitionen (YAML)
+func ParseResources(data []byte) ([]Planet, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse planet resources: %w", err)
+	}
+	if len(file.Planets) == 0 {
+		return nil, fmt.Errorf("no planets defined")
+	}
+
+	seen := make(map[string]bool, len(file.Planets))
+	for i, p := range file.Planets {
+		switch {
+		case strings.TrimSpace(p.Planet) == "":
+			return nil, fmt.Errorf("planet %d: name is required", i)
+		case seen[p.Planet]:
+			return nil, fmt.Errorf("planet %s: duplicate name", p.Planet)
+		case len(p.Resources) == 0:
+			return nil, fmt.Errorf("planet %s: no resources", p.Planet)
+		}
+		seen[p.Planet] = true
+	}
+	return file.Planets, nil
+}
+
+// TypeName liefert den Namen des Planetentyps in types
+func (p Planet) TypeName() string {
+	return "Planet (" + p.Planet + ")"
+}
@@ -0,0 +1,106 @@
+package planetary
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "pi.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestDefaultResources(t *testing.T) {
+	planets := DefaultResources()
+	if len(planets) != 8 {
+		t.Fatalf("DefaultResources = %d planets, want 8", len(planets))
+	}
+	for _, p := range planets {
+		if len(p.Resources) != 5 {
+			t.Errorf("%s: %d resources, want 5", p.Planet, len(p.Resources))
+		}
+	}
+	if planets[0].TypeName() != "Planet (Barren)" {
+		t.Errorf("TypeName = %q", planets[0].TypeName())
+	}
+}
+
+func TestParseResources_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"leer":      "planets: []",
+		"ohne Name": "planets: [{resources: [Base Metals]}]",
+		"doppelt":   "planets: [{planet: Gas, resources: [Noble Gas]}, {planet: Gas, resources: [Reactive Gas]}]",
+		"ohne P0":   "planets: [{planet: Gas}]",
+		"kein YAML": "planets: [",
+	}
+	for name, data := range tests {
+		if _, err := ParseResources([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestBuildTables(t *testing.T) {
+	db := openTestDB(t)
+
+	// Ohne Quelltabellen bleiben die PI-Tabellen leer
+	stats, err := BuildTables(db, DefaultResources())
+	if err != nil {
+		t.Fatalf("BuildTables without sources failed: %v", err)
+	}
+	if stats != (Stats{}) {
+		t.Errorf("stats without sources = %+v, want empty", stats)
+	}
+
+	// Leere Quelltabellen (Teilimport in eine neue Datenbank) ebenso
+	if _, err := db.Exec(`
+		CREATE TABLE types (_key INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE planetSchematics (_key INTEGER PRIMARY KEY, cycleTime INTEGER, name TEXT, pins TEXT, types TEXT);
+	`); err != nil {
+		t.Fatalf("Failed to create empty tables: %v", err)
+	}
+	stats, err = BuildTables(db, DefaultResources())
+	if err != nil {
+		t.Fatalf("BuildTables with empty types failed: %v", err)
+	}
+	if stats != (Stats{}) {
+		t.Errorf("stats with empty types = %+v, want empty", stats)
+	}
+
+	if _, err := db.Exec(`
+		INSERT INTO types VALUES (2016, '{"en":"Planet (Barren)"}'), (2267, '{"en":"Base Metals"}'), (2389, '{"en":"Reactive Metals"}');
+		INSERT INTO planetSchematics VALUES
+			(134, 1800, '{"en":"Reactive Metals"}', '[2473,2481]', '[{"_key":2267,"isInput":true,"quantity":3000},{"_key":2389,"isInput":false,"quantity":20}]');
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+
+	stats, err = BuildTables(db, []Planet{{Planet: "Barren", Resources: []string{"Base Metals"}}})
+	if err != nil {
+		t.Fatalf("BuildTables failed: %v", err)
+	}
+	if want := (Stats{SchematicTypes: 2, SchematicPins: 2, Resources: 1}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+
+	var input bool
+	var quantity int64
+	if err := db.QueryRow("SELECT is_input, quantity FROM "+SchematicTypesTable+" WHERE schematic_id = 134 AND type_id = 2267").
+		Scan(&input, &quantity); err != nil || !input || quantity != 3000 {
+		t.Errorf("Base Metals input = %v, %d, %v", input, quantity, err)
+	}
+
+	_, err = BuildTables(db, []Planet{{Planet: "Barren", Resources: []string{"Unobtainium"}}})
+	if err == nil || !strings.Contains(err.Error(), "Unobtainium") {
+		t.Errorf("unknown resource: err = %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package planetary
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// PI-Tabellen
+const (
+	// SchematicTypesTable: Ein- und Ausgaben je Schematic und Zyklus
+	SchematicTypesTable = "planet_schematic_types"
+	// SchematicPinsTable: Fabrik-Pins, auf denen eine Schematic laufen kann
+	SchematicPinsTable = "planet_schematic_pins"
+	// ResourcesTable: Förderbare Rohstoffe (P0) je Planetentyp
+	ResourcesTable = "planet_type_resources"
+)
+
+// Stats fasst die geschriebenen PI-Tabellen zusammen
+type Stats struct {
+	SchematicTypes int
+	SchematicPins  int
+	Resources      int
+}
+
+// BuildTables erstellt die PI-Tabellen neu. Sind planetSchematics bzw. types leer (Teilimport in eine neue
+// Datenbank, das Schema legt alle Tabellen an), bleiben die betroffenen Tabellen leer; Rohstoffnamen, die ein
+// befülltes types nicht kennt, sind ein Fehler.
+func BuildTables(db *sql.DB, planets []Planet) (Stats, error) {
+	var stats Stats
+
+	tx, err := db.Begin()
+	if err != nil {
+		return stats, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		"DROP TABLE IF EXISTS " + SchematicTypesTable,
+		`CREATE TABLE ` + SchematicTypesTable + ` (
+  schematic_id INTEGER NOT NULL,
+  type_id INTEGER NOT NULL,
+  is_input INTEGER NOT NULL,
+  quantity INTEGER NOT NULL,
+  PRIMARY KEY (schematic_id, type_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_planet_schematic_types_type ON ` + SchematicTypesTable + `(type_id, is_input)`,
+
+		"DROP TABLE IF EXISTS " + SchematicPinsTable,
+		`CREATE TABLE ` + SchematicPinsTable + ` (
+  schematic_id INTEGER NOT NULL,
+  pin_type_id INTEGER NOT NULL,
+  PRIMARY KEY (schematic_id, pin_type_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_planet_schematic_pins_pin ON ` + SchematicPinsTable + `(pin_type_id)`,
+
+		"DROP TABLE IF EXISTS " + ResourcesTable,
+		`CREATE TABLE ` + ResourcesTable + ` (
+  planet_type_id INTEGER NOT NULL,
+  resource_type_id INTEGER NOT NULL,
+  PRIMARY KEY (planet_type_id, resource_type_id)
+) WITHOUT ROWID`,
+		`CREATE INDEX idx_planet_type_resources_resource ON ` + ResourcesTable + `(resource_type_id)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.Exec(stmt); err != nil {
+			return stats, fmt.Errorf("failed to create PI tables: %w", err)
+		}
+	}
+
+	hasSchematics, err := hasRows(tx, "planetSchematics")
+	if err != nil {
+		return stats, err
+	}
+	if hasSchematics {
+		// types-Einträge: {"_key": typeID, "isInput": bool, "quantity": n}
+		for _, stmt := range []string{
+			`INSERT INTO ` + SchematicTypesTable + ` (schematic_id, type_id, is_input, quantity)
+SELECT s._key, CAST(COALESCE(json_extract(t.value, '$._key'), json_extract(t.value, '$.typeID')) AS INTEGER),
+       MAX(COALESCE(json_extract(t.value, '$.isInput'), 0)), SUM(CAST(json_extract(t.value, '$.quantity') AS INTEGER))
+FROM planetSchematics s, json_each(s.types) t
+WHERE COALESCE(json_extract(t.value, '$._key'), json_extract(t.value, '$.typeID')) IS NOT NULL
+GROUP BY 1, 2`,
+			`INSERT OR IGNORE INTO ` + SchematicPinsTable + ` (schematic_id, pin_type_id)
+SELECT s._key, CAST(p.value AS INTEGER)
+FROM planetSchematics s, json_each(s.pins) p`,
+		} {
+			if _, err := tx.Exec(stmt); err != nil {
+				return stats, fmt.Errorf("failed to build PI schematic tables: %w", err)
+			}
+		}
+	}
+
+	hasTypes, err := hasRows(tx, "types")
+	if err != nil {
+		return stats, err
+	}
+	if hasTypes {
+		for _, p := range planets {
+			planetID, err := typeID(tx, p.TypeName())
+			if err != nil {
+				return stats, err
+			}
+			for _, name := range p.Resources {
+				resourceID, err := typeID(tx, name)
+				if err != nil {
+					return stats, err
+				}
+				if _, err := tx.Exec("INSERT OR IGNORE INTO "+ResourcesTable+" (planet_type_id, resource_type_id) VALUES (?, ?)",
+					planetID, resourceID); err != nil {
+					return stats, fmt.Errorf("failed to insert planet resource %s: %w", name, err)
+				}
+			}
+		}
+	}
+
+	counts := []struct {
+		table string
+		n     *int
+	}{
+		{SchematicTypesTable, &stats.SchematicTypes},
+		{SchematicPinsTable, &stats.SchematicPins},
+		{ResourcesTable, &stats.Resources},
+	}
+	for _, c := range counts {
+		if err := tx.QueryRow("SELECT COUNT(*) FROM " + c.table).Scan(c.n); err != nil {
+			return stats, fmt.Errorf("failed to count %s: %w", c.table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return stats, fmt.Errorf("failed to commit PI tables: %w", err)
+	}
+	return stats, nil
+}
+
+// hasRows prüft, ob eine Tabelle existiert und Zeilen enthält
+func hasRows(tx *sql.Tx, name string) (bool, error) {
+	var exists bool
+	if err := tx.QueryRow("SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?", name).
+		Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", name, err)
+	}
+	if !exists {
+		return false, nil
+	}
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM " + name + ")").Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", name, err)
+	}
+	return exists, nil
+}
+
+// typeID löst einen englischen Typnamen auf (kleinste ID bei Mehrdeutigkeit)
+func typeID(tx *sql.Tx, name string) (int64, error) {
+	var id int64
+	err := tx.QueryRow("SELECT _key FROM types WHERE json_extract(name, '$.en') = ? ORDER BY _key LIMIT 1", name).Scan(&id)
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, fmt.Errorf("planet resources: type %q not found", name)
+	case err != nil:
+		return 0, fmt.Errorf("failed to resolve type %q: %w", name, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+)
+
+// GeneratedColumn ist eine berechnete Spalte (GENERATED ALWAYS AS … VIRTUAL).
+// Sie wird nach den Struct-Feldern angehängt und vom Importer nicht befüllt.
+type GeneratedColumn struct {
+	Name string
+	Type string
+	Expr string
+}
+
+// Definition liefert die Spaltendefinition
+func (c GeneratedColumn) Definition() string {
+	return fmt.Sprintf("%s %s GENERATED ALWAYS AS (%s) VIRTUAL", c.Name, c.Type, c.Expr)
+}
+
+// DefaultGeneratedColumns: Tabelle → generierte Spalten
+var DefaultGeneratedColumns = map[string][]GeneratedColumn{
+	// Zentrale Security-Klassifikation (siehe internal/sqlite/security)
+	"mapSolarSystems": {
+		{Name: security.DisplayColumn, Type: "REAL", Expr: security.DisplaySQL},
+		{Name: security.ZoneColumn, Type: "TEXT", Expr: security.ZoneSQL},
+	},
+}
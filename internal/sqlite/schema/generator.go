@@ -6,27 +6,105 @@ import (
 	"strings"
 )
 
+// EnumType wird von generierten String-Enums (sde-schema-gen) implementiert
+type EnumType interface {
+	Values() []string
+}
+
+var enumTypeInterface = reflect.TypeOf((*EnumType)(nil)).Elem()
+
 // Generator erstellt SQLite DDL aus Go-Structs
 type Generator struct {
 	// LocalizedAsJSON: Wenn true, wird LocalizedText als JSON gespeichert
 	// Wenn false, separate Tabelle (nicht implementiert)
 	LocalizedAsJSON bool
+
+	// EnumChecks: Wenn true, erhalten Enum-Felder ein CHECK (col IN (...))
+	EnumChecks bool
+
+	// Strict: Wenn true, werden Tabellen als STRICT erzeugt (INTEGER/REAL/TEXT/ANY),
+	// JSON-Spalten erhalten CHECK(json_valid(col)) und Booleans CHECK(col IN (0,1))
+	Strict bool
+
+	// Tables: Bekannte Tabellen für die Fremdschlüssel-Inferenz (leer = keine Inferenz)
+	Tables []string
+
+	// ReferenceOverrides: "table.column" oder "column" → Zieltabelle
+	// Ein leerer Wert unterdrückt die Referenz
+	ReferenceOverrides map[string]string
+
+	// Generated: Tabelle → zusätzliche generierte Spalten
+	Generated map[string][]GeneratedColumn
 }
 
 // NewGenerator erstellt einen neuen Schema-Generator
 func NewGenerator() *Generator {
+	overrides := make(map[string]string, len(DefaultReferenceOverrides))
+	for k, v := range DefaultReferenceOverrides {
+		overrides[k] = v
+	}
+
+	generated := make(map[string][]GeneratedColumn, len(DefaultGeneratedColumns))
+	for k, v := range DefaultGeneratedColumns {
+		generated[k] = v
+	}
+
 	return &Generator{
-		LocalizedAsJSON: true,
+		LocalizedAsJSON:    true,
+		EnumChecks:         true,
+		ReferenceOverrides: overrides,
+		Generated:          generated,
 	}
 }
 
+// Column beschreibt eine generierte Spalte
+type Column struct {
+	Name       string
+	Type       string
+	PrimaryKey bool
+	NotNull    bool
+	// Generated: Berechnete Spalte, wird weder importiert noch kopiert
+	Generated bool
+	// Definition ist die vollständige Spaltendefinition inkl. Constraints
+	Definition string
+}
+
 // GenerateTable erstellt CREATE TABLE Statement aus Go-Struct
 func (g *Generator) GenerateTable(tableName string, structType reflect.Type) (string, error) {
+	columns, err := g.Columns(tableName, structType)
+	if err != nil {
+		return "", err
+	}
+
+	defs := make([]string, len(columns))
+	for i, col := range columns {
+		defs[i] = "  " + col.Definition
+	}
+
+	// CREATE TABLE Statement
+	ddl := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n)%s;",
+		tableName,
+		strings.Join(defs, ",\n"),
+		g.TableOptions())
+
+	return ddl, nil
+}
+
+// TableOptions liefert die Tabellen-Optionen nach der Spaltenliste (z.B. " STRICT")
+func (g *Generator) TableOptions() string {
+	if g.Strict {
+		return " STRICT"
+	}
+	return ""
+}
+
+// Columns erstellt die Spaltendefinitionen eines Go-Structs
+func (g *Generator) Columns(tableName string, structType reflect.Type) ([]Column, error) {
 	if structType.Kind() != reflect.Struct {
-		return "", fmt.Errorf("expected struct, got %s", structType.Kind())
+		return nil, fmt.Errorf("expected struct, got %s", structType.Kind())
 	}
 
-	var columns []string
+	var columns []Column
 
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
@@ -43,30 +121,87 @@ func (g *Generator) GenerateTable(tableName string, structType reflect.Type) (st
 		isRequired := !containsOmitEmpty(parts)
 
 		// SQL Typ ermitteln
-		sqlType, err := g.goTypeToSQL(field.Type)
+		sqlType, err := g.columnType(field.Type)
 		if err != nil {
-			return "", fmt.Errorf("field %s: %w", field.Name, err)
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
 		}
 
+		col := Column{Name: columnName, Type: sqlType}
+
 		// Column Definition
-		colDef := fmt.Sprintf("  %s %s", columnName, sqlType)
+		colDef := fmt.Sprintf("%s %s", columnName, sqlType)
 
 		// Primary Key Detection
 		if columnName == "_key" {
 			colDef += " PRIMARY KEY"
+			col.PrimaryKey = true
 		} else if isRequired {
 			colDef += " NOT NULL"
+			col.NotNull = true
+		}
+
+		// Foreign Key
+		if refTable, ok := g.InferReference(tableName, columnName); ok {
+			colDef += fmt.Sprintf(" REFERENCES %s(_key)", refTable)
+		}
+
+		// Enum Constraint
+		if g.EnumChecks {
+			if values := enumValues(field.Type); len(values) > 0 {
+				colDef += fmt.Sprintf(" CHECK (%s IN (%s))", columnName, quoteValues(values))
+			}
 		}
 
-		columns = append(columns, colDef)
+		// Typ-Constraints (nur STRICT)
+		if g.Strict {
+			switch {
+			case isBool(field.Type):
+				colDef += fmt.Sprintf(" CHECK (%s IN (0, 1))", columnName)
+			case isJSON(field.Type):
+				colDef += fmt.Sprintf(" CHECK (json_valid(%s))", columnName)
+			}
+		}
+
+		col.Definition = colDef
+		columns = append(columns, col)
 	}
 
-	// CREATE TABLE Statement
-	ddl := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n%s\n);",
-		tableName,
-		strings.Join(columns, ",\n"))
+	for _, gen := range g.Generated[tableName] {
+		columns = append(columns, Column{Name: gen.Name, Type: gen.Type, Generated: true, Definition: gen.Definition()})
+	}
 
-	return ddl, nil
+	return columns, nil
+}
+
+// columnType liefert den Spaltentyp; STRICT-Tabellen speichern interface{} als ANY
+func (g *Generator) columnType(t reflect.Type) (string, error) {
+	if g.Strict && derefType(t).Kind() == reflect.Interface {
+		return "ANY", nil
+	}
+	return g.goTypeToSQL(t)
+}
+
+// derefType löst Pointer-Typen auf
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// isBool prüft ob ein Feld als 0/1 gespeichert wird
+func isBool(t reflect.Type) bool {
+	return derefType(t).Kind() == reflect.Bool
+}
+
+// isJSON prüft ob ein Feld als JSON-Text gespeichert wird (Slices, Maps, Structs)
+func isJSON(t reflect.Type) bool {
+	switch derefType(t).Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct:
+		return true
+	default:
+		return false
+	}
 }
 
 // goTypeToSQL konvertiert Go-Typ zu SQLite-Typ
@@ -109,6 +244,26 @@ func (g *Generator) goTypeToSQL(t reflect.Type) (string, error) {
 	}
 }
 
+// enumValues liefert die erlaubten Werte eines Enum-Typs (nil = kein Enum)
+func enumValues(t reflect.Type) []string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.String || !t.Implements(enumTypeInterface) {
+		return nil
+	}
+	return reflect.Zero(t).Interface().(EnumType).Values()
+}
+
+// quoteValues erzeugt eine SQL-Werteliste aus String-Literalen
+func quoteValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
 // containsOmitEmpty prüft ob "omitempty" in JSON-Tag-Parts
 func containsOmitEmpty(parts []string) bool {
 	for _, p := range parts {
@@ -121,13 +276,12 @@ func containsOmitEmpty(parts []string) bool {
 
 // GenerateIndex erstellt Index-Statement
 func (g *Generator) GenerateIndex(tableName, columnName string) string {
-	indexName := fmt.Sprintf("idx_%s_%s", tableName, columnName)
-	return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s(%s);",
-		indexName, tableName, columnName)
+	return Index{Columns: []string{columnName}}.SQL(tableName)
 }
 
 // GenerateSchema erstellt vollständiges Schema (Tabelle + Indices)
-func (g *Generator) GenerateSchema(tableName string, structType reflect.Type, indices []string) ([]string, error) {
+// Unbekannte Spalten in Indices ergeben einen Fehler
+func (g *Generator) GenerateSchema(tableName string, structType reflect.Type, indices []Index) ([]string, error) {
 	statements := make([]string, 0)
 
 	// Table
@@ -137,14 +291,36 @@ func (g *Generator) GenerateSchema(tableName string, structType reflect.Type, in
 	}
 	statements = append(statements, table)
 
-	// Indices (nur für existierende Felder)
+	// Explizite Indices
 	validFields := g.getFieldMap(structType)
-	for _, col := range indices {
-		if _, exists := validFields[col]; exists {
-			idx := g.GenerateIndex(tableName, col)
-			statements = append(statements, idx)
+	for _, gen := range g.Generated[tableName] {
+		validFields[gen.Name] = true
+	}
+	names := make(map[string]bool)
+	for _, idx := range indices {
+		if err := idx.Validate(tableName, validFields); err != nil {
+			return nil, err
+		}
+		name := idx.IndexName(tableName)
+		if names[name] {
+			return nil, fmt.Errorf("duplicate index %s", name)
+		}
+		names[name] = true
+		statements = append(statements, idx.SQL(tableName))
+	}
+
+	// Indices für Fremdschlüssel (sofern nicht bereits als erste Spalte abgedeckt)
+	for _, ref := range g.References(tableName, structType) {
+		covered := false
+		for _, idx := range indices {
+			if idx.covers(ref.Column) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			statements = append(statements, g.GenerateIndex(tableName, ref.Column))
 		}
-		// Ignoriere nicht-existente Felder stillschweigend
 	}
 
 	return statements, nil
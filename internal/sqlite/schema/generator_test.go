@@ -1,11 +1,14 @@
 package schema
 
 import (
+	"database/sql"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/Sternrassler/eve-sde/internal/schema/types"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 func TestGenerateTable(t *testing.T) {
@@ -93,7 +96,7 @@ func TestGenerateSchema(t *testing.T) {
 	gen := NewGenerator()
 
 	// Test mit einzelner Tabelle + Index
-	ddl, err := gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), []string{"groupID"})
+	ddl, err := gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), Indices("groupID"))
 	if err != nil {
 		t.Fatalf("GenerateSchema failed: %v", err)
 	}
@@ -122,7 +125,7 @@ func TestGenerateSchema(t *testing.T) {
 func TestGenerateSchema_NoIndices(t *testing.T) {
 	gen := NewGenerator()
 
-	ddl, err := gen.GenerateSchema("simple", reflect.TypeOf(types.Groups{}), []string{})
+	ddl, err := gen.GenerateSchema("simple", reflect.TypeOf(types.Groups{}), nil)
 	if err != nil {
 		t.Fatalf("GenerateSchema failed: %v", err)
 	}
@@ -244,3 +247,255 @@ func TestContainsOmitEmpty(t *testing.T) {
 		}
 	}
 }
+
+type testEnum string
+
+func (testEnum) Values() []string { return []string{"A", "B", "O'Neil"} }
+
+func TestGenerateTable_EnumCheck(t *testing.T) {
+	gen := NewGenerator()
+
+	type WithEnum struct {
+		Key   int64    `json:"_key"`
+		Class testEnum `json:"class,omitempty"`
+	}
+
+	ddl, err := gen.GenerateTable("with_enum", reflect.TypeOf(WithEnum{}))
+	if err != nil {
+		t.Fatalf("GenerateTable failed: %v", err)
+	}
+
+	if !contains(ddl, "class TEXT CHECK (class IN ('A', 'B', 'O''Neil'))") {
+		t.Errorf("Missing enum CHECK constraint:\n%s", ddl)
+	}
+
+	// Ohne EnumChecks kein Constraint
+	gen.EnumChecks = false
+	ddl, err = gen.GenerateTable("with_enum", reflect.TypeOf(WithEnum{}))
+	if err != nil {
+		t.Fatalf("GenerateTable failed: %v", err)
+	}
+	if contains(ddl, "CHECK") {
+		t.Errorf("Unexpected CHECK constraint with EnumChecks=false:\n%s", ddl)
+	}
+}
+
+func TestGenerateTable_GeneratedEnum(t *testing.T) {
+	gen := NewGenerator()
+
+	ddl, err := gen.GenerateTable("mapSolarSystems", reflect.TypeOf(types.MapSolarSystems{}))
+	if err != nil {
+		t.Fatalf("GenerateTable failed: %v", err)
+	}
+
+	if !contains(ddl, "securityClass TEXT CHECK (securityClass IN (") {
+		t.Errorf("Missing CHECK constraint for securityClass:\n%s", ddl)
+	}
+}
+
+func TestInferReference(t *testing.T) {
+	gen := NewGenerator()
+	gen.Tables = []string{"types", "groups", "categories", "mapSolarSystems", "marketGroups", "factions", "npcCorporations", "dogmaAttributeCategories", "dogmaAttributes"}
+
+	tests := []struct {
+		table, column string
+		want          string
+		ok            bool
+	}{
+		{"types", "groupID", "groups", true},
+		{"groups", "categoryID", "categories", true},
+		{"npcStations", "solarSystemID", "mapSolarSystems", true},
+		{"types", "marketGroupID", "marketGroups", true},
+		{"npcCorporations", "factionID", "factions", true},
+		{"bloodlines", "corporationID", "npcCorporations", true},
+		{"blueprints", "blueprintTypeID", "types", true},
+		{"marketGroups", "parentGroupID", "marketGroups", true},
+		{"dogmaAttributes", "attributeCategoryID", "dogmaAttributeCategories", true},
+		{"mapPlanets", "orbitID", "", false}, // Override: mehrdeutig
+		{"types", "iconID", "", false},       // icons unbekannt
+		{"types", "_key", "", false},         // Primary Key
+		{"types", "volume", "", false},       // keine ID-Spalte
+		{"npcStations", "ownerID", "npcCorporations", true},
+	}
+
+	for _, tt := range tests {
+		got, ok := gen.InferReference(tt.table, tt.column)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("InferReference(%s, %s) = (%q, %v), want (%q, %v)", tt.table, tt.column, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestInferReference_NoTables(t *testing.T) {
+	gen := NewGenerator()
+
+	if ref, ok := gen.InferReference("types", "groupID"); ok {
+		t.Errorf("InferReference without Tables = %q, want none", ref)
+	}
+}
+
+func TestGenerateTable_References(t *testing.T) {
+	gen := NewGenerator()
+	gen.Tables = []string{"types", "groups", "marketGroups"}
+
+	ddl, err := gen.GenerateTable("types", reflect.TypeOf(types.Types{}))
+	if err != nil {
+		t.Fatalf("GenerateTable failed: %v", err)
+	}
+
+	if !contains(ddl, "groupID INTEGER REFERENCES groups(_key)") {
+		t.Errorf("Missing groupID reference:\n%s", ddl)
+	}
+	if !contains(ddl, "marketGroupID INTEGER REFERENCES marketGroups(_key)") {
+		t.Errorf("Missing marketGroupID reference:\n%s", ddl)
+	}
+}
+
+func TestGenerateSchema_ReferenceIndices(t *testing.T) {
+	gen := NewGenerator()
+	gen.Tables = []string{"types", "groups", "marketGroups"}
+
+	ddl, err := gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), Indices("groupID"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+
+	// Table + groupID (explizit) + marketGroupID (Fremdschlüssel), kein Duplikat für groupID
+	if len(ddl) != 3 {
+		t.Fatalf("DDL length = %d, want 3:\n%s", len(ddl), strings.Join(ddl, "\n"))
+	}
+	if ddl[2] != "CREATE INDEX IF NOT EXISTS idx_types_marketGroupID ON types(marketGroupID);" {
+		t.Errorf("ddl[2] = %s, want marketGroupID index", ddl[2])
+	}
+}
+
+func TestWriteRelationshipGraph(t *testing.T) {
+	refs := []Reference{
+		{Table: "types", Column: "groupID", RefTable: "groups", RefColumn: "_key"},
+		{Table: "groups", Column: "categoryID", RefTable: "categories", RefColumn: "_key"},
+	}
+
+	var b strings.Builder
+	if err := WriteRelationshipGraph(&b, refs); err != nil {
+		t.Fatalf("WriteRelationshipGraph failed: %v", err)
+	}
+
+	doc := b.String()
+	if !contains(doc, "types }o--o| groups : groupID") {
+		t.Errorf("Missing mermaid edge:\n%s", doc)
+	}
+	if !contains(doc, "| groups | categoryID | categories._key |") {
+		t.Errorf("Missing table row:\n%s", doc)
+	}
+	// Sortiert nach Tabelle
+	if strings.Index(doc, "| groups |") > strings.Index(doc, "| types |") {
+		t.Error("Rows not sorted by table")
+	}
+}
+
+func TestGenerateTable_Strict(t *testing.T) {
+	gen := NewGenerator()
+	gen.Strict = true
+
+	type StrictType struct {
+		Key       int64                  `json:"_key"`
+		Published bool                   `json:"published,omitempty"`
+		Volume    float64                `json:"volume,omitempty"`
+		Name      types.LocalizedText    `json:"name,omitempty"`
+		Data      map[string]interface{} `json:"data,omitempty"`
+		Any       interface{}            `json:"any,omitempty"`
+	}
+
+	ddl, err := gen.GenerateTable("strict", reflect.TypeOf(StrictType{}))
+	if err != nil {
+		t.Fatalf("GenerateTable failed: %v", err)
+	}
+
+	expected := []string{
+		"_key INTEGER PRIMARY KEY",
+		"published INTEGER CHECK (published IN (0, 1))",
+		"volume REAL",
+		"name TEXT CHECK (json_valid(name))",
+		"data TEXT CHECK (json_valid(data))",
+		"any ANY",
+		") STRICT;",
+	}
+	for _, e := range expected {
+		if !strings.Contains(ddl, e) {
+			t.Errorf("DDL missing %q:\n%s", e, ddl)
+		}
+	}
+}
+
+func TestGenerateTable_StrictRejectsWrongTypes(t *testing.T) {
+	gen := NewGenerator()
+	gen.Strict = true
+
+	ddl, err := gen.GenerateTable("types", reflect.TypeOf(types.Types{}))
+	if err != nil {
+		t.Fatalf("GenerateTable failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "strict.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(ddl); err != nil {
+		t.Fatalf("Failed to create STRICT table: %v\n%s", err, ddl)
+	}
+
+	// Ganzzahliger Float ist verlustfrei → erlaubt
+	if _, err := db.Exec("INSERT INTO types (_key, basePrice) VALUES (1, 100.0)"); err != nil {
+		t.Errorf("Lossless REAL in INTEGER column rejected: %v", err)
+	}
+
+	invalid := map[string]string{
+		"float in INTEGER":  "INSERT INTO types (_key, basePrice) VALUES (2, 2.5)",
+		"bool out of range": "INSERT INTO types (_key, published) VALUES (3, 2)",
+		"invalid JSON":      "INSERT INTO types (_key, name) VALUES (4, '{broken')",
+	}
+	for name, stmt := range invalid {
+		if _, err := db.Exec(stmt); err == nil {
+			t.Errorf("%s: expected error for %s", name, stmt)
+		}
+	}
+}
+
+func TestGenerateSchema_GeneratedColumns(t *testing.T) {
+	gen := NewGenerator()
+	gen.Strict = true
+
+	stmts, err := gen.GenerateSchema("mapSolarSystems", reflect.TypeOf(types.MapSolarSystems{}),
+		[]Index{{Columns: []string{"securityZone"}}})
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "generated.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	// Nur die Tabelle und der explizite Index (Fremdschlüssel-Indices brauchen keine Zieltabellen)
+	for _, stmt := range stmts[:2] {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Exec failed: %v\n%s", err, stmt)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO mapSolarSystems (_key, constellationID, name, position, regionID, securityStatus)
+		VALUES (30000142, 20000020, '{"en":"Jita"}', '{"x":0,"y":0,"z":0}', 10000002, 0.9459)`); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+
+	var display float64
+	var zone string
+	if err := db.QueryRow(`SELECT displaySecurity, securityZone FROM mapSolarSystems`).Scan(&display, &zone); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if display != 0.9 || zone != "High-Sec" {
+		t.Errorf("displaySecurity, securityZone = %v, %q; want 0.9, High-Sec", display, zone)
+	}
+}
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Index beschreibt einen Index auf einer Tabelle
+type Index struct {
+	// Name: Optionaler Indexname (leer = idx_<table>_<columns>)
+	Name string
+
+	// Columns: Spalten oder Ausdrücke in Index-Reihenfolge, z.B.
+	// "solarSystemID", "typeID DESC", "json_extract(name, '$.en')"
+	Columns []string
+
+	// Unique: CREATE UNIQUE INDEX
+	Unique bool
+
+	// Where: Bedingung für einen partiellen Index, z.B. "published = 1"
+	Where string
+}
+
+// Indices erstellt je einen einspaltigen Index pro Spalte
+func Indices(columns ...string) []Index {
+	indices := make([]Index, len(columns))
+	for i, col := range columns {
+		indices[i] = Index{Columns: []string{col}}
+	}
+	return indices
+}
+
+var (
+	// indexColumnPattern: Spaltenname mit optionaler Sortierung
+	indexColumnPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(\s+(?i:ASC|DESC))?$`)
+	nonNameChars       = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+)
+
+// isExpression prüft ob ein Index-Eintrag ein Ausdruck statt eines Spaltennamens ist
+func isExpression(entry string) bool {
+	return !indexColumnPattern.MatchString(strings.TrimSpace(entry))
+}
+
+// IndexName liefert den Namen des Index (explizit oder aus Tabelle und Spalten abgeleitet)
+func (idx Index) IndexName(tableName string) string {
+	if idx.Name != "" {
+		return idx.Name
+	}
+
+	parts := make([]string, len(idx.Columns))
+	for i, entry := range idx.Columns {
+		if m := indexColumnPattern.FindStringSubmatch(strings.TrimSpace(entry)); m != nil {
+			parts[i] = m[1]
+			continue
+		}
+		parts[i] = strings.Trim(nonNameChars.ReplaceAllString(entry, "_"), "_")
+	}
+
+	name := fmt.Sprintf("idx_%s_%s", tableName, strings.Join(parts, "_"))
+	if idx.Where != "" {
+		name += "_partial"
+	}
+	return name
+}
+
+// Validate prüft den Index gegen die Spalten der Tabelle.
+// Ausdrücke und WHERE-Bedingungen prüft SQLite beim Anlegen des Index.
+func (idx Index) Validate(tableName string, columns map[string]bool) error {
+	if len(idx.Columns) == 0 {
+		return fmt.Errorf("index on %s has no columns", tableName)
+	}
+
+	for _, entry := range idx.Columns {
+		if strings.TrimSpace(entry) == "" {
+			return fmt.Errorf("index %s has an empty column", idx.IndexName(tableName))
+		}
+		if isExpression(entry) {
+			continue
+		}
+		name := indexColumnPattern.FindStringSubmatch(strings.TrimSpace(entry))[1]
+		if !columns[name] {
+			return fmt.Errorf("index %s: unknown column %s.%s", idx.IndexName(tableName), tableName, name)
+		}
+	}
+
+	return nil
+}
+
+// SQL erstellt das CREATE INDEX Statement
+func (idx Index) SQL(tableName string) string {
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+
+	columns := make([]string, len(idx.Columns))
+	for i, entry := range idx.Columns {
+		columns[i] = strings.TrimSpace(entry)
+	}
+
+	stmt := fmt.Sprintf("CREATE %sINDEX IF NOT EXISTS %s ON %s(%s)",
+		unique, idx.IndexName(tableName), tableName, strings.Join(columns, ", "))
+	if idx.Where != "" {
+		stmt += " WHERE " + idx.Where
+	}
+	return stmt + ";"
+}
+
+// covers prüft ob der Index eine Spalte als erste Spalte abdeckt
+func (idx Index) covers(column string) bool {
+	if len(idx.Columns) == 0 || idx.Where != "" {
+		return false
+	}
+	m := indexColumnPattern.FindStringSubmatch(strings.TrimSpace(idx.Columns[0]))
+	return m != nil && m[1] == column
+}
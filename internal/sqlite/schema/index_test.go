@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/schema/types"
+)
+
+func TestIndexSQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		index Index
+		want  string
+	}{
+		{
+			name:  "single",
+			index: Index{Columns: []string{"groupID"}},
+			want:  "CREATE INDEX IF NOT EXISTS idx_types_groupID ON types(groupID);",
+		},
+		{
+			name:  "composite",
+			index: Index{Columns: []string{"groupID", "marketGroupID DESC"}},
+			want:  "CREATE INDEX IF NOT EXISTS idx_types_groupID_marketGroupID ON types(groupID, marketGroupID DESC);",
+		},
+		{
+			name:  "unique",
+			index: Index{Name: "ux_types_name", Columns: []string{"name"}, Unique: true},
+			want:  "CREATE UNIQUE INDEX IF NOT EXISTS ux_types_name ON types(name);",
+		},
+		{
+			name:  "partial",
+			index: Index{Columns: []string{"groupID"}, Where: "published = 1"},
+			want:  "CREATE INDEX IF NOT EXISTS idx_types_groupID_partial ON types(groupID) WHERE published = 1;",
+		},
+		{
+			name:  "expression",
+			index: Index{Columns: []string{"json_extract(name, '$.en')"}},
+			want:  "CREATE INDEX IF NOT EXISTS idx_types_json_extract_name_en ON types(json_extract(name, '$.en'));",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.index.SQL("types"); got != tt.want {
+				t.Errorf("SQL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSchema_UnknownIndexColumn(t *testing.T) {
+	gen := NewGenerator()
+
+	_, err := gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), []Index{
+		{Columns: []string{"groupID", "typeID"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown column types.typeID") {
+		t.Errorf("err = %v, want unknown column error", err)
+	}
+
+	_, err = gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), []Index{{}})
+	if err == nil {
+		t.Error("expected error for index without columns")
+	}
+
+	_, err = gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), Indices("groupID", "groupID"))
+	if err == nil || !strings.Contains(err.Error(), "duplicate index") {
+		t.Errorf("err = %v, want duplicate index error", err)
+	}
+}
+
+func TestGenerateSchema_IndexSpecsExecute(t *testing.T) {
+	gen := NewGenerator()
+	gen.Tables = []string{"types", "groups"}
+
+	stmts, err := gen.GenerateSchema("types", reflect.TypeOf(types.Types{}), []Index{
+		{Columns: []string{"groupID", "marketGroupID"}},
+		{Columns: []string{"groupID"}, Where: "published = 1"},
+		{Name: "idx_types_name_en", Columns: []string{"json_extract(name, '$.en')"}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+
+	// Composite-Index mit groupID an erster Stelle deckt den Fremdschlüssel ab
+	for _, stmt := range stmts {
+		if strings.Contains(stmt, "idx_types_groupID ON") {
+			t.Errorf("unexpected separate foreign key index: %s", stmt)
+		}
+	}
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	defer db.Close()
+
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Exec failed: %v\n%s", err, stmt)
+		}
+	}
+
+	var plan string
+	rows, err := db.Query(`EXPLAIN QUERY PLAN SELECT _key FROM types WHERE json_extract(name, '$.en') = 'Tritanium'`)
+	if err != nil {
+		t.Fatalf("Query plan failed: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		plan += detail
+	}
+	if !strings.Contains(plan, "idx_types_name_en") {
+		t.Errorf("query plan = %q, want expression index", plan)
+	}
+}
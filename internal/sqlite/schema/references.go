@@ -0,0 +1,171 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DefaultReferenceOverrides ergänzt die Namenskonvention um Spalten, deren
+// Zieltabelle sich nicht aus dem Namen ableiten lässt.
+// Schlüssel: "table.column" (spezifisch) oder "column" (global), leerer Wert = keine Referenz
+var DefaultReferenceOverrides = map[string]string{
+	// Typ-Referenzen mit Präfix
+	"blueprintTypeID":    "types",
+	"licenseTypeID":      "types",
+	"materialTypeID":     "types",
+	"shipTypeID":         "types",
+	"controlTowerTypeID": "types",
+
+	// NPC Corporations
+	"ownerID":              "npcCorporations",
+	"enemyID":              "npcCorporations",
+	"friendID":             "npcCorporations",
+	"militiaCorporationID": "npcCorporations",
+
+	// Corporation Activities / Station Operations
+	"mainActivityID":               "corporationActivities",
+	"secondaryActivityID":          "corporationActivities",
+	"stationOperations.activityID": "corporationActivities",
+	"operationID":                  "stationOperations",
+
+	// Dogma
+	"unitID":                   "dogmaUnits",
+	"attributeCategoryID":      "dogmaAttributeCategories",
+	"maxAttributeID":           "dogmaAttributes",
+	"minAttributeID":           "dogmaAttributes",
+	"chargeRechargeTimeID":     "dogmaAttributes",
+	"dischargeAttributeID":     "dogmaAttributes",
+	"durationAttributeID":      "dogmaAttributes",
+	"falloffAttributeID":       "dogmaAttributes",
+	"rangeAttributeID":         "dogmaAttributes",
+	"trackingSpeedAttributeID": "dogmaAttributes",
+
+	// Hierarchien
+	"marketGroups.parentGroupID": "marketGroups",
+
+	// Keine Tabelle / mehrdeutig (Planet, Mond oder Stern)
+	"orbitID":         "",
+	"wormholeClassID": "",
+	"soundID":         "",
+	"nebulaID":        "",
+}
+
+// Reference beschreibt eine Fremdschlüssel-Beziehung table.column → refTable._key
+type Reference struct {
+	Table     string
+	Column    string
+	RefTable  string
+	RefColumn string
+}
+
+// InferReference ermittelt die Zieltabelle einer Spalte.
+// Reihenfolge: Override "table.column", Override "column", Namenskonvention
+// (groupID → groups, solarSystemID → mapSolarSystems, corporationID → npcCorporations).
+func (g *Generator) InferReference(tableName, columnName string) (string, bool) {
+	if len(g.Tables) == 0 || columnName == "_key" {
+		return "", false
+	}
+
+	for _, key := range []string{tableName + "." + columnName, columnName} {
+		if refTable, ok := g.ReferenceOverrides[key]; ok {
+			return refTable, refTable != "" && g.hasTable(refTable)
+		}
+	}
+
+	base, ok := strings.CutSuffix(columnName, "ID")
+	if !ok || base == "" {
+		return "", false
+	}
+
+	plural := pluralize(base)
+	upper := strings.ToUpper(plural[:1]) + plural[1:]
+	for _, candidate := range []string{plural, "map" + upper, "npc" + upper} {
+		if g.hasTable(candidate) {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// References liefert alle Fremdschlüssel eines Structs in Feld-Reihenfolge
+func (g *Generator) References(tableName string, structType reflect.Type) []Reference {
+	var refs []Reference
+	for i := 0; i < structType.NumField(); i++ {
+		jsonTag := structType.Field(i).Tag.Get("json")
+		if jsonTag == "" || jsonTag == "-" {
+			continue
+		}
+		columnName := strings.Split(jsonTag, ",")[0]
+
+		if refTable, ok := g.InferReference(tableName, columnName); ok {
+			refs = append(refs, Reference{
+				Table:     tableName,
+				Column:    columnName,
+				RefTable:  refTable,
+				RefColumn: "_key",
+			})
+		}
+	}
+	return refs
+}
+
+// hasTable prüft ob eine Tabelle in Tables bekannt ist
+func (g *Generator) hasTable(name string) bool {
+	for _, t := range g.Tables {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
+
+// pluralize bildet den englischen Plural der Tabellennamen (category → categories)
+func pluralize(s string) string {
+	switch {
+	case strings.HasSuffix(s, "y"):
+		return strings.TrimSuffix(s, "y") + "ies"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"):
+		return s + "es"
+	default:
+		return s + "s"
+	}
+}
+
+// WriteRelationshipGraph schreibt ein Markdown-Dokument (Mermaid ER-Diagramm + Tabelle)
+// aller Fremdschlüssel-Beziehungen
+func WriteRelationshipGraph(w io.Writer, refs []Reference) error {
+	sorted := make([]Reference, len(refs))
+	copy(sorted, refs)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Table != sorted[j].Table {
+			return sorted[i].Table < sorted[j].Table
+		}
+		return sorted[i].Column < sorted[j].Column
+	})
+
+	var b strings.Builder
+	b.WriteString("# Tabellen-Beziehungen\n\n")
+	b.WriteString("<!-- Generiert mit: go run ./cmd/sde-to-sqlite --relations-doc docs/relationships.md -->\n\n")
+	b.WriteString("Fremdschlüssel werden aus Spaltennamen abgeleitet (`groupID` → `groups`) und über\n")
+	b.WriteString("`schema.DefaultReferenceOverrides` ergänzt. Jede Beziehung ist als `REFERENCES` im DDL hinterlegt\n")
+	b.WriteString("und kann per `PRAGMA foreign_key_list(<table>)` abgefragt werden.\n\n")
+
+	b.WriteString("```mermaid\nerDiagram\n")
+	for _, ref := range sorted {
+		fmt.Fprintf(&b, "    %s }o--o| %s : %s\n", ref.Table, ref.RefTable, ref.Column)
+	}
+	b.WriteString("```\n\n")
+
+	b.WriteString("| Tabelle | Spalte | Referenziert |\n")
+	b.WriteString("|---------|--------|--------------|\n")
+	for _, ref := range sorted {
+		fmt.Fprintf(&b, "| %s | %s | %s.%s |\n", ref.Table, ref.Column, ref.RefTable, ref.RefColumn)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,121 @@
+// Package security definiert die zentrale Security-Klassifikation von Sonnensystemen.
+// Dieselben Regeln liegen als SQL-Ausdrücke (generierte Spalten von mapSolarSystems)
+// und als Go-Funktionen vor; ein Test stellt sicher, dass beide übereinstimmen.
+package security
+
+import (
+	"math"
+	"strconv"
+)
+
+// Zone ist die Security-Zone eines Systems
+type Zone string
+
+const (
+	ZoneHighSec  Zone = "High-Sec"
+	ZoneLowSec   Zone = "Low-Sec"
+	ZoneNullSec  Zone = "Null-Sec"
+	ZoneWormhole Zone = "Wormhole"
+	ZonePochven  Zone = "Pochven"
+	ZoneJove     Zone = "Jove"
+	ZoneAbyssal  Zone = "Abyssal"
+)
+
+// Zones liefert alle Zonen
+func Zones() []Zone {
+	return []Zone{ZoneHighSec, ZoneLowSec, ZoneNullSec, ZoneWormhole, ZonePochven, ZoneJove, ZoneAbyssal}
+}
+
+// HighSecMin: Ab diesem (angezeigten) Security-Status gilt ein System als High-Sec
+const HighSecMin = 0.5
+
+// Sonderregionen und ID-Bereiche
+const (
+	PochvenRegionID int64 = 10000070
+
+	WormholeMinID int64 = 31000000
+	WormholeMaxID int64 = 31999999
+	AbyssalMinID  int64 = 32000000
+	AbyssalMaxID  int64 = 32999999
+)
+
+// JoveRegionIDs: Regionen ohne Zugang per Stargate (UUA-F4, J7HZ-F, A821-A)
+var JoveRegionIDs = []int64{10000004, 10000017, 10000019}
+
+// Spalten von mapSolarSystems
+const (
+	// DisplayColumn: Angezeigter Security-Status (generierte Spalte)
+	DisplayColumn = "displaySecurity"
+	// ZoneColumn: Security-Zone (generierte Spalte)
+	ZoneColumn = "securityZone"
+)
+
+// DisplaySQL berechnet den angezeigten Security-Status aus securityStatus:
+// Rundung auf eine Nachkommastelle, Werte zwischen 0.0 und 0.05 werden als 0.1 angezeigt
+const DisplaySQL = `CASE WHEN securityStatus > 0.0 AND securityStatus < 0.05 THEN 0.1 ELSE ROUND(securityStatus, 1) END`
+
+// ZoneSQL berechnet die Security-Zone aus _key, regionID und securityStatus
+const ZoneSQL = `CASE
+    WHEN _key BETWEEN 31000000 AND 31999999 THEN 'Wormhole'
+    WHEN _key BETWEEN 32000000 AND 32999999 THEN 'Abyssal'
+    WHEN regionID = 10000070 THEN 'Pochven'
+    WHEN regionID IN (10000004, 10000017, 10000019) THEN 'Jove'
+    WHEN (` + DisplaySQL + `) >= 0.5 THEN 'High-Sec'
+    WHEN (` + DisplaySQL + `) > 0.0 THEN 'Low-Sec'
+    ELSE 'Null-Sec'
+END`
+
+// Display liefert den angezeigten Security-Status (wie DisplaySQL)
+func Display(sec float64) float64 {
+	if sec > 0 && sec < 0.05 {
+		return 0.1
+	}
+
+	// Korrekte Dezimalrundung des Binärwerts (0.35 ist 0.3499… → 0.3)
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(sec, 'f', 1, 64), 64)
+
+	// Exakte Halbwerte (0.25) rundet FormatFloat zur geraden Ziffer, SQLite von der Null weg
+	scaled := sec * 10
+	if math.FMA(sec, 10, -scaled) == 0 && math.Abs(scaled-math.Trunc(scaled)) == 0.5 {
+		rounded = math.Trunc(scaled)/10 + math.Copysign(0.1, sec)
+		rounded, _ = strconv.ParseFloat(strconv.FormatFloat(rounded, 'f', 1, 64), 64)
+	}
+	return rounded
+}
+
+// HighSec prüft ob ein Security-Status als High-Sec angezeigt wird
+func HighSec(sec float64) bool {
+	return Display(sec) >= HighSecMin
+}
+
+// Classify liefert die Security-Zone eines Systems (wie ZoneSQL)
+func Classify(systemID, regionID int64, sec float64) Zone {
+	switch {
+	case systemID >= WormholeMinID && systemID <= WormholeMaxID:
+		return ZoneWormhole
+	case systemID >= AbyssalMinID && systemID <= AbyssalMaxID:
+		return ZoneAbyssal
+	case regionID == PochvenRegionID:
+		return ZonePochven
+	case isJove(regionID):
+		return ZoneJove
+	}
+
+	switch display := Display(sec); {
+	case display >= HighSecMin:
+		return ZoneHighSec
+	case display > 0:
+		return ZoneLowSec
+	default:
+		return ZoneNullSec
+	}
+}
+
+func isJove(regionID int64) bool {
+	for _, id := range JoveRegionIDs {
+		if id == regionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package security
+
+import (
+	"database/sql"
+	"math/rand"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// edgeCases: Grenzfälle der Klassifikation (Security-Werte wie in mapSolarSystems)
+var edgeCases = []struct {
+	name        string
+	systemID    int64
+	regionID    int64
+	sec         float64
+	wantDisplay float64
+	wantZone    Zone
+}{
+	{"Jita", 30000142, 10000002, 0.9459131166648389, 0.9, ZoneHighSec},
+	{"high-sec rounded up from 0.45", 30000001, 10000001, 0.45, 0.5, ZoneHighSec},
+	{"low-sec just below 0.45", 30000002, 10000001, 0.4499999, 0.4, ZoneLowSec},
+	{"decimal 0.35 stored below tie", 30000003, 10000001, 0.35, 0.3, ZoneLowSec},
+	{"exact binary tie 0.25", 30000004, 10000001, 0.25, 0.3, ZoneLowSec},
+	{"exact binary tie 0.75", 30000005, 10000001, 0.75, 0.8, ZoneHighSec},
+	{"0.0-0.05 shown as 0.1", 30000006, 10000001, 0.0001, 0.1, ZoneLowSec},
+	{"0.049 shown as 0.1", 30000007, 10000001, 0.049, 0.1, ZoneLowSec},
+	{"0.05 rounds to 0.1", 30000008, 10000001, 0.05, 0.1, ZoneLowSec},
+	{"exactly 0.0 is null-sec", 30000009, 10000001, 0.0, 0.0, ZoneNullSec},
+	{"slightly negative is null-sec", 30000010, 10000001, -0.04, 0.0, ZoneNullSec},
+	{"negative tie -0.25", 30000011, 10000001, -0.25, -0.3, ZoneNullSec},
+	{"deep null-sec", 30000012, 10000060, -0.9876, -1.0, ZoneNullSec},
+	{"Pochven (sec -1.0)", 30000013, PochvenRegionID, -1.0, -1.0, ZonePochven},
+	{"Jove region", 30000014, 10000017, -1.0, -1.0, ZoneJove},
+	{"wormhole C1", 31000001, 11000001, -0.99, -1.0, ZoneWormhole},
+	{"Thera", 31000005, 11000031, -0.99, -1.0, ZoneWormhole},
+	{"last wormhole ID", WormholeMaxID, 11000033, -1.0, -1.0, ZoneWormhole},
+	{"Abyssal", 32000001, 12000001, -1.0, -1.0, ZoneAbyssal},
+	{"Zarzakh is null-sec", 30100000, 10001000, -1.0, -1.0, ZoneNullSec},
+}
+
+func TestDisplayAndClassify(t *testing.T) {
+	for _, tt := range edgeCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Display(tt.sec); got != tt.wantDisplay {
+				t.Errorf("Display(%v) = %v, want %v", tt.sec, got, tt.wantDisplay)
+			}
+			if got := Classify(tt.systemID, tt.regionID, tt.sec); got != tt.wantZone {
+				t.Errorf("Classify = %q, want %q", got, tt.wantZone)
+			}
+		})
+	}
+}
+
+// openSystems legt eine mapSolarSystems-Tabelle mit den generierten Spalten an
+func openSystems(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE mapSolarSystems (
+		_key INTEGER PRIMARY KEY,
+		regionID INTEGER,
+		securityStatus REAL,
+		` + DisplayColumn + ` REAL GENERATED ALWAYS AS (` + DisplaySQL + `) VIRTUAL,
+		` + ZoneColumn + ` TEXT GENERATED ALWAYS AS (` + ZoneSQL + `) VIRTUAL
+	)`)
+	if err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	return db
+}
+
+func TestSQLMatchesGo(t *testing.T) {
+	db := openSystems(t)
+
+	for _, tt := range edgeCases {
+		if _, err := db.Exec(`INSERT INTO mapSolarSystems (_key, regionID, securityStatus) VALUES (?, ?, ?)`,
+			tt.systemID, tt.regionID, tt.sec); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	// Alle Werte mit 2 und 3 Nachkommastellen sowie zufällige Werte
+	rng := rand.New(rand.NewSource(1))
+	id := int64(30010000)
+	var values []float64
+	for i := -1000; i <= 1000; i++ {
+		values = append(values, float64(i)/1000)
+		if i%10 == 0 {
+			values = append(values, float64(i/10)/100)
+		}
+	}
+	for i := 0; i < 5000; i++ {
+		values = append(values, rng.Float64()*2-1)
+	}
+	for _, sec := range values {
+		if _, err := db.Exec(`INSERT INTO mapSolarSystems (_key, regionID, securityStatus) VALUES (?, 10000001, ?)`, id, sec); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		id++
+	}
+
+	rows, err := db.Query(`SELECT _key, regionID, securityStatus, ` + DisplayColumn + `, ` + ZoneColumn + ` FROM mapSolarSystems`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+
+	checked := 0
+	for rows.Next() {
+		var (
+			systemID, regionID int64
+			sec, display       float64
+			zone               string
+		)
+		if err := rows.Scan(&systemID, &regionID, &sec, &display, &zone); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		if want := Display(sec); display != want {
+			t.Errorf("%d: SQL display(%v) = %v, Go = %v", systemID, sec, display, want)
+		}
+		if want := Classify(systemID, regionID, sec); Zone(zone) != want {
+			t.Errorf("%d: SQL zone(%v) = %q, Go = %q", systemID, sec, zone, want)
+		}
+		checked++
+	}
+	if checked != len(edgeCases)+len(values) {
+		t.Errorf("checked %d rows, want %d", checked, len(edgeCases)+len(values))
+	}
+}
@@ -0,0 +1,119 @@
+// Package sqlitetest stellt Test-Datenbanken bereit, deren SDE-Tabellen wie beim Import per
+// schema.Generator aus den Structs in internal/schema/types erzeugt werden. Fixtures enthalten
+// damit nur Daten; Abweichungen zwischen Views und generiertem Schema fallen in den Tests auf.
+package sqlitetest
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/Sternrassler/eve-sde/internal/schema/types"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/schema"
+)
+
+// Tables: SDE-Tabelle → Struct (wie die Mappings von sde-to-sqlite)
+var Tables = map[string]reflect.Type{
+	"agentTypes":               reflect.TypeOf(types.AgentTypes{}),
+	"agentsInSpace":            reflect.TypeOf(types.AgentsInSpace{}),
+	"ancestries":               reflect.TypeOf(types.Ancestries{}),
+	"bloodlines":               reflect.TypeOf(types.Bloodlines{}),
+	"blueprints":               reflect.TypeOf(types.Blueprints{}),
+	"categories":               reflect.TypeOf(types.Categories{}),
+	"certificates":             reflect.TypeOf(types.Certificates{}),
+	"characterAttributes":      reflect.TypeOf(types.CharacterAttributes{}),
+	"contrabandTypes":          reflect.TypeOf(types.ContrabandTypes{}),
+	"controlTowerResources":    reflect.TypeOf(types.ControlTowerResources{}),
+	"corporationActivities":    reflect.TypeOf(types.CorporationActivities{}),
+	"dbuffCollections":         reflect.TypeOf(types.DbuffCollections{}),
+	"dogmaAttributeCategories": reflect.TypeOf(types.DogmaAttributeCategories{}),
+	"dogmaAttributes":          reflect.TypeOf(types.DogmaAttributes{}),
+	"dogmaEffects":             reflect.TypeOf(types.DogmaEffects{}),
+	"dogmaUnits":               reflect.TypeOf(types.DogmaUnits{}),
+	"dynamicItemAttributes":    reflect.TypeOf(types.DynamicItemAttributes{}),
+	"factions":                 reflect.TypeOf(types.Factions{}),
+	"graphics":                 reflect.TypeOf(types.Graphics{}),
+	"groups":                   reflect.TypeOf(types.Groups{}),
+	"icons":                    reflect.TypeOf(types.Icons{}),
+	"landmarks":                reflect.TypeOf(types.Landmarks{}),
+	"mapAsteroidBelts":         reflect.TypeOf(types.MapAsteroidBelts{}),
+	"mapConstellations":        reflect.TypeOf(types.MapConstellations{}),
+	"mapMoons":                 reflect.TypeOf(types.MapMoons{}),
+	"mapPlanets":               reflect.TypeOf(types.MapPlanets{}),
+	"mapRegions":               reflect.TypeOf(types.MapRegions{}),
+	"mapSolarSystems":          reflect.TypeOf(types.MapSolarSystems{}),
+	"mapStargates":             reflect.TypeOf(types.MapStargates{}),
+	"mapStars":                 reflect.TypeOf(types.MapStars{}),
+	"marketGroups":             reflect.TypeOf(types.MarketGroups{}),
+	"masteries":                reflect.TypeOf(types.Masteries{}),
+	"metaGroups":               reflect.TypeOf(types.MetaGroups{}),
+	"npcCharacters":            reflect.TypeOf(types.NpcCharacters{}),
+	"npcCorporationDivisions":  reflect.TypeOf(types.NpcCorporationDivisions{}),
+	"npcCorporations":          reflect.TypeOf(types.NpcCorporations{}),
+	"npcStations":              reflect.TypeOf(types.NpcStations{}),
+	"planetResources":          reflect.TypeOf(types.PlanetResources{}),
+	"planetSchematics":         reflect.TypeOf(types.PlanetSchematics{}),
+	"races":                    reflect.TypeOf(types.Races{}),
+	"skinLicenses":             reflect.TypeOf(types.SkinLicenses{}),
+	"skinMaterials":            reflect.TypeOf(types.SkinMaterials{}),
+	"skins":                    reflect.TypeOf(types.Skins{}),
+	"sovereigntyUpgrades":      reflect.TypeOf(types.SovereigntyUpgrades{}),
+	"stationOperations":        reflect.TypeOf(types.StationOperations{}),
+	"stationServices":          reflect.TypeOf(types.StationServices{}),
+	"translationLanguages":     reflect.TypeOf(types.TranslationLanguages{}),
+	"typeBonus":                reflect.TypeOf(types.TypeBonus{}),
+	"typeDogma":                reflect.TypeOf(types.TypeDogma{}),
+	"typeMaterials":            reflect.TypeOf(types.TypeMaterials{}),
+	"types":                    reflect.TypeOf(types.Types{}),
+}
+
+// Open öffnet eine leere Datenbank im Temp-Verzeichnis des Tests und legt die angegebenen
+// SDE-Tabellen an
+func Open(t testing.TB, tables ...string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	Create(t, db, tables...)
+	return db
+}
+
+// Create legt SDE-Tabellen samt generierter Spalten und Fremdschlüssel-Indices an
+func Create(t testing.TB, db *sql.DB, tables ...string) {
+	t.Helper()
+
+	gen := NewGenerator()
+	for _, name := range tables {
+		structType, ok := Tables[name]
+		if !ok {
+			t.Fatalf("unknown SDE table %s", name)
+		}
+		stmts, err := gen.GenerateSchema(name, structType, nil)
+		if err != nil {
+			t.Fatalf("Failed to generate schema for %s: %v", name, err)
+		}
+		for _, stmt := range stmts {
+			if _, err := db.Exec(stmt); err != nil {
+				t.Fatalf("Failed to create %s: %v", name, err)
+			}
+		}
+	}
+}
+
+// NewGenerator liefert den Schema-Generator mit allen SDE-Tabellen für die Fremdschlüssel-Inferenz
+func NewGenerator() *schema.Generator {
+	gen := schema.NewGenerator()
+	for name := range Tables {
+		gen.Tables = append(gen.Tables, name)
+	}
+	sort.Strings(gen.Tables)
+	return gen
+}
@@ -0,0 +1,32 @@
+package sqlitetest
+
+import "testing"
+
+func TestOpen_AllTables(t *testing.T) {
+	var names []string
+	for name := range Tables {
+		names = append(names, name)
+	}
+	db := Open(t, names...)
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'").Scan(&count); err != nil {
+		t.Fatalf("Query sqlite_master failed: %v", err)
+	}
+	if count != len(Tables) {
+		t.Errorf("tables = %d, want %d", count, len(Tables))
+	}
+
+	// Generierte Spalten wie beim Import
+	if _, err := db.Exec("INSERT INTO mapSolarSystems (_key, securityStatus, regionID) VALUES (30000001, 0.46, 10000001)"); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	var display float64
+	var zone string
+	if err := db.QueryRow("SELECT displaySecurity, securityZone FROM mapSolarSystems").Scan(&display, &zone); err != nil {
+		t.Fatalf("Query generated columns failed: %v", err)
+	}
+	if display != 0.5 || zone != "High-Sec" {
+		t.Errorf("display = %v, zone = %s; want 0.5, High-Sec", display, zone)
+	}
+}
@@ -0,0 +1,305 @@
+// Package validate prüft die referenzielle Integrität einer gebauten SDE-Datenbank
+package validate
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity gibt an, wie schwer eine Regelverletzung wiegt
+type Severity int
+
+const (
+	// SeverityInfo ist rein informativ
+	SeverityInfo Severity = iota
+	// SeverityWarning markiert verdächtige, aber tolerierbare Daten
+	SeverityWarning
+	// SeverityError markiert Integritätsfehler (Release-Gate)
+	SeverityError
+)
+
+// String implementiert Stringer für Severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// ParseSeverity wandelt "info", "warning" oder "error" in eine Severity
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "info":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	default:
+		return 0, fmt.Errorf("unknown severity: %s", s)
+	}
+}
+
+// Rule ist eine Integritätsregel
+type Rule struct {
+	Name        string
+	Severity    Severity
+	Description string
+	// Tables: benötigte Tabellen; fehlt eine, wird die Regel übersprungen
+	Tables []string
+	// Query liefert eine Zeile pro Verletzung, erste Spalte = Schlüssel
+	Query string
+}
+
+// Result ist das Ergebnis einer Regel
+type Result struct {
+	Rule       Rule
+	Violations int
+	Samples    []string
+	Skipped    bool
+}
+
+// Failed prüft ob die Regel verletzt wurde und mindestens die Schwelle erreicht
+func (r Result) Failed(threshold Severity) bool {
+	return !r.Skipped && r.Violations > 0 && r.Rule.Severity >= threshold
+}
+
+// warningColumns sind Referenzen ohne Auswirkung auf Spiellogik (Darstellung)
+var warningColumns = map[string]bool{
+	"iconID":    true,
+	"graphicID": true,
+}
+
+// ForeignKeyRules erzeugt eine Regel pro REFERENCES-Constraint der Datenbank
+// (PRAGMA foreign_key_list)
+func ForeignKeyRules(db *sql.DB) ([]Rule, error) {
+	tables, err := listTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	for _, table := range sortedKeys(tables) {
+		rows, err := db.Query(fmt.Sprintf("PRAGMA foreign_key_list(%s)", table))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read foreign keys of %s: %w", table, err)
+		}
+
+		for rows.Next() {
+			var (
+				id, seq                         int
+				refTable, from, to              string
+				onUpdate, onDelete, matchClause string
+			)
+			if err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &matchClause); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan foreign key of %s: %w", table, err)
+			}
+
+			severity := SeverityError
+			if warningColumns[from] {
+				severity = SeverityWarning
+			}
+
+			rules = append(rules, Rule{
+				Name:        fmt.Sprintf("fk_%s_%s", table, from),
+				Severity:    severity,
+				Description: fmt.Sprintf("%s.%s must exist in %s.%s", table, from, refTable, to),
+				Tables:      []string{table, refTable},
+				Query: fmt.Sprintf(
+					"SELECT s._key, s.%[2]s FROM %[1]s s WHERE s.%[2]s IS NOT NULL AND NOT EXISTS (SELECT 1 FROM %[3]s r WHERE r.%[4]s = s.%[2]s)",
+					table, from, refTable, to),
+			})
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rows.Close()
+	}
+
+	return rules, nil
+}
+
+// DefaultRules liefert die fachlichen Regeln, die sich nicht aus REFERENCES ableiten lassen
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name:        "stargate_destination_exists",
+			Severity:    SeverityError,
+			Description: "mapStargates.destination.stargateID must point to an existing stargate",
+			Tables:      []string{"mapStargates"},
+			Query: `SELECT s._key, json_extract(s.destination, '$.stargateID')
+				FROM mapStargates s
+				WHERE NOT EXISTS (
+					SELECT 1 FROM mapStargates d WHERE d._key = json_extract(s.destination, '$.stargateID')
+				)`,
+		},
+		{
+			Name:        "stargate_bidirectional",
+			Severity:    SeverityError,
+			Description: "the destination gate of every stargate must lead back to it",
+			Tables:      []string{"mapStargates"},
+			Query: `SELECT s._key, d._key
+				FROM mapStargates s
+				JOIN mapStargates d ON d._key = json_extract(s.destination, '$.stargateID')
+				WHERE json_extract(d.destination, '$.stargateID') IS NOT s._key`,
+		},
+		{
+			Name:        "stargate_destination_system",
+			Severity:    SeverityError,
+			Description: "destination.solarSystemID must match the solar system of the destination gate",
+			Tables:      []string{"mapStargates"},
+			Query: `SELECT s._key, json_extract(s.destination, '$.solarSystemID')
+				FROM mapStargates s
+				JOIN mapStargates d ON d._key = json_extract(s.destination, '$.stargateID')
+				WHERE json_extract(s.destination, '$.solarSystemID') IS NOT d.solarSystemID`,
+		},
+		{
+			Name:        "published_type_market_group",
+			Severity:    SeverityWarning,
+			Description: "published types of marketable categories should have a market group",
+			Tables:      []string{"types", "groups"},
+			Query: `SELECT t._key, t.groupID
+				FROM types t
+				JOIN groups g ON g._key = t.groupID
+				WHERE t.published = 1
+				  AND t.marketGroupID IS NULL
+				  AND g.categoryID IN (4, 6, 7, 8, 18, 20, 22, 25, 32, 34, 35, 41, 42, 43, 65, 66, 87)`,
+		},
+		{
+			Name:        "blueprint_products_exist",
+			Severity:    SeverityError,
+			Description: "every blueprint activity product must be an existing type",
+			Tables:      []string{"blueprints", "types"},
+			Query: `SELECT b._key, json_extract(p.value, '$.typeID')
+				FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.products') p
+				WHERE NOT EXISTS (SELECT 1 FROM types t WHERE t._key = json_extract(p.value, '$.typeID'))`,
+		},
+		{
+			Name:        "blueprint_materials_exist",
+			Severity:    SeverityWarning,
+			Description: "every blueprint activity material must be an existing type",
+			Tables:      []string{"blueprints", "types"},
+			Query: `SELECT b._key, json_extract(m.value, '$.typeID')
+				FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.materials') m
+				WHERE NOT EXISTS (SELECT 1 FROM types t WHERE t._key = json_extract(m.value, '$.typeID'))`,
+		},
+	}
+}
+
+// Run führt alle Regeln aus und sammelt bis zu sampleSize Beispiel-Schlüssel pro Regel
+func Run(db *sql.DB, rules []Rule, sampleSize int) ([]Result, error) {
+	tables, err := listTables(db)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(rules))
+	for _, rule := range rules {
+		result := Result{Rule: rule}
+
+		for _, t := range rule.Tables {
+			if !tables[t] {
+				result.Skipped = true
+				break
+			}
+		}
+
+		if !result.Skipped {
+			if err := runRule(db, &result, sampleSize); err != nil {
+				return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// runRule führt die Query einer Regel aus
+func runRule(db *sql.DB, result *Result, sampleSize int) error {
+	rows, err := db.Query(result.Rule.Query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		result.Violations++
+		if len(result.Samples) >= sampleSize {
+			continue
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		result.Samples = append(result.Samples, formatSample(values))
+	}
+
+	return rows.Err()
+}
+
+// formatSample formatiert eine Zeile als "key" bzw. "key→value"
+func formatSample(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		switch x := v.(type) {
+		case nil:
+			parts[i] = "NULL"
+		case []byte:
+			parts[i] = string(x)
+		default:
+			parts[i] = fmt.Sprint(x)
+		}
+	}
+	return strings.Join(parts, "→")
+}
+
+// listTables liefert alle Tabellen der Datenbank
+func listTables(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table'")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables[name] = true
+	}
+	return tables, rows.Err()
+}
+
+// sortedKeys liefert die Schlüssel einer Set-Map sortiert
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
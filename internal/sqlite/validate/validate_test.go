@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newFixtureDB erstellt eine kleine Datenbank mit bekannten Integritätsfehlern
+func newFixtureDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "fixture.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	stmts := []string{
+		`CREATE TABLE groups (_key INTEGER PRIMARY KEY, categoryID INTEGER)`,
+		`CREATE TABLE types (_key INTEGER PRIMARY KEY, groupID INTEGER REFERENCES groups(_key), iconID INTEGER REFERENCES icons(_key), marketGroupID INTEGER, published INTEGER)`,
+		`CREATE TABLE mapStargates (_key INTEGER PRIMARY KEY, solarSystemID INTEGER, destination TEXT)`,
+		`INSERT INTO groups VALUES (1, 6)`,
+		`INSERT INTO types VALUES (10, 1, NULL, 100, 1), (11, 2, NULL, NULL, 1), (12, 3, 5, 100, 1), (13, 1, NULL, NULL, 1)`,
+		// 1 ↔ 2 korrekt, 3 → 4 existiert nicht, 5 → 1 nicht bidirektional,
+		// 6 ↔ 7 mit falschem Zielsystem
+		`INSERT INTO mapStargates VALUES
+			(1, 100, '{"solarSystemID":200,"stargateID":2}'),
+			(2, 200, '{"solarSystemID":100,"stargateID":1}'),
+			(3, 100, '{"solarSystemID":300,"stargateID":4}'),
+			(5, 300, '{"solarSystemID":100,"stargateID":1}'),
+			(6, 300, '{"solarSystemID":999,"stargateID":7}'),
+			(7, 400, '{"solarSystemID":300,"stargateID":6}')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Fixture setup failed: %v\n%s", err, stmt)
+		}
+	}
+	return db
+}
+
+func TestForeignKeyRules(t *testing.T) {
+	db := newFixtureDB(t)
+
+	rules, err := ForeignKeyRules(db)
+	if err != nil {
+		t.Fatalf("ForeignKeyRules failed: %v", err)
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("len(rules) = %d, want 2", len(rules))
+	}
+
+	bySeverity := map[string]Severity{}
+	for _, r := range rules {
+		bySeverity[r.Name] = r.Severity
+	}
+	if bySeverity["fk_types_groupID"] != SeverityError {
+		t.Errorf("fk_types_groupID severity = %s, want error", bySeverity["fk_types_groupID"])
+	}
+	if bySeverity["fk_types_iconID"] != SeverityWarning {
+		t.Errorf("fk_types_iconID severity = %s, want warning", bySeverity["fk_types_iconID"])
+	}
+}
+
+func TestRun(t *testing.T) {
+	db := newFixtureDB(t)
+
+	rules, err := ForeignKeyRules(db)
+	if err != nil {
+		t.Fatalf("ForeignKeyRules failed: %v", err)
+	}
+	rules = append(rules, DefaultRules()...)
+
+	results, err := Run(db, rules, 1)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	byName := map[string]Result{}
+	for _, r := range results {
+		byName[r.Rule.Name] = r
+	}
+
+	tests := []struct {
+		rule       string
+		violations int
+		skipped    bool
+	}{
+		{"fk_types_groupID", 2, false},
+		{"fk_types_iconID", 0, true}, // icons fehlt
+		{"stargate_destination_exists", 1, false},
+		{"stargate_bidirectional", 1, false},
+		{"stargate_destination_system", 1, false},
+		{"published_type_market_group", 1, false},
+		{"blueprint_products_exist", 0, true},
+	}
+
+	for _, tt := range tests {
+		r, ok := byName[tt.rule]
+		if !ok {
+			t.Errorf("rule %s missing", tt.rule)
+			continue
+		}
+		if r.Skipped != tt.skipped {
+			t.Errorf("%s: skipped = %v, want %v", tt.rule, r.Skipped, tt.skipped)
+		}
+		if r.Violations != tt.violations {
+			t.Errorf("%s: violations = %d, want %d", tt.rule, r.Violations, tt.violations)
+		}
+		if r.Violations > 0 && len(r.Samples) != 1 {
+			t.Errorf("%s: len(samples) = %d, want 1", tt.rule, len(r.Samples))
+		}
+	}
+
+	if got := byName["fk_types_groupID"].Samples[0]; got != "11→2" {
+		t.Errorf("fk_types_groupID sample = %q, want 11→2", got)
+	}
+}
+
+func TestResultFailed(t *testing.T) {
+	warn := Result{Rule: Rule{Severity: SeverityWarning}, Violations: 3}
+	if warn.Failed(SeverityError) {
+		t.Error("warning should not fail at threshold error")
+	}
+	if !warn.Failed(SeverityWarning) {
+		t.Error("warning should fail at threshold warning")
+	}
+
+	skipped := Result{Rule: Rule{Severity: SeverityError}, Violations: 1, Skipped: true}
+	if skipped.Failed(SeverityInfo) {
+		t.Error("skipped rule should never fail")
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []Severity{SeverityInfo, SeverityWarning, SeverityError} {
+		got, err := ParseSeverity(s.String())
+		if err != nil || got != s {
+			t.Errorf("ParseSeverity(%q) = %v, %v", s.String(), got, err)
+		}
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("Expected error for unknown severity")
+	}
+}
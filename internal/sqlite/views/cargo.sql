@@ -5,7 +5,10 @@
 -- v_item_volumes: Item volume data for transport calculations
 -- Provides volume, capacity, and value density information for all published items
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_item_volumes AS
+-- @view v_item_volumes
+-- @depends types, groups
+-- @materialize type_id, category_id
+CREATE VIEW v_item_volumes AS
 SELECT 
     t._key as type_id,
     COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as item_name,
@@ -27,9 +30,11 @@ WHERE t.published = 1
 -- =============================================================================
 -- v_ship_cargo_capacities: Ship cargo capacity information
 -- Provides base cargo capacities for all published ships (without skill bonuses)
--- Skill bonuses must be applied in application code
+-- Skill and module bonuses: pkg/evedb/dogma (Data.Evaluate)
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_ship_cargo_capacities AS
+-- @view v_ship_cargo_capacities
+-- @depends types, groups, categories
+CREATE VIEW v_ship_cargo_capacities AS
 SELECT
     t._key as ship_type_id,
     COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as ship_name,
@@ -49,21 +54,22 @@ AND CAST(t.capacity AS REAL) > 0;
 -- v_route_security_analysis: Route security analysis for hauling
 -- Provides security classification and risk indicators for all systems
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_route_security_analysis AS
+-- @view v_route_security_analysis
+-- @depends mapSolarSystems, mapStargates, mapRegions
+-- @materialize system_id, security_class
+CREATE VIEW v_route_security_analysis AS
 SELECT
     sys._key as system_id,
     COALESCE(json_extract(sys.name, '$.en'), json_extract(sys.name, '$.de')) as system_name,
     sys.securityStatus as security_status,
-    CASE 
-        WHEN sys.securityStatus >= 0.5 THEN 'High-Sec'
-        WHEN sys.securityStatus > 0.0 THEN 'Low-Sec'
-        ELSE 'Null-Sec'
-    END as security_class,
-    -- Chokepoint detection (fewer gates = higher risk)
+    sys.displaySecurity as display_security,
+    sys.securityZone as security_class,
+    -- Number of stargates (connectivity only); chokepoints are in graph_articulation_points
+    -- and graph_bridges (sde-to-sqlite --graph-analysis)
     (SELECT COUNT(*) FROM mapStargates WHERE solarSystemID = sys._key) as gate_count,
     sys.border as is_border_system,
     sys.corridor as is_corridor_system,
-    r.regionID,
+    r._key as regionID,
     COALESCE(json_extract(r.name, '$.en'), json_extract(r.name, '$.de')) as region_name
 FROM mapSolarSystems sys
 LEFT JOIN mapRegions r ON sys.regionID = r._key;
@@ -0,0 +1,192 @@
+-- EVE Industry - SQL Views
+-- These views flatten blueprints.activities and expand build trees (bill of materials)
+
+-- =============================================================================
+-- v_blueprint_products: Products of every blueprint activity
+-- activity: manufacturing, reaction, invention, copying, research_*;
+-- probability is only set for invention
+-- =============================================================================
+-- @view v_blueprint_products
+-- @depends blueprints, types
+-- @materialize product_type_id, blueprint_type_id
+CREATE VIEW v_blueprint_products AS
+SELECT
+    b._key as blueprint_type_id,
+    a.key as activity,
+    CAST(json_extract(p.value, '$.typeID') AS INTEGER) as product_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as product_name,
+    CAST(json_extract(p.value, '$.quantity') AS INTEGER) as quantity,
+    CAST(json_extract(p.value, '$.probability') AS REAL) as probability,
+    CAST(json_extract(a.value, '$.time') AS INTEGER) as time
+FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.products') p
+LEFT JOIN types t ON t._key = json_extract(p.value, '$.typeID');
+
+-- =============================================================================
+-- v_blueprint_materials: Input materials of every blueprint activity (per run, ME 0)
+-- =============================================================================
+-- @view v_blueprint_materials
+-- @depends blueprints, types
+-- @materialize blueprint_type_id, material_type_id
+CREATE VIEW v_blueprint_materials AS
+SELECT
+    b._key as blueprint_type_id,
+    a.key as activity,
+    CAST(json_extract(m.value, '$.typeID') AS INTEGER) as material_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as material_name,
+    CAST(json_extract(m.value, '$.quantity') AS INTEGER) as quantity
+FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.materials') m
+LEFT JOIN types t ON t._key = json_extract(m.value, '$.typeID');
+
+-- =============================================================================
+-- v_bom_tree: Recursive build tree of every manufacturable/reactable product
+-- quantity: units per produced unit of root_type_id (ME 0, no rounding);
+-- blueprint_type_id NULL marks raw materials (minerals, PI, moon materials, ...)
+-- Expands all products - filter on root_type_id, for ME/TE/runs use pkg/evedb/industry
+-- =============================================================================
+-- @view v_bom_tree
+-- @depends v_blueprint_products, v_blueprint_materials, types
+CREATE VIEW v_bom_tree AS
+WITH RECURSIVE producers AS (
+    -- Ein Blueprint je Produkt (kleinste Blueprint-ID)
+    SELECT product_type_id, MIN(blueprint_type_id) as blueprint_type_id, activity, quantity
+    FROM v_blueprint_products
+    WHERE activity IN ('manufacturing', 'reaction') AND quantity > 0
+    GROUP BY product_type_id
+),
+tree(root_type_id, parent_type_id, type_id, depth, quantity, path) AS (
+    SELECT product_type_id, NULL, product_type_id, 0, 1.0, ',' || product_type_id || ','
+    FROM producers
+
+    UNION ALL
+
+    SELECT
+        t.root_type_id,
+        t.type_id,
+        m.material_type_id,
+        t.depth + 1,
+        t.quantity * m.quantity / p.quantity,
+        t.path || m.material_type_id || ','
+    FROM tree t
+    JOIN producers p ON p.product_type_id = t.type_id
+    JOIN v_blueprint_materials m ON m.blueprint_type_id = p.blueprint_type_id AND m.activity = p.activity
+    WHERE t.depth < 16
+      AND instr(t.path, ',' || m.material_type_id || ',') = 0
+)
+SELECT
+    t.root_type_id,
+    t.parent_type_id,
+    t.type_id,
+    COALESCE(json_extract(ty.name, '$.en'), json_extract(ty.name, '$.de')) as type_name,
+    t.depth,
+    t.quantity,
+    p.blueprint_type_id,
+    p.activity,
+    t.path
+FROM tree t
+LEFT JOIN producers p ON p.product_type_id = t.type_id
+LEFT JOIN types ty ON ty._key = t.type_id;
+
+-- =============================================================================
+-- v_bom_raw_materials: Aggregated raw materials per produced unit (ME 0)
+-- Example: SELECT * FROM v_bom_raw_materials WHERE root_type_id = 587
+-- =============================================================================
+-- @view v_bom_raw_materials
+-- @depends v_bom_tree
+CREATE VIEW v_bom_raw_materials AS
+SELECT
+    root_type_id,
+    type_id as material_type_id,
+    type_name as material_name,
+    SUM(quantity) as quantity
+FROM v_bom_tree
+WHERE blueprint_type_id IS NULL
+GROUP BY root_type_id, type_id, type_name;
+
+-- =============================================================================
+-- v_material_usage: Which blueprints consume a material ("used in")
+-- Reads the indexed blueprint_material_usage table (internal/sqlite/blueprints);
+-- product_* is the product of the same activity (NULL for research/copying)
+-- Example: SELECT * FROM v_material_usage WHERE material_type_id = 34
+-- =============================================================================
+-- @view v_material_usage
+-- @depends blueprint_material_usage, blueprint_product_sources, types
+CREATE VIEW v_material_usage AS
+SELECT
+    u.material_type_id,
+    COALESCE(json_extract(mt.name, '$.en'), json_extract(mt.name, '$.de')) as material_name,
+    u.activity,
+    u.quantity,
+    u.blueprint_type_id,
+    COALESCE(json_extract(bt.name, '$.en'), json_extract(bt.name, '$.de')) as blueprint_name,
+    s.product_type_id,
+    COALESCE(json_extract(pt.name, '$.en'), json_extract(pt.name, '$.de')) as product_name
+FROM blueprint_material_usage u
+LEFT JOIN blueprint_product_sources s ON s.blueprint_type_id = u.blueprint_type_id AND s.activity = u.activity
+LEFT JOIN types mt ON mt._key = u.material_type_id
+LEFT JOIN types bt ON bt._key = u.blueprint_type_id
+LEFT JOIN types pt ON pt._key = s.product_type_id;
+
+-- =============================================================================
+-- v_product_sources: Which blueprints produce a type ("produced by")
+-- Covers manufacturing, reaction formulas and invention (with probability)
+-- Example: SELECT * FROM v_product_sources WHERE product_type_id = 16679
+-- =============================================================================
+-- @view v_product_sources
+-- @depends blueprint_product_sources, types
+CREATE VIEW v_product_sources AS
+SELECT
+    s.product_type_id,
+    COALESCE(json_extract(pt.name, '$.en'), json_extract(pt.name, '$.de')) as product_name,
+    s.activity,
+    s.quantity,
+    s.probability,
+    s.blueprint_type_id,
+    COALESCE(json_extract(bt.name, '$.en'), json_extract(bt.name, '$.de')) as blueprint_name
+FROM blueprint_product_sources s
+LEFT JOIN types pt ON pt._key = s.product_type_id
+LEFT JOIN types bt ON bt._key = s.blueprint_type_id;
+
+-- =============================================================================
+-- v_blueprint_skills: Required skills of every blueprint activity
+-- =============================================================================
+-- @view v_blueprint_skills
+-- @depends blueprints, types
+CREATE VIEW v_blueprint_skills AS
+SELECT
+    b._key as blueprint_type_id,
+    a.key as activity,
+    CAST(json_extract(s.value, '$.typeID') AS INTEGER) as skill_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as skill_name,
+    CAST(json_extract(s.value, '$.level') AS INTEGER) as level
+FROM blueprints b, json_each(b.activities) a, json_each(a.value, '$.skills') s
+LEFT JOIN types t ON t._key = json_extract(s.value, '$.typeID');
+
+-- =============================================================================
+-- v_decryptors: Invention decryptors with their dogma modifiers
+-- Every type with inventionPropabilityMultiplier (sic, SDE spelling) is a decryptor;
+-- me/te/run modifiers are added to the invented blueprint (ME 2, TE 4, base runs)
+-- =============================================================================
+-- @view v_decryptors
+-- @depends types, typeDogma, dogmaAttributes
+CREATE VIEW v_decryptors AS
+WITH attrs AS (
+    SELECT
+        d._key as type_id,
+        da.name as attribute_name,
+        CAST(json_extract(a.value, '$.value') AS REAL) as value
+    FROM typeDogma d
+    JOIN json_each(d.dogmaAttributes) a
+    JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+    WHERE da.name IN ('inventionPropabilityMultiplier', 'inventionMEModifier', 'inventionTEModifier', 'inventionMaxRunModifier')
+)
+SELECT
+    a.type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as type_name,
+    MAX(CASE WHEN a.attribute_name = 'inventionPropabilityMultiplier' THEN a.value END) as probability_multiplier,
+    CAST(COALESCE(MAX(CASE WHEN a.attribute_name = 'inventionMEModifier' THEN a.value END), 0) AS INTEGER) as me_modifier,
+    CAST(COALESCE(MAX(CASE WHEN a.attribute_name = 'inventionTEModifier' THEN a.value END), 0) AS INTEGER) as te_modifier,
+    CAST(COALESCE(MAX(CASE WHEN a.attribute_name = 'inventionMaxRunModifier' THEN a.value END), 0) AS INTEGER) as run_modifier
+FROM attrs a
+LEFT JOIN types t ON t._key = a.type_id
+GROUP BY a.type_id, type_name
+HAVING probability_multiplier IS NOT NULL;
@@ -1,4 +1,4 @@
-// Package views provides SQL view initialization for the eve-sde databasepackage views
+// Package views provides SQL view initialization for the eve-sde database
 
 // This package is part of the DB-core and contains only SQL view definitions
 package views
@@ -15,11 +15,68 @@ var navigationViewsSQL string
 //go:embed cargo.sql
 var cargoViewsSQL string
 
+//go:embed wormhole.sql
+var wormholeViewsSQL string
+
+//go:embed industry.sql
+var industryViewsSQL string
+
+//go:embed reprocessing.sql
+var reprocessingViewsSQL string
+
+//go:embed planetary.sql
+var planetaryViewsSQL string
+
+//go:embed skills.sql
+var skillsViewsSQL string
+
+// Quelldateien der eingebetteten Views
+const (
+	NavigationSource   = "navigation.sql"
+	CargoSource        = "cargo.sql"
+	WormholeSource     = "wormhole.sql"
+	IndustrySource     = "industry.sql"
+	ReprocessingSource = "reprocessing.sql"
+	PlanetarySource    = "planetary.sql"
+	SkillsSource       = "skills.sql"
+)
+
+// DefaultRegistry erstellt die Registry aller eingebetteten Views
+func DefaultRegistry() (*Registry, error) {
+	r := &Registry{views: make(map[string]*View)}
+
+	sources := []struct{ name, content string }{
+		{NavigationSource, navigationViewsSQL},
+		{CargoSource, cargoViewsSQL},
+		{WormholeSource, wormholeViewsSQL},
+		{IndustrySource, industryViewsSQL},
+		{ReprocessingSource, reprocessingViewsSQL},
+		{PlanetarySource, planetaryViewsSQL},
+		{SkillsSource, skillsViewsSQL},
+	}
+	for _, src := range sources {
+		views, err := ParseViews(src.name, src.content)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range views {
+			if err := r.Register(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Zyklen und unbekannte Views früh erkennen
+	if _, err := r.Names(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // InitializeNavigationViews creates all navigation-related views in the database
 // This should be called after map data (mapSolarSystems, mapStargates) has been imported
 func InitializeNavigationViews(db *sql.DB) error {
-	_, err := db.Exec(navigationViewsSQL)
-	if err != nil {
+	if err := initializeSource(db, NavigationSource); err != nil {
 		return fmt.Errorf("failed to initialize navigation views: %w", err)
 	}
 	return nil
@@ -28,9 +85,75 @@ func InitializeNavigationViews(db *sql.DB) error {
 // InitializeCargoViews creates all cargo-related views in the database
 // This should be called after types, groups, categories, and typeDogma data has been imported
 func InitializeCargoViews(db *sql.DB) error {
-	_, err := db.Exec(cargoViewsSQL)
-	if err != nil {
+	if err := initializeSource(db, CargoSource); err != nil {
 		return fmt.Errorf("failed to initialize cargo views: %w", err)
 	}
 	return nil
 }
+
+// InitializeWormholeViews creates all wormhole-related views in the database
+// This should be called after map data (mapSolarSystems, mapStars), types and typeDogma data has been imported
+func InitializeWormholeViews(db *sql.DB) error {
+	if err := initializeSource(db, WormholeSource); err != nil {
+		return fmt.Errorf("failed to initialize wormhole views: %w", err)
+	}
+	return nil
+}
+
+// InitializeIndustryViews creates all industry-related views in the database
+// This should be called after blueprints and types data has been imported
+func InitializeIndustryViews(db *sql.DB) error {
+	if err := initializeSource(db, IndustrySource); err != nil {
+		return fmt.Errorf("failed to initialize industry views: %w", err)
+	}
+	return nil
+}
+
+// InitializeReprocessingViews creates all reprocessing-related views in the database
+// This should be called after typeMaterials, types, typeDogma and npcStations data has been imported
+func InitializeReprocessingViews(db *sql.DB) error {
+	if err := initializeSource(db, ReprocessingSource); err != nil {
+		return fmt.Errorf("failed to initialize reprocessing views: %w", err)
+	}
+	return nil
+}
+
+// InitializePlanetaryViews creates all planetary interaction views in the database
+// This should be called after planetSchematics, mapPlanets and types have been imported
+// and the PI tables (internal/sqlite/planetary) have been built
+func InitializePlanetaryViews(db *sql.DB) error {
+	if err := initializeSource(db, PlanetarySource); err != nil {
+		return fmt.Errorf("failed to initialize planetary views: %w", err)
+	}
+	return nil
+}
+
+// InitializeSkillsViews creates all skill prerequisite views in the database
+// This should be called after types, groups, typeDogma, dogmaAttributes and characterAttributes have been imported
+func InitializeSkillsViews(db *sql.DB) error {
+	if err := initializeSource(db, SkillsSource); err != nil {
+		return fmt.Errorf("failed to initialize skills views: %w", err)
+	}
+	return nil
+}
+
+// initializeSource erstellt alle Views einer Quelldatei in Abhängigkeitsreihenfolge
+func initializeSource(db *sql.DB, source string) error {
+	r, err := DefaultRegistry()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name, v := range r.views {
+		if v.Source == source {
+			names = append(names, name)
+		}
+	}
+
+	ordered, err := r.order(names)
+	if err != nil {
+		return err
+	}
+	return r.create(db, ordered)
+}
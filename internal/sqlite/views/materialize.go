@@ -0,0 +1,194 @@
+package views
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaterializedTable protokolliert die materialisierten Views
+const MaterializedTable = "_materialized_views"
+
+// Snapshot beschreibt eine materialisierte View
+type Snapshot struct {
+	View      string
+	Table     string
+	Rows      int64
+	CreatedAt string
+}
+
+// MaterializedName liefert den Tabellennamen der materialisierten View (v_system_info → mv_system_info)
+func MaterializedName(view string) string {
+	return "mv_" + strings.TrimPrefix(view, "v_")
+}
+
+// Materializable liefert alle per "-- @materialize" markierten Views in Abhängigkeitsreihenfolge
+func (r *Registry) Materializable() ([]string, error) {
+	var names []string
+	for name, v := range r.views {
+		if v.Materialize {
+			names = append(names, name)
+		}
+	}
+	return r.order(names)
+}
+
+// Materialize schreibt die Views als indizierte Tabellen (mv_*) und protokolliert sie in MaterializedTable.
+// Bestehende Snapshots werden ersetzt, fehlende Views vorher angelegt.
+func (r *Registry) Materialize(db *sql.DB, names ...string) ([]Snapshot, error) {
+	ordered, err := r.order(names)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.ensureViews(db, ordered); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureMaterializedTable(tx); err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Now().UTC().Format(time.RFC3339)
+	snapshots := make([]Snapshot, 0, len(ordered))
+
+	for _, name := range ordered {
+		v := r.views[name]
+		table := MaterializedName(name)
+
+		stmts := []string{
+			"DROP TABLE IF EXISTS " + table,
+			fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s", table, name),
+		}
+		for _, col := range v.MaterializeIndices {
+			stmts = append(stmts, fmt.Sprintf("CREATE INDEX idx_%s_%s ON %s(%s)", table, col, table, col))
+		}
+		for _, stmt := range stmts {
+			if _, err := tx.Exec(stmt); err != nil {
+				return nil, fmt.Errorf("failed to materialize %s: %w", name, err)
+			}
+		}
+
+		s := Snapshot{View: name, Table: table, CreatedAt: createdAt}
+		if err := tx.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&s.Rows); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+
+		_, err := tx.Exec("INSERT OR REPLACE INTO "+MaterializedTable+" (view_name, table_name, row_count, created_at) VALUES (?, ?, ?, ?)",
+			s.View, s.Table, s.Rows, s.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record snapshot %s: %w", table, err)
+		}
+		snapshots = append(snapshots, s)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Dematerialize entfernt Snapshots; Consumer lesen danach wieder die View
+func Dematerialize(db *sql.DB, names ...string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := ensureMaterializedTable(tx); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if _, err := tx.Exec("DROP TABLE IF EXISTS " + MaterializedName(name)); err != nil {
+			return fmt.Errorf("failed to drop snapshot of %s: %w", name, err)
+		}
+		if _, err := tx.Exec("DELETE FROM "+MaterializedTable+" WHERE view_name = ?", name); err != nil {
+			return fmt.Errorf("failed to remove snapshot record %s: %w", name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Snapshots liefert alle protokollierten Snapshots (View-Name → Snapshot)
+func Snapshots(db *sql.DB) (map[string]Snapshot, error) {
+	snapshots := make(map[string]Snapshot)
+
+	rows, err := db.Query("SELECT view_name, table_name, row_count, created_at FROM " + MaterializedTable)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return snapshots, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s Snapshot
+		if err := rows.Scan(&s.View, &s.Table, &s.Rows, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		snapshots[s.View] = s
+	}
+	return snapshots, rows.Err()
+}
+
+// Source liefert die Relation, aus der Consumer eine View lesen sollen:
+// den Snapshot (mv_*), falls materialisiert, sonst die View selbst.
+// Beide haben dieselben Spalten.
+func Source(db *sql.DB, view string) (string, error) {
+	var table string
+	err := db.QueryRow("SELECT table_name FROM "+MaterializedTable+" WHERE view_name = ?", view).Scan(&table)
+	switch {
+	case err == nil:
+		return table, nil
+	case err == sql.ErrNoRows, strings.Contains(err.Error(), "no such table"):
+		return view, nil
+	default:
+		return "", fmt.Errorf("failed to resolve source of %s: %w", view, err)
+	}
+}
+
+// refreshSnapshots aktualisiert bestehende Snapshots der übergebenen Views
+func (r *Registry) refreshSnapshots(db *sql.DB, names []string) error {
+	snapshots, err := Snapshots(db)
+	if err != nil {
+		return err
+	}
+
+	var stale []string
+	for _, name := range names {
+		if _, ok := snapshots[name]; ok {
+			stale = append(stale, name)
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	sort.Strings(stale)
+
+	_, err = r.Materialize(db, stale...)
+	return err
+}
+
+// ensureMaterializedTable legt die Protokolltabelle an
+func ensureMaterializedTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE TABLE IF NOT EXISTS ` + MaterializedTable + ` (
+  view_name TEXT PRIMARY KEY,
+  table_name TEXT NOT NULL,
+  row_count INTEGER NOT NULL,
+  created_at TEXT NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", MaterializedTable, err)
+	}
+	return nil
+}
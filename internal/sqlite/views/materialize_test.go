@@ -0,0 +1,93 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseViews_Materialize(t *testing.T) {
+	views, err := ParseViews("test.sql", `-- @view v_a
+-- @depends systems
+-- @materialize _key, sec
+CREATE VIEW v_a AS SELECT _key, sec FROM systems;
+`)
+	if err != nil {
+		t.Fatalf("ParseViews failed: %v", err)
+	}
+	if !views[0].Materialize || !reflect.DeepEqual(views[0].MaterializeIndices, []string{"_key", "sec"}) {
+		t.Errorf("view = %+v, want materialize with indices _key, sec", views[0])
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	db := openTestDB(t)
+	r := testRegistry(t)
+	r.views["v_high"].MaterializeIndices = []string{"_key"}
+
+	// Source ohne Snapshot ist die View selbst
+	if src, err := Source(db, "v_high"); err != nil || src != "v_high" {
+		t.Errorf("Source = %q, %v; want v_high", src, err)
+	}
+
+	// Views existieren noch nicht und werden samt Abhängigkeiten angelegt
+	snapshots, err := r.Materialize(db, "v_high")
+	if err != nil {
+		t.Fatalf("Materialize failed: %v", err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Table != "mv_high" || snapshots[0].Rows != 1 {
+		t.Fatalf("snapshots = %+v, want mv_high with 1 row", snapshots)
+	}
+
+	src, err := Source(db, "v_high")
+	if err != nil || src != "mv_high" {
+		t.Fatalf("Source = %q, %v; want mv_high", src, err)
+	}
+
+	var index string
+	if err := db.QueryRow("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = 'mv_high'").Scan(&index); err != nil || index != "idx_mv_high__key" {
+		t.Errorf("index = %q, %v; want idx_mv_high__key", index, err)
+	}
+
+	// Rebuild nach Import aktualisiert den Snapshot
+	if _, err := db.Exec("UPDATE systems SET sec = 0.5 WHERE _key = 2"); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := r.Rebuild(db, "systems"); err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + src).Scan(&count); err != nil || count != 2 {
+		t.Errorf("snapshot rows = %d, %v; want 2 after rebuild", count, err)
+	}
+
+	recorded, err := Snapshots(db)
+	if err != nil || recorded["v_high"].Rows != 2 {
+		t.Errorf("Snapshots = %+v, %v; want v_high with 2 rows", recorded, err)
+	}
+
+	if err := Dematerialize(db, "v_high"); err != nil {
+		t.Fatalf("Dematerialize failed: %v", err)
+	}
+	if src, _ := Source(db, "v_high"); src != "v_high" {
+		t.Errorf("Source after Dematerialize = %q, want v_high", src)
+	}
+}
+
+func TestDefaultRegistry_Materializable(t *testing.T) {
+	r, err := DefaultRegistry()
+	if err != nil {
+		t.Fatalf("DefaultRegistry failed: %v", err)
+	}
+	names, err := r.Materializable()
+	if err != nil {
+		t.Fatalf("Materializable failed: %v", err)
+	}
+	want := []string{
+		"v_blueprint_materials", "v_blueprint_products", "v_system_info", "v_constellation_adjacency", "v_item_volumes", "v_region_adjacency",
+		"v_reprocessing_materials", "v_route_security_analysis", "v_skill_prerequisite_tree",
+		"v_wormhole_systems", "v_wormhole_types",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Materializable = %v, want %v", names, want)
+	}
+}
@@ -6,7 +6,9 @@
 -- Extracts gate connections from JSON destination field
 -- Used for pathfinding and route calculation
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_stargate_graph AS
+-- @view v_stargate_graph
+-- @depends mapStargates
+CREATE VIEW v_stargate_graph AS
 SELECT 
     s.solarSystemID as from_system_id,
     CAST(json_extract(s.destination, '$.solarSystemID') AS INTEGER) as to_system_id,
@@ -29,20 +31,20 @@ WHERE json_extract(s.destination, '$.solarSystemID') IS NOT NULL;
 -- =============================================================================
 -- v_system_info: Enhanced system information with parsed names and security zones
 -- Provides human-readable system data for routing and analysis
+-- display_security / security_zone come from the generated columns of
+-- mapSolarSystems (single classification, see internal/sqlite/security)
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_system_info AS
+-- @view v_system_info
+-- @depends mapSolarSystems, mapRegions, mapConstellations
+-- @materialize system_id, constellation_id, region_id, security_zone
+CREATE VIEW v_system_info AS
 SELECT 
     sys._key as system_id,
     sys._key as solar_system_id,  -- _key IS the solar system ID
     COALESCE(json_extract(sys.name, '$.en'), json_extract(sys.name, '$.de')) as system_name,
     sys.securityStatus as security_status,
-    CASE 
-        WHEN sys.securityStatus >= 0.45 THEN 'High-Sec'
-        WHEN sys.securityStatus > 0.0 THEN 'Low-Sec'
-        WHEN sys.securityStatus <= 0.0 AND sys.wormholeClassID IS NULL THEN 'Null-Sec'
-        WHEN sys.wormholeClassID IS NOT NULL THEN 'Wormhole'
-        ELSE 'Unknown'
-    END as security_zone,
+    sys.displaySecurity as display_security,
+    sys.securityZone as security_zone,
     sys.constellationID as constellation_id,
     sys.regionID as region_id,
     COALESCE(json_extract(r.name, '$.en'), json_extract(r.name, '$.de')) as region_name,
@@ -59,7 +61,9 @@ LEFT JOIN mapConstellations c ON sys.constellationID = c._key;
 -- v_system_security_zones: Security zone statistics by region/constellation
 -- Useful for risk assessment and region analysis
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_system_security_zones AS
+-- @view v_system_security_zones
+-- @depends v_system_info
+CREATE VIEW v_system_security_zones AS
 SELECT 
     region_id,
     region_name,
@@ -73,7 +77,9 @@ GROUP BY region_id, region_name, security_zone;
 -- v_region_stats: Comprehensive region statistics
 -- Total systems, average security, and border system counts
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_region_stats AS
+-- @view v_region_stats
+-- @depends v_system_info
+CREATE VIEW v_region_stats AS
 SELECT 
     region_id,
     region_name,
@@ -83,33 +89,110 @@ SELECT
     SUM(CASE WHEN security_zone = 'High-Sec' THEN 1 ELSE 0 END) as high_sec_count,
     SUM(CASE WHEN security_zone = 'Low-Sec' THEN 1 ELSE 0 END) as low_sec_count,
     SUM(CASE WHEN security_zone = 'Null-Sec' THEN 1 ELSE 0 END) as null_sec_count,
-    SUM(CASE WHEN security_zone = 'Wormhole' THEN 1 ELSE 0 END) as wormhole_count
+    SUM(CASE WHEN security_zone = 'Wormhole' THEN 1 ELSE 0 END) as wormhole_count,
+    SUM(CASE WHEN security_zone = 'Pochven' THEN 1 ELSE 0 END) as pochven_count
 FROM v_system_info
 GROUP BY region_id, region_name;
 
 -- =============================================================================
--- v_trade_hubs: Pre-calculated major trade hub information
--- Known trade hub system IDs (from EVE data):
--- Jita (The Forge): 30000142
--- Amarr (Domain): 30002187
--- Dodixie (Sinq Laison): 30002659
--- Rens (Heimatar): 30002510
--- Hek (Metropolis): 30002053
+-- v_trade_hubs: Configured trade hubs with system information
+-- Hubs come from the trade_hubs registry (internal/sqlite/hubs, default:
+-- Jita, Amarr, Dodixie, Rens, Hek; override with sde-to-sqlite --hubs)
 -- =============================================================================
-CREATE VIEW IF NOT EXISTS v_trade_hubs AS
+-- @view v_trade_hubs
+-- @depends trade_hubs, v_system_info
+CREATE VIEW v_trade_hubs AS
 SELECT 
-    system_id,
-    system_name,
-    region_name,
-    security_status,
-    security_zone,
-    CASE system_id
-        WHEN 30000142 THEN 'Jita'
-        WHEN 30002187 THEN 'Amarr'
-        WHEN 30002659 THEN 'Dodixie'
-        WHEN 30002510 THEN 'Rens'
-        WHEN 30002053 THEN 'Hek'
-        ELSE NULL
-    END as hub_name
-FROM v_system_info
-WHERE system_id IN (30000142, 30002187, 30002659, 30002510, 30002053);
+    s.system_id,
+    s.system_name,
+    s.region_name,
+    s.security_status,
+    s.security_zone,
+    h.name as hub_name,
+    h.station_id,
+    h.tier
+FROM trade_hubs h
+JOIN v_system_info s ON s.system_id = h.system_id
+ORDER BY h.sort_order;
+
+-- =============================================================================
+-- v_gate_connections: Stargate pairs (one row per direction)
+-- Both gates of a connection collapse into the same row; connections with
+-- only one gate in the data get the reverse direction with to_gate_id NULL
+-- =============================================================================
+-- @view v_gate_connections
+-- @depends mapStargates
+CREATE VIEW v_gate_connections AS
+SELECT
+    s.solarSystemID as from_system_id,
+    s._key as from_gate_id,
+    CAST(json_extract(s.destination, '$.solarSystemID') AS INTEGER) as to_system_id,
+    CAST(json_extract(s.destination, '$.stargateID') AS INTEGER) as to_gate_id
+FROM mapStargates s
+WHERE json_extract(s.destination, '$.solarSystemID') IS NOT NULL
+
+UNION
+
+SELECT
+    CAST(json_extract(s.destination, '$.solarSystemID') AS INTEGER) as from_system_id,
+    CAST(json_extract(s.destination, '$.stargateID') AS INTEGER) as from_gate_id,
+    s.solarSystemID as to_system_id,
+    s._key as to_gate_id
+FROM mapStargates s
+WHERE json_extract(s.destination, '$.solarSystemID') IS NOT NULL;
+
+-- =============================================================================
+-- v_region_adjacency: Regions connected by at least one stargate
+-- One row per direction; gates holds the connecting gate pairs as JSON array
+-- Example: regions bordering Delve -> WHERE from_region_id = 10000060
+-- =============================================================================
+-- @view v_region_adjacency
+-- @depends v_gate_connections, v_system_info
+-- @materialize from_region_id, to_region_id
+CREATE VIEW v_region_adjacency AS
+SELECT
+    f.region_id as from_region_id,
+    f.region_name as from_region_name,
+    t.region_id as to_region_id,
+    t.region_name as to_region_name,
+    COUNT(*) as gate_count,
+    json_group_array(json_object(
+        'from_system_id', g.from_system_id,
+        'from_gate_id', g.from_gate_id,
+        'to_system_id', g.to_system_id,
+        'to_gate_id', g.to_gate_id
+    )) as gates
+FROM v_gate_connections g
+JOIN v_system_info f ON f.system_id = g.from_system_id
+JOIN v_system_info t ON t.system_id = g.to_system_id
+WHERE f.region_id <> t.region_id
+GROUP BY f.region_id, f.region_name, t.region_id, t.region_name;
+
+-- =============================================================================
+-- v_constellation_adjacency: Constellations connected by at least one stargate
+-- Same layout as v_region_adjacency, plus the region of each constellation
+-- =============================================================================
+-- @view v_constellation_adjacency
+-- @depends v_gate_connections, v_system_info
+-- @materialize from_constellation_id, to_constellation_id
+CREATE VIEW v_constellation_adjacency AS
+SELECT
+    f.constellation_id as from_constellation_id,
+    f.constellation_name as from_constellation_name,
+    f.region_id as from_region_id,
+    t.constellation_id as to_constellation_id,
+    t.constellation_name as to_constellation_name,
+    t.region_id as to_region_id,
+    COUNT(*) as gate_count,
+    json_group_array(json_object(
+        'from_system_id', g.from_system_id,
+        'from_gate_id', g.from_gate_id,
+        'to_system_id', g.to_system_id,
+        'to_gate_id', g.to_gate_id
+    )) as gates
+FROM v_gate_connections g
+JOIN v_system_info f ON f.system_id = g.from_system_id
+JOIN v_system_info t ON t.system_id = g.to_system_id
+WHERE f.constellation_id <> t.constellation_id
+GROUP BY f.constellation_id, f.constellation_name, f.region_id,
+         t.constellation_id, t.constellation_name, t.region_id;
@@ -0,0 +1,128 @@
+-- EVE Planetary Interaction - SQL Views
+-- These views read the normalized PI tables (internal/sqlite/planetary) and classify the chain P0 → P4
+
+-- =============================================================================
+-- v_pi_schematics: Output of every PI schematic per cycle
+-- =============================================================================
+-- @view v_pi_schematics
+-- @depends planetSchematics, planet_schematic_types, types
+CREATE VIEW v_pi_schematics AS
+SELECT
+    s._key as schematic_id,
+    COALESCE(json_extract(s.name, '$.en'), json_extract(s.name, '$.de')) as schematic_name,
+    CAST(s.cycleTime AS INTEGER) as cycle_time,
+    st.type_id as output_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as output_name,
+    st.quantity as output_quantity
+FROM planetSchematics s
+JOIN planet_schematic_types st ON st.schematic_id = s._key AND st.is_input = 0
+LEFT JOIN types t ON t._key = st.type_id;
+
+-- =============================================================================
+-- v_pi_schematic_inputs: Inputs of every PI schematic per cycle
+-- =============================================================================
+-- @view v_pi_schematic_inputs
+-- @depends planet_schematic_types, types
+CREATE VIEW v_pi_schematic_inputs AS
+SELECT
+    st.schematic_id,
+    st.type_id as input_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as input_name,
+    st.quantity
+FROM planet_schematic_types st
+LEFT JOIN types t ON t._key = st.type_id
+WHERE st.is_input = 1;
+
+-- =============================================================================
+-- v_pi_levels: Tier of every PI commodity (0 = P0 raw resource ... 4 = P4)
+-- P0 are inputs that no schematic produces; a product is one tier above its highest input
+-- =============================================================================
+-- @view v_pi_levels
+-- @depends planet_schematic_types, types
+CREATE VIEW v_pi_levels AS
+WITH RECURSIVE tiers(type_id, level) AS (
+    SELECT DISTINCT type_id, 0
+    FROM planet_schematic_types
+    WHERE is_input = 1
+      AND type_id NOT IN (SELECT type_id FROM planet_schematic_types WHERE is_input = 0)
+
+    UNION
+
+    SELECT o.type_id, t.level + 1
+    FROM tiers t
+    JOIN planet_schematic_types i ON i.type_id = t.type_id AND i.is_input = 1
+    JOIN planet_schematic_types o ON o.schematic_id = i.schematic_id AND o.is_input = 0
+    WHERE t.level < 8
+)
+SELECT
+    t.type_id,
+    COALESCE(json_extract(ty.name, '$.en'), json_extract(ty.name, '$.de')) as type_name,
+    MAX(t.level) as level
+FROM tiers t
+LEFT JOIN types ty ON ty._key = t.type_id
+GROUP BY t.type_id, type_name;
+
+-- =============================================================================
+-- v_planet_resources: Extractable P0 resources per planet type
+-- extractor_type_id is the planet type's Extractor Control Unit pin
+-- =============================================================================
+-- @view v_planet_resources
+-- @depends planet_type_resources, types
+CREATE VIEW v_planet_resources AS
+WITH planets AS (
+    SELECT DISTINCT
+        r.planet_type_id,
+        json_extract(p.name, '$.en') as planet_type_name,
+        -- "Planet (Barren)" → "Barren"
+        substr(json_extract(p.name, '$.en'), 9, length(json_extract(p.name, '$.en')) - 9) as planet_class
+    FROM planet_type_resources r
+    JOIN types p ON p._key = r.planet_type_id
+)
+SELECT
+    p.planet_type_id,
+    p.planet_type_name,
+    p.planet_class,
+    r.resource_type_id,
+    COALESCE(json_extract(rt.name, '$.en'), json_extract(rt.name, '$.de')) as resource_name,
+    (SELECT e._key FROM types e WHERE json_extract(e.name, '$.en') = p.planet_class || ' Extractor Control Unit' ORDER BY e._key LIMIT 1) as extractor_type_id
+FROM planets p
+JOIN planet_type_resources r ON r.planet_type_id = p.planet_type_id
+LEFT JOIN types rt ON rt._key = r.resource_type_id;
+
+-- =============================================================================
+-- v_pi_schematic_planets: Planet types whose facility pins can run a schematic
+-- Facility pins carry the planet class as name prefix ("Barren Advanced Industry Facility")
+-- =============================================================================
+-- @view v_pi_schematic_planets
+-- @depends planet_schematic_pins, v_planet_resources, types
+CREATE VIEW v_pi_schematic_planets AS
+SELECT DISTINCT
+    sp.schematic_id,
+    p.planet_type_id,
+    p.planet_class,
+    sp.pin_type_id,
+    json_extract(pt.name, '$.en') as pin_name
+FROM planet_schematic_pins sp
+JOIN types pt ON pt._key = sp.pin_type_id
+JOIN (SELECT DISTINCT planet_type_id, planet_class FROM v_planet_resources) p
+    ON json_extract(pt.name, '$.en') LIKE p.planet_class || ' %';
+
+-- =============================================================================
+-- v_system_planet_resources: Extractable P0 resources of every planet per solar system
+-- Example: SELECT DISTINCT resource_name FROM v_system_planet_resources WHERE system_id = 30000142
+-- =============================================================================
+-- @view v_system_planet_resources
+-- @depends mapPlanets, mapSolarSystems, v_planet_resources
+CREATE VIEW v_system_planet_resources AS
+SELECT
+    p.solarSystemID as system_id,
+    COALESCE(json_extract(s.name, '$.en'), json_extract(s.name, '$.de')) as system_name,
+    p._key as planet_id,
+    p.celestialIndex as celestial_index,
+    r.planet_type_id,
+    r.planet_class,
+    r.resource_type_id,
+    r.resource_name
+FROM mapPlanets p
+JOIN v_planet_resources r ON r.planet_type_id = p.typeID
+LEFT JOIN mapSolarSystems s ON s._key = p.solarSystemID;
@@ -0,0 +1,119 @@
+package views
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/planetary"
+)
+
+// planetaryDB legt eine PI-Kette P0 → P4 auf einem Temperate- und einem Barren-Planeten an
+func planetaryDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTestDB(t)
+
+	if _, err := db.Exec(`
+		CREATE TABLE types (_key INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE planetSchematics (_key INTEGER PRIMARY KEY, cycleTime INTEGER, name TEXT, pins TEXT, types TEXT);
+		CREATE TABLE mapSolarSystems (_key INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE mapPlanets (_key INTEGER PRIMARY KEY, solarSystemID INTEGER, typeID INTEGER, celestialIndex INTEGER);
+
+		INSERT INTO types VALUES
+			(11, '{"en":"Planet (Temperate)"}'), (2016, '{"en":"Planet (Barren)"}'),
+			(2268, '{"en":"Aqueous Liquids"}'), (2073, '{"en":"Microorganisms"}'), (2267, '{"en":"Base Metals"}'),
+			(3645, '{"en":"Water"}'), (2393, '{"en":"Bacteria"}'), (2389, '{"en":"Reactive Metals"}'),
+			(9832, '{"en":"Coolant"}'), (2463, '{"en":"Nanites"}'), (2867, '{"en":"Broadcast Node"}'),
+			(2481, '{"en":"Temperate Basic Industry Facility"}'), (2473, '{"en":"Barren Basic Industry Facility"}'),
+			(2474, '{"en":"Barren Advanced Industry Facility"}'), (2475, '{"en":"Barren High-Tech Production Plant"}'),
+			(2848, '{"en":"Barren Extractor Control Unit"}'), (3068, '{"en":"Temperate Extractor Control Unit"}');
+		INSERT INTO planetSchematics VALUES
+			(121, 1800, '{"en":"Water"}', '[2481,2473]', '[{"_key":2268,"isInput":true,"quantity":3000},{"_key":3645,"isInput":false,"quantity":20}]'),
+			(131, 1800, '{"en":"Bacteria"}', '[2481,2473]', '[{"_key":2073,"isInput":true,"quantity":3000},{"_key":2393,"isInput":false,"quantity":20}]'),
+			(134, 1800, '{"en":"Reactive Metals"}', '[2473]', '[{"_key":2267,"isInput":true,"quantity":3000},{"_key":2389,"isInput":false,"quantity":20}]'),
+			(66, 3600, '{"en":"Coolant"}', '[2474]', '[{"_key":3645,"isInput":true,"quantity":40},{"_key":2393,"isInput":true,"quantity":40},{"_key":9832,"isInput":false,"quantity":5}]'),
+			(80, 3600, '{"en":"Nanites"}', '[2474]', '[{"_key":9832,"isInput":true,"quantity":10},{"_key":2389,"isInput":true,"quantity":10},{"_key":2463,"isInput":false,"quantity":3}]'),
+			(100, 3600, '{"en":"Broadcast Node"}', '[2475]', '[{"_key":2463,"isInput":true,"quantity":6},{"_key":2393,"isInput":true,"quantity":40},{"_key":2867,"isInput":false,"quantity":1}]');
+		INSERT INTO mapSolarSystems VALUES (30000142, '{"en":"Jita"}');
+		INSERT INTO mapPlanets VALUES (40009077, 30000142, 11, 1), (40009078, 30000142, 2016, 2);
+	`); err != nil {
+		t.Fatalf("Failed to create planetary fixture: %v", err)
+	}
+
+	planets := []planetary.Planet{
+		{Planet: "Temperate", Resources: []string{"Aqueous Liquids", "Microorganisms"}},
+		{Planet: "Barren", Resources: []string{"Aqueous Liquids", "Base Metals", "Microorganisms"}},
+	}
+	if _, err := planetary.BuildTables(db, planets); err != nil {
+		t.Fatalf("BuildTables failed: %v", err)
+	}
+	if err := InitializePlanetaryViews(db); err != nil {
+		t.Fatalf("InitializePlanetaryViews failed: %v", err)
+	}
+	return db
+}
+
+func TestPILevels(t *testing.T) {
+	db := planetaryDB(t)
+
+	rows, err := db.Query(`SELECT type_id, level FROM v_pi_levels ORDER BY level, type_id`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+
+	got := make(map[int64]int)
+	for rows.Next() {
+		var id int64
+		var level int
+		if err := rows.Scan(&id, &level); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		got[id] = level
+	}
+	// Broadcast Node: P3 und P1 als Input → höchster Input zählt
+	want := map[int64]int{2073: 0, 2267: 0, 2268: 0, 2389: 1, 2393: 1, 3645: 1, 9832: 2, 2463: 3, 2867: 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("v_pi_levels = %v, want %v", got, want)
+	}
+}
+
+func TestPlanetResourceViews(t *testing.T) {
+	db := planetaryDB(t)
+
+	var extractor int64
+	var class string
+	if err := db.QueryRow(`SELECT planet_class, extractor_type_id FROM v_planet_resources
+		WHERE planet_type_id = 2016 AND resource_type_id = 2267`).Scan(&class, &extractor); err != nil {
+		t.Fatalf("Query planet resources failed: %v", err)
+	}
+	if class != "Barren" || extractor != 2848 {
+		t.Errorf("Barren/Base Metals = %s, extractor %d", class, extractor)
+	}
+
+	var planets []int64
+	rows, err := db.Query(`SELECT planet_type_id FROM v_pi_schematic_planets WHERE schematic_id = 121 ORDER BY planet_type_id`)
+	if err != nil {
+		t.Fatalf("Query schematic planets failed: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		planets = append(planets, id)
+	}
+	if !reflect.DeepEqual(planets, []int64{11, 2016}) {
+		t.Errorf("Water schematic planets = %v, want [11 2016]", planets)
+	}
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM v_system_planet_resources WHERE system_id = 30000142 AND resource_type_id = 2267`).
+		Scan(&n); err != nil {
+		t.Fatalf("Query system resources failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("planets with Base Metals in Jita = %d, want 1", n)
+	}
+}
@@ -0,0 +1,395 @@
+package views
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// View ist eine registrierte SQL-View mit deklarierten Abhängigkeiten
+type View struct {
+	// Name der View (z.B. v_system_info)
+	Name string
+	// Depends: Tabellen und Views, die die View liest
+	Depends []string
+	// SQL: SELECT-Statement bzw. vollständiges CREATE VIEW … AS SELECT …
+	SQL string
+	// Source: Herkunft (Dateiname der eingebetteten SQL-Datei)
+	Source string
+	// Materialize: Kandidat für einen Snapshot als Tabelle (mv_*)
+	Materialize bool
+	// MaterializeIndices: Spalten, die im Snapshot indiziert werden
+	MaterializeIndices []string
+}
+
+// Registry verwaltet Views und erstellt sie in Abhängigkeitsreihenfolge
+type Registry struct {
+	views map[string]*View
+}
+
+// NewRegistry erstellt eine Registry aus den übergebenen Views
+func NewRegistry(views ...View) (*Registry, error) {
+	r := &Registry{views: make(map[string]*View)}
+	for _, v := range views {
+		if err := r.Register(v); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register fügt eine View hinzu
+func (r *Registry) Register(v View) error {
+	if v.Name == "" {
+		return fmt.Errorf("view without name in %s", v.Source)
+	}
+	if _, exists := r.views[v.Name]; exists {
+		return fmt.Errorf("duplicate view %s", v.Name)
+	}
+	if strings.TrimSpace(v.SQL) == "" {
+		return fmt.Errorf("view %s has no SQL", v.Name)
+	}
+	r.views[v.Name] = &v
+	return nil
+}
+
+// View liefert eine registrierte View
+func (r *Registry) View(name string) (*View, bool) {
+	v, ok := r.views[name]
+	return v, ok
+}
+
+// Names liefert alle Views in Abhängigkeitsreihenfolge
+func (r *Registry) Names() ([]string, error) {
+	all := make([]string, 0, len(r.views))
+	for name := range r.views {
+		all = append(all, name)
+	}
+	return r.order(all)
+}
+
+// Affected liefert alle Views, die direkt oder transitiv von einer der Tabellen
+// abhängen, in Abhängigkeitsreihenfolge
+func (r *Registry) Affected(tables ...string) ([]string, error) {
+	changed := make(map[string]bool)
+	for _, t := range tables {
+		changed[t] = true
+	}
+
+	// Fixpunkt: Views mit geänderter Abhängigkeit sind selbst geändert
+	for grew := true; grew; {
+		grew = false
+		for name, v := range r.views {
+			if changed[name] {
+				continue
+			}
+			for _, dep := range v.Depends {
+				if changed[dep] {
+					changed[name] = true
+					grew = true
+					break
+				}
+			}
+		}
+	}
+
+	var affected []string
+	for name := range r.views {
+		if changed[name] {
+			affected = append(affected, name)
+		}
+	}
+	return r.order(affected)
+}
+
+// order sortiert Views topologisch (Abhängigkeiten zuerst, sonst alphabetisch)
+func (r *Registry) order(names []string) ([]string, error) {
+	selected := make(map[string]bool, len(names))
+	for _, n := range names {
+		if _, ok := r.views[n]; !ok {
+			return nil, fmt.Errorf("unknown view %s", n)
+		}
+		selected[n] = true
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var ordered []string
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("view dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		case done:
+			return nil
+		}
+		state[name] = visiting
+
+		deps := append([]string(nil), r.views[name].Depends...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, isView := r.views[dep]; isView {
+				if err := visit(dep, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+
+		state[name] = done
+		if selected[name] {
+			ordered = append(ordered, name)
+		}
+		return nil
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	for _, name := range sorted {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// CreateAll erstellt alle Views neu und aktualisiert bestehende Snapshots
+func (r *Registry) CreateAll(db *sql.DB) error {
+	names, err := r.Names()
+	if err != nil {
+		return err
+	}
+	if err := r.create(db, names); err != nil {
+		return err
+	}
+	return r.refreshSnapshots(db, names)
+}
+
+// Rebuild erstellt alle von den Tabellen betroffenen Views neu, aktualisiert deren
+// Snapshots und liefert die Namen der Views
+func (r *Registry) Rebuild(db *sql.DB, tables ...string) ([]string, error) {
+	names, err := r.Affected(tables...)
+	if err != nil {
+		return nil, err
+	}
+	// Nach einem Teilimport in eine neue Datenbank fehlen ggf. abhängige Views
+	if err := r.ensureViews(db, names); err != nil {
+		return nil, err
+	}
+	if err := r.create(db, names); err != nil {
+		return nil, err
+	}
+	if err := r.refreshSnapshots(db, names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// create prüft die Abhängigkeiten und erstellt die Views (bereits sortiert)
+// per DROP VIEW IF EXISTS + CREATE VIEW in einer Transaktion.
+// Jede View wird mit SELECT … LIMIT 0 kompiliert, damit fehlerhafte Spalten sofort auffallen.
+func (r *Registry) create(db *sql.DB, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	objects, err := schemaObjects(db)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		for _, dep := range r.views[name].Depends {
+			if _, isView := r.views[dep]; !isView && !objects[dep] {
+				return fmt.Errorf("view %s depends on missing table %s", name, dep)
+			}
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, name := range names {
+		v := r.views[name]
+		if _, err := tx.Exec("DROP VIEW IF EXISTS " + name); err != nil {
+			return fmt.Errorf("failed to drop view %s: %w", name, err)
+		}
+		if _, err := tx.Exec(v.createSQL()); err != nil {
+			return fmt.Errorf("failed to create view %s (%s): %w", name, v.Source, err)
+		}
+		rows, err := tx.Query("SELECT * FROM " + name + " LIMIT 0")
+		if err != nil {
+			return fmt.Errorf("invalid view %s (%s): %w", name, v.Source, err)
+		}
+		rows.Close()
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit views: %w", err)
+	}
+	return nil
+}
+
+// ensureViews legt fehlende Views samt ihrer View-Abhängigkeiten an
+func (r *Registry) ensureViews(db *sql.DB, names []string) error {
+	objects, err := schemaObjects(db)
+	if err != nil {
+		return err
+	}
+
+	needed := make(map[string]bool)
+	var collect func(name string)
+	collect = func(name string) {
+		if needed[name] {
+			return
+		}
+		needed[name] = true
+		for _, dep := range r.views[name].Depends {
+			if _, isView := r.views[dep]; isView {
+				collect(dep)
+			}
+		}
+	}
+	for _, name := range names {
+		collect(name)
+	}
+
+	var missing []string
+	for name := range needed {
+		if !objects[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	ordered, err := r.order(missing)
+	if err != nil {
+		return err
+	}
+	return r.create(db, ordered)
+}
+
+var createViewPattern = regexp.MustCompile(`(?is)^(\s*--[^\n]*\n)*\s*CREATE\s+VIEW\s+(IF\s+NOT\s+EXISTS\s+)?([A-Za-z_][A-Za-z0-9_]*)\s+AS\s+`)
+
+// createSQL liefert das CREATE VIEW Statement (ohne IF NOT EXISTS, damit Änderungen greifen)
+func (v *View) createSQL() string {
+	body := strings.TrimSuffix(strings.TrimSpace(v.SQL), ";")
+	if m := createViewPattern.FindStringSubmatchIndex(body); m != nil {
+		body = body[m[1]:]
+	}
+	return fmt.Sprintf("CREATE VIEW %s AS\n%s", v.Name, body)
+}
+
+// schemaObjects liefert alle Tabellen und Views der Datenbank
+func schemaObjects(db *sql.DB) (map[string]bool, error) {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type IN ('table', 'view')")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schema objects: %w", err)
+	}
+	defer rows.Close()
+
+	objects := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		objects[name] = true
+	}
+	return objects, rows.Err()
+}
+
+// ParseViews zerlegt eine annotierte SQL-Datei in Views.
+// Jede View beginnt mit "-- @view <name>", gefolgt von optionalen
+// "-- @depends a, b" und "-- @materialize col, …" Zeilen und genau einem
+// CREATE VIEW Statement, das mit ";" am Zeilenende abschließt.
+func ParseViews(source, content string) ([]View, error) {
+	var (
+		views   []View
+		current *View
+		body    strings.Builder
+		lineNo  int
+	)
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		current.SQL = strings.TrimSpace(body.String())
+		m := createViewPattern.FindStringSubmatch(current.SQL)
+		if m == nil {
+			return fmt.Errorf("%s: view %s has no CREATE VIEW statement", source, current.Name)
+		}
+		if m[3] != current.Name {
+			return fmt.Errorf("%s: @view %s does not match CREATE VIEW %s", source, current.Name, m[3])
+		}
+		views = append(views, *current)
+		current = nil
+		body.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNo++
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "-- @view "):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			current = &View{Name: strings.TrimSpace(strings.TrimPrefix(trimmed, "-- @view ")), Source: source}
+
+		case strings.HasPrefix(trimmed, "-- @depends "):
+			if current == nil {
+				return nil, fmt.Errorf("%s:%d: @depends outside of @view", source, lineNo)
+			}
+			for _, dep := range strings.Split(strings.TrimPrefix(trimmed, "-- @depends "), ",") {
+				if dep = strings.TrimSpace(dep); dep != "" {
+					current.Depends = append(current.Depends, dep)
+				}
+			}
+
+		case strings.HasPrefix(trimmed, "-- @materialize"):
+			if current == nil {
+				return nil, fmt.Errorf("%s:%d: @materialize outside of @view", source, lineNo)
+			}
+			current.Materialize = true
+			for _, col := range strings.Split(strings.TrimPrefix(trimmed, "-- @materialize"), ",") {
+				if col = strings.TrimSpace(col); col != "" {
+					current.MaterializeIndices = append(current.MaterializeIndices, col)
+				}
+			}
+
+		case current != nil:
+			body.WriteString(line)
+			body.WriteString("\n")
+			if strings.HasSuffix(trimmed, ";") {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+
+		case trimmed != "" && !strings.HasPrefix(trimmed, "--"):
+			return nil, fmt.Errorf("%s:%d: SQL outside of @view block", source, lineNo)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
@@ -0,0 +1,210 @@
+package views
+
+import (
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "views.db"))
+	if err != nil {
+		t.Fatalf("Failed to open DB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec(`
+		CREATE TABLE systems (_key INTEGER PRIMARY KEY, name TEXT, sec REAL);
+		CREATE TABLE gates (_key INTEGER PRIMARY KEY, systemID INTEGER);
+		INSERT INTO systems VALUES (1, 'Jita', 0.9), (2, 'Tama', 0.3);
+		INSERT INTO gates VALUES (10, 1), (11, 2);
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	return db
+}
+
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r, err := NewRegistry(
+		View{Name: "v_high", Depends: []string{"v_systems"}, SQL: "SELECT * FROM v_systems WHERE sec >= 0.5"},
+		View{Name: "v_systems", Depends: []string{"systems"}, SQL: "SELECT _key, name, sec FROM systems"},
+		View{Name: "v_gates", Depends: []string{"gates", "v_systems"}, SQL: "SELECT g._key, s.name FROM gates g JOIN v_systems s ON s._key = g.systemID"},
+	)
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	return r
+}
+
+func TestParseViews(t *testing.T) {
+	content := `-- header
+
+-- @view v_a
+-- @depends systems, gates
+CREATE VIEW v_a AS
+SELECT 1;
+
+-- description of v_b
+-- @view v_b
+-- @depends v_a
+CREATE VIEW IF NOT EXISTS v_b AS
+-- inline comment
+SELECT * FROM v_a;
+`
+	views, err := ParseViews("test.sql", content)
+	if err != nil {
+		t.Fatalf("ParseViews failed: %v", err)
+	}
+	if len(views) != 2 {
+		t.Fatalf("views = %d, want 2", len(views))
+	}
+	if !reflect.DeepEqual(views[0].Depends, []string{"systems", "gates"}) {
+		t.Errorf("v_a depends = %v", views[0].Depends)
+	}
+	if got := views[1].createSQL(); !strings.HasPrefix(got, "CREATE VIEW v_b AS\n-- inline comment") {
+		t.Errorf("createSQL = %q", got)
+	}
+
+	if _, err := ParseViews("bad.sql", "-- @view v_x\nCREATE VIEW v_y AS SELECT 1;\n"); err == nil {
+		t.Error("expected error for mismatched view name")
+	}
+	if _, err := ParseViews("bad.sql", "CREATE VIEW v_y AS SELECT 1;\n"); err == nil {
+		t.Error("expected error for SQL outside of @view block")
+	}
+}
+
+func TestRegistry_Order(t *testing.T) {
+	names, err := testRegistry(t).Names()
+	if err != nil {
+		t.Fatalf("Names failed: %v", err)
+	}
+	want := []string{"v_systems", "v_gates", "v_high"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Names = %v, want %v", names, want)
+	}
+}
+
+func TestRegistry_Cycle(t *testing.T) {
+	r, err := NewRegistry(
+		View{Name: "v_a", Depends: []string{"v_b"}, SQL: "SELECT 1"},
+		View{Name: "v_b", Depends: []string{"v_a"}, SQL: "SELECT 1"},
+	)
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	if _, err := r.Names(); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("err = %v, want cycle error", err)
+	}
+}
+
+func TestRegistry_Affected(t *testing.T) {
+	r := testRegistry(t)
+
+	tests := []struct {
+		tables []string
+		want   []string
+	}{
+		{[]string{"gates"}, []string{"v_gates"}},
+		{[]string{"systems"}, []string{"v_systems", "v_gates", "v_high"}},
+		{[]string{"types"}, nil},
+	}
+	for _, tt := range tests {
+		got, err := r.Affected(tt.tables...)
+		if err != nil {
+			t.Fatalf("Affected(%v) failed: %v", tt.tables, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Affected(%v) = %v, want %v", tt.tables, got, tt.want)
+		}
+	}
+}
+
+func TestRegistry_RebuildReplacesDefinition(t *testing.T) {
+	db := openTestDB(t)
+	r := testRegistry(t)
+
+	if err := r.CreateAll(db); err != nil {
+		t.Fatalf("CreateAll failed: %v", err)
+	}
+
+	// Geänderte Definition muss nach Rebuild greifen
+	r.views["v_systems"].SQL = "SELECT _key, upper(name) AS name, sec FROM systems"
+	rebuilt, err := r.Rebuild(db, "systems")
+	if err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	if len(rebuilt) != 3 {
+		t.Errorf("rebuilt = %v, want 3 views", rebuilt)
+	}
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM v_high").Scan(&name); err != nil || name != "JITA" {
+		t.Errorf("v_high name = %q, %v; want JITA", name, err)
+	}
+}
+
+func TestRegistry_RebuildCreatesMissingDependencies(t *testing.T) {
+	db := openTestDB(t)
+	r := testRegistry(t)
+
+	// Nur gates betroffen, v_systems existiert noch nicht
+	rebuilt, err := r.Rebuild(db, "gates")
+	if err != nil {
+		t.Fatalf("Rebuild failed: %v", err)
+	}
+	if !reflect.DeepEqual(rebuilt, []string{"v_gates"}) {
+		t.Errorf("rebuilt = %v, want [v_gates]", rebuilt)
+	}
+
+	var name string
+	if err := db.QueryRow("SELECT name FROM v_gates WHERE _key = 10").Scan(&name); err != nil || name != "Jita" {
+		t.Errorf("v_gates name = %q, %v; want Jita", name, err)
+	}
+}
+
+func TestRegistry_CreateFailsHard(t *testing.T) {
+	db := openTestDB(t)
+
+	missing, _ := NewRegistry(View{Name: "v_x", Depends: []string{"nope"}, SQL: "SELECT 1"})
+	if err := missing.CreateAll(db); err == nil || !strings.Contains(err.Error(), "missing table nope") {
+		t.Errorf("err = %v, want missing table error", err)
+	}
+
+	badColumn, _ := NewRegistry(View{Name: "v_x", Depends: []string{"systems"}, SQL: "SELECT regionID FROM systems"})
+	if err := badColumn.CreateAll(db); err == nil {
+		t.Error("expected error for unknown column")
+	}
+
+	// Fehlgeschlagene Transaktion hinterlässt keine View
+	var count int
+	db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'view'").Scan(&count)
+	if count != 0 {
+		t.Errorf("views after failure = %d, want 0", count)
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	r, err := DefaultRegistry()
+	if err != nil {
+		t.Fatalf("DefaultRegistry failed: %v", err)
+	}
+
+	info, ok := r.View("v_system_info")
+	if !ok || info.Source != NavigationSource {
+		t.Fatalf("v_system_info missing or wrong source: %+v", info)
+	}
+
+	affected, err := r.Affected("mapRegions")
+	if err != nil {
+		t.Fatalf("Affected failed: %v", err)
+	}
+	if len(affected) == 0 || affected[0] != "v_system_info" {
+		t.Errorf("Affected(mapRegions) = %v, want v_system_info first", affected)
+	}
+}
@@ -0,0 +1,112 @@
+-- EVE Reprocessing - SQL Views
+-- These views flatten typeMaterials and classify ore/ice (incl. compressed variants) for yield calculations
+
+-- =============================================================================
+-- v_reprocessing_materials: Reprocessing output of every type per portion (100% yield)
+-- Output for n units: floor(n / portion_size) * quantity * yield, for yield use pkg/evedb/reprocessing
+-- Example: SELECT material_name, quantity FROM v_reprocessing_materials WHERE type_id = 1230
+-- =============================================================================
+-- @view v_reprocessing_materials
+-- @depends typeMaterials, types, groups
+-- @materialize type_id, material_type_id
+CREATE VIEW v_reprocessing_materials AS
+SELECT
+    tm._key as type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as type_name,
+    t.groupID as group_id,
+    g.categoryID as category_id,
+    MAX(COALESCE(t.portionSize, 1), 1) as portion_size,
+    CAST(json_extract(m.value, '$.materialTypeID') AS INTEGER) as material_type_id,
+    COALESCE(json_extract(mt.name, '$.en'), json_extract(mt.name, '$.de')) as material_name,
+    CAST(json_extract(m.value, '$.quantity') AS INTEGER) as quantity
+FROM typeMaterials tm, json_each(tm.materials) m
+LEFT JOIN types t ON t._key = tm._key
+LEFT JOIN groups g ON g._key = t.groupID
+LEFT JOIN types mt ON mt._key = json_extract(m.value, '$.materialTypeID');
+
+-- =============================================================================
+-- v_asteroid_types: Reprocessable ore and ice (category Asteroid) incl. compressed variants
+-- kind: 'ice' (group Ice) or 'ore'; base_type_id is the uncompressed type of the same group;
+-- skill_type_id is the ore-specific processing skill (dogma attribute reprocessingSkillType)
+-- =============================================================================
+-- @view v_asteroid_types
+-- @depends types, groups, typeMaterials, typeDogma, dogmaAttributes
+CREATE VIEW v_asteroid_types AS
+WITH asteroids AS (
+    SELECT
+        t._key as type_id,
+        COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as type_name,
+        t.groupID as group_id,
+        COALESCE(json_extract(g.name, '$.en'), json_extract(g.name, '$.de')) as group_name,
+        MAX(COALESCE(t.portionSize, 1), 1) as portion_size,
+        CAST(t.volume AS REAL) as volume
+    FROM types t
+    JOIN groups g ON g._key = t.groupID
+    WHERE g.categoryID = 25
+      AND t.published = 1
+      AND t._key IN (SELECT _key FROM typeMaterials)
+),
+skills AS (
+    SELECT d._key as type_id, CAST(json_extract(a.value, '$.value') AS INTEGER) as skill_type_id
+    FROM typeDogma d
+    JOIN json_each(d.dogmaAttributes) a
+    JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+    WHERE da.name = 'reprocessingSkillType'
+)
+SELECT
+    a.type_id,
+    a.type_name,
+    CASE WHEN json_extract(g.name, '$.en') = 'Ice' THEN 'ice' ELSE 'ore' END as kind,
+    a.group_id,
+    a.group_name,
+    a.portion_size,
+    a.volume,
+    CASE WHEN a.type_name LIKE 'Compressed %' THEN 1 ELSE 0 END as compressed,
+    COALESCE(b.type_id, a.type_id) as base_type_id,
+    s.skill_type_id,
+    COALESCE(json_extract(st.name, '$.en'), json_extract(st.name, '$.de')) as skill_name
+FROM asteroids a
+JOIN groups g ON g._key = a.group_id
+LEFT JOIN asteroids b ON a.type_name LIKE 'Compressed %'
+    AND b.group_id = a.group_id
+    AND b.type_name = substr(a.type_name, length('Compressed ') + 1)
+LEFT JOIN skills s ON s.type_id = a.type_id
+LEFT JOIN types st ON st._key = s.skill_type_id;
+
+-- =============================================================================
+-- v_ore_types: Ores (incl. moon ores and compressed variants)
+-- =============================================================================
+-- @view v_ore_types
+-- @depends v_asteroid_types
+CREATE VIEW v_ore_types AS
+SELECT type_id, type_name, group_id, group_name, portion_size, volume, compressed, base_type_id, skill_type_id, skill_name
+FROM v_asteroid_types
+WHERE kind = 'ore';
+
+-- =============================================================================
+-- v_ice_types: Ice (incl. compressed variants)
+-- =============================================================================
+-- @view v_ice_types
+-- @depends v_asteroid_types
+CREATE VIEW v_ice_types AS
+SELECT type_id, type_name, group_id, group_name, portion_size, volume, compressed, base_type_id, skill_type_id, skill_name
+FROM v_asteroid_types
+WHERE kind = 'ice';
+
+-- =============================================================================
+-- v_station_reprocessing: Reprocessing base yield and take of every NPC station
+-- reprocessing_efficiency is the station base yield (0.5 = 50%)
+-- =============================================================================
+-- @view v_station_reprocessing
+-- @depends npcStations, mapSolarSystems
+CREATE VIEW v_station_reprocessing AS
+SELECT
+    st._key as station_id,
+    st.solarSystemID as solar_system_id,
+    COALESCE(json_extract(s.name, '$.en'), json_extract(s.name, '$.de')) as system_name,
+    s.securityStatus as security,
+    CAST(st.reprocessingEfficiency AS REAL) as reprocessing_efficiency,
+    CAST(st.reprocessingStationsTake AS REAL) as stations_take
+FROM npcStations st
+LEFT JOIN mapSolarSystems s ON s._key = st.solarSystemID
+WHERE st.reprocessingEfficiency > 0;
@@ -0,0 +1,124 @@
+package views
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+// reprocessingDB legt Erz, komprimiertes Erz, Eis, ein Modul und zwei Stationen an
+func reprocessingDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTestDB(t)
+
+	if _, err := db.Exec(`
+		CREATE TABLE groups (_key INTEGER PRIMARY KEY, name TEXT, categoryID INTEGER);
+		CREATE TABLE types (_key INTEGER PRIMARY KEY, name TEXT, groupID INTEGER, portionSize INTEGER, volume REAL, published INTEGER);
+		CREATE TABLE typeMaterials (_key INTEGER PRIMARY KEY, materials TEXT);
+		CREATE TABLE typeDogma (_key INTEGER PRIMARY KEY, dogmaAttributes TEXT);
+		CREATE TABLE dogmaAttributes (_key INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE npcStations (_key INTEGER PRIMARY KEY, solarSystemID INTEGER, reprocessingEfficiency REAL, reprocessingStationsTake REAL);
+		CREATE TABLE mapSolarSystems (_key INTEGER PRIMARY KEY, name TEXT, securityStatus REAL);
+
+		INSERT INTO groups VALUES (462, '{"en":"Veldspar"}', 25), (465, '{"en":"Ice"}', 25), (18, '{"en":"Mineral"}', 4),
+			(55, '{"en":"Projectile Weapon"}', 7), (16, '{"en":"Skill"}', 16);
+		INSERT INTO types VALUES
+			(1230, '{"en":"Veldspar"}', 462, 100, 0.1, 1),
+			(28432, '{"en":"Compressed Veldspar"}', 462, 1, 0.001, 1),
+			(16264, '{"en":"Blue Ice"}', 465, 1, 1000, 1),
+			(28433, '{"en":"Compressed Blue Ice"}', 465, 1, 100, 1),
+			(34, '{"en":"Tritanium"}', 18, 1, 0.01, 1),
+			(486, '{"en":"125mm Gatling AutoCannon I"}', 55, 1, 5, 1),
+			(12180, '{"en":"Veldspar Processing"}', 16, 1, 0.01, 1),
+			(18025, '{"en":"Ice Processing"}', 16, 1, 0.01, 1);
+		INSERT INTO typeMaterials VALUES
+			(1230, '[{"materialTypeID":34,"quantity":400}]'),
+			(28432, '[{"materialTypeID":34,"quantity":400}]'),
+			(16264, '[{"materialTypeID":16272,"quantity":69}]'),
+			(28433, '[{"materialTypeID":16272,"quantity":69}]'),
+			(486, '[{"materialTypeID":34,"quantity":250}]');
+		INSERT INTO dogmaAttributes VALUES (790, 'reprocessingSkillType');
+		INSERT INTO typeDogma VALUES
+			(1230, '[{"attributeID":790,"value":12180}]'),
+			(28432, '[{"attributeID":790,"value":12180}]'),
+			(16264, '[{"attributeID":790,"value":18025}]'),
+			(28433, '[{"attributeID":790,"value":18025}]');
+		INSERT INTO mapSolarSystems VALUES (30000142, '{"en":"Jita"}', 0.946);
+		INSERT INTO npcStations VALUES (60003760, 30000142, 0.5, 0.05), (60000004, 30000142, 0, 0);
+	`); err != nil {
+		t.Fatalf("Failed to create reprocessing fixture: %v", err)
+	}
+
+	if err := InitializeReprocessingViews(db); err != nil {
+		t.Fatalf("InitializeReprocessingViews failed: %v", err)
+	}
+	return db
+}
+
+func TestAsteroidTypes(t *testing.T) {
+	db := reprocessingDB(t)
+
+	type row struct {
+		id         int64
+		kind       string
+		compressed bool
+		base       int64
+		skill      int64
+		portion    int64
+	}
+	rows, err := db.Query(`SELECT type_id, kind, compressed, base_type_id, skill_type_id, portion_size FROM v_asteroid_types ORDER BY type_id`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+
+	var got []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.kind, &r.compressed, &r.base, &r.skill, &r.portion); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		got = append(got, r)
+	}
+	want := []row{
+		{1230, "ore", false, 1230, 12180, 100},
+		{16264, "ice", false, 16264, 18025, 1},
+		{28432, "ore", true, 1230, 12180, 1},
+		{28433, "ice", true, 16264, 18025, 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("v_asteroid_types =\n%v\nwant\n%v", got, want)
+	}
+
+	var ores, ice int
+	if err := db.QueryRow(`SELECT (SELECT COUNT(*) FROM v_ore_types), (SELECT COUNT(*) FROM v_ice_types)`).Scan(&ores, &ice); err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if ores != 2 || ice != 2 {
+		t.Errorf("ores = %d, ice = %d, want 2 each", ores, ice)
+	}
+}
+
+func TestReprocessingMaterials(t *testing.T) {
+	db := reprocessingDB(t)
+
+	var portion, quantity int64
+	var name string
+	if err := db.QueryRow(`SELECT portion_size, material_name, quantity FROM v_reprocessing_materials WHERE type_id = 1230`).
+		Scan(&portion, &name, &quantity); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if portion != 100 || name != "Tritanium" || quantity != 400 {
+		t.Errorf("Veldspar = portion %d, %s × %d", portion, name, quantity)
+	}
+
+	var stations int
+	var efficiency, take float64
+	if err := db.QueryRow(`SELECT COUNT(*), MAX(reprocessing_efficiency), MAX(stations_take) FROM v_station_reprocessing`).
+		Scan(&stations, &efficiency, &take); err != nil {
+		t.Fatalf("Query stations failed: %v", err)
+	}
+	if stations != 1 || efficiency != 0.5 || take != 0.05 {
+		t.Errorf("v_station_reprocessing = %d stations, %v, %v", stations, efficiency, take)
+	}
+}
@@ -0,0 +1,147 @@
+-- EVE SDE Skill Views
+-- Skill-Voraussetzungen aus typeDogma (requiredSkill1..6 / requiredSkill1Level..6Level),
+-- Rang und Trainingsattribute der Skills sowie der rekursive Voraussetzungsbaum mit Skillpunkten
+
+-- =============================================================================
+-- v_skill_requirements: Direct skill requirements of every type
+-- slot is the N of requiredSkillN; types without a level attribute require level 0
+-- =============================================================================
+-- @view v_skill_requirements
+-- @depends types, typeDogma, dogmaAttributes
+CREATE VIEW v_skill_requirements AS
+WITH attrs AS (
+    SELECT
+        d._key as type_id,
+        da.name as attribute_name,
+        CAST(json_extract(a.value, '$.value') AS INTEGER) as value
+    FROM typeDogma d
+    JOIN json_each(d.dogmaAttributes) a
+    JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+    WHERE da.name GLOB 'requiredSkill[1-6]' OR da.name GLOB 'requiredSkill[1-6]Level'
+)
+SELECT
+    s.type_id,
+    CAST(substr(s.attribute_name, 14, 1) AS INTEGER) as slot,
+    s.value as skill_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as skill_name,
+    COALESCE(l.value, 0) as level
+FROM attrs s
+LEFT JOIN attrs l ON l.type_id = s.type_id AND l.attribute_name = s.attribute_name || 'Level'
+LEFT JOIN types t ON t._key = s.value
+WHERE s.attribute_name GLOB 'requiredSkill[1-6]'
+AND s.value > 0;
+
+-- =============================================================================
+-- v_skills: Skills with rank (skillTimeConstant) and training attributes
+-- primary/secondary_attribute_id are dogma attribute IDs (164-168); names come from
+-- characterAttributes (matched by English name)
+-- =============================================================================
+-- @view v_skills
+-- @depends types, groups, typeDogma, dogmaAttributes, characterAttributes
+CREATE VIEW v_skills AS
+WITH attrs AS (
+    SELECT
+        d._key as type_id,
+        da.name as attribute_name,
+        CAST(json_extract(a.value, '$.value') AS REAL) as value
+    FROM typeDogma d
+    JOIN json_each(d.dogmaAttributes) a
+    JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+    WHERE da.name IN ('skillTimeConstant', 'primaryAttribute', 'secondaryAttribute')
+),
+skills AS (
+    SELECT
+        type_id,
+        MAX(CASE WHEN attribute_name = 'skillTimeConstant' THEN value END) as rank,
+        CAST(MAX(CASE WHEN attribute_name = 'primaryAttribute' THEN value END) AS INTEGER) as primary_attribute_id,
+        CAST(MAX(CASE WHEN attribute_name = 'secondaryAttribute' THEN value END) AS INTEGER) as secondary_attribute_id
+    FROM attrs
+    GROUP BY type_id
+)
+SELECT
+    t._key as skill_type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as skill_name,
+    g._key as group_id,
+    COALESCE(json_extract(g.name, '$.en'), json_extract(g.name, '$.de')) as group_name,
+    COALESCE(t.published, 0) as published,
+    COALESCE(s.rank, 1) as rank,
+    s.primary_attribute_id,
+    COALESCE(json_extract(pc.name, '$.en'), pa.name) as primary_attribute,
+    s.secondary_attribute_id,
+    COALESCE(json_extract(sc.name, '$.en'), sa.name) as secondary_attribute
+FROM types t
+JOIN groups g ON g._key = t.groupID
+LEFT JOIN skills s ON s.type_id = t._key
+LEFT JOIN dogmaAttributes pa ON pa._key = s.primary_attribute_id
+LEFT JOIN characterAttributes pc ON lower(json_extract(pc.name, '$.en')) = lower(pa.name)
+LEFT JOIN dogmaAttributes sa ON sa._key = s.secondary_attribute_id
+LEFT JOIN characterAttributes sc ON lower(json_extract(sc.name, '$.en')) = lower(sa.name)
+WHERE g.categoryID = 16;
+
+-- =============================================================================
+-- v_skill_prerequisite_tree: Every skill needed for a type, recursively
+-- Each skill appears once with the highest required level and its shallowest depth (1 = direct);
+-- sp is the cumulative skill point total of that level: ceil(250 × rank × √32^(level-1))
+-- Training minutes: sp / (primary + secondary / 2) with the character's attribute values
+-- =============================================================================
+-- @view v_skill_prerequisite_tree
+-- @depends v_skill_requirements, v_skills
+-- @materialize type_id, skill_type_id
+CREATE VIEW v_skill_prerequisite_tree AS
+WITH RECURSIVE tree(type_id, skill_type_id, level, depth) AS (
+    SELECT type_id, skill_type_id, level, 1 FROM v_skill_requirements
+    UNION
+    SELECT tr.type_id, r.skill_type_id, r.level, tr.depth + 1
+    FROM tree tr
+    JOIN v_skill_requirements r ON r.type_id = tr.skill_type_id
+    WHERE tr.depth < 16
+),
+needed AS (
+    SELECT type_id, skill_type_id, MAX(level) as level, MIN(depth) as depth
+    FROM tree
+    GROUP BY type_id, skill_type_id
+),
+points AS (
+    SELECT
+        n.*,
+        COALESCE(s.rank, 1) * CASE n.level
+            WHEN 1 THEN 250.0
+            WHEN 2 THEN 1414.2135623730951
+            WHEN 3 THEN 8000.0
+            WHEN 4 THEN 45254.833995939045
+            WHEN 5 THEN 256000.0
+            ELSE 0.0
+        END as raw_sp
+    FROM needed n
+    LEFT JOIN v_skills s ON s.skill_type_id = n.skill_type_id
+)
+SELECT
+    p.type_id,
+    p.skill_type_id,
+    s.skill_name,
+    p.level,
+    p.depth,
+    COALESCE(s.rank, 1) as rank,
+    CAST(p.raw_sp AS INTEGER) + (p.raw_sp > CAST(p.raw_sp AS INTEGER)) as sp,
+    s.primary_attribute_id,
+    s.primary_attribute,
+    s.secondary_attribute_id,
+    s.secondary_attribute
+FROM points p
+LEFT JOIN v_skills s ON s.skill_type_id = p.skill_type_id;
+
+-- =============================================================================
+-- v_type_skill_points: Total skill points of all prerequisites of a type
+-- =============================================================================
+-- @view v_type_skill_points
+-- @depends v_skill_prerequisite_tree, types
+CREATE VIEW v_type_skill_points AS
+SELECT
+    p.type_id,
+    COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as type_name,
+    COUNT(*) as skill_count,
+    MAX(p.depth) as max_depth,
+    SUM(p.sp) as total_sp
+FROM v_skill_prerequisite_tree p
+LEFT JOIN types t ON t._key = p.type_id
+GROUP BY p.type_id, type_name;
@@ -0,0 +1,120 @@
+package views
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+// skillsDB legt eine kleine Skill-Hierarchie an:
+// Modul 561 → Small Hybrid Turret IV → Gunnery I, Surgical Strike II (Rang 4) → Gunnery IV;
+// Merlin → Caldari Frigate I (Rang 2) → Spaceship Command I
+func skillsDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTestDB(t)
+
+	if _, err := db.Exec(`
+		CREATE TABLE types (_key INTEGER PRIMARY KEY, name TEXT, groupID INTEGER, published INTEGER);
+		CREATE TABLE groups (_key INTEGER PRIMARY KEY, name TEXT, categoryID INTEGER);
+		CREATE TABLE typeDogma (_key INTEGER PRIMARY KEY, dogmaAttributes TEXT, dogmaEffects TEXT);
+		CREATE TABLE dogmaAttributes (_key INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE characterAttributes (_key INTEGER PRIMARY KEY, name TEXT, shortDescription TEXT);
+
+		INSERT INTO groups VALUES (255, '{"en":"Gunnery"}', 16), (257, '{"en":"Spaceship Command"}', 16),
+			(25, '{"en":"Frigate"}', 6), (74, '{"en":"Hybrid Weapon"}', 7);
+		INSERT INTO types VALUES
+			(3300, '{"en":"Gunnery"}', 255, 1), (3301, '{"en":"Small Hybrid Turret"}', 255, 1),
+			(3315, '{"en":"Surgical Strike"}', 255, 1), (3327, '{"en":"Spaceship Command"}', 257, 1),
+			(3330, '{"en":"Caldari Frigate"}', 257, 1), (603, '{"en":"Merlin"}', 25, 1),
+			(561, '{"en":"75mm Gatling Rail I"}', 74, 1);
+		INSERT INTO dogmaAttributes VALUES
+			(182, 'requiredSkill1'), (183, 'requiredSkill2'), (277, 'requiredSkill1Level'), (278, 'requiredSkill2Level'),
+			(275, 'skillTimeConstant'), (180, 'primaryAttribute'), (181, 'secondaryAttribute'),
+			(164, 'charisma'), (165, 'intelligence'), (166, 'memory'), (167, 'perception'), (168, 'willpower');
+		INSERT INTO characterAttributes VALUES
+			(1, '{"en":"Perception"}', 'PER'), (2, '{"en":"Memory"}', 'MEM'), (3, '{"en":"Willpower"}', 'WIL'),
+			(4, '{"en":"Intelligence"}', 'INT'), (5, '{"en":"Charisma"}', 'CHA');
+		INSERT INTO typeDogma VALUES
+			(3300, '[{"attributeID":275,"value":1},{"attributeID":180,"value":167},{"attributeID":181,"value":168}]', '[]'),
+			(3301, '[{"attributeID":275,"value":1},{"attributeID":180,"value":167},{"attributeID":181,"value":168},
+				{"attributeID":182,"value":3300},{"attributeID":277,"value":1}]', '[]'),
+			(3315, '[{"attributeID":275,"value":4},{"attributeID":180,"value":167},{"attributeID":181,"value":168},
+				{"attributeID":182,"value":3300},{"attributeID":277,"value":4}]', '[]'),
+			(3327, '[{"attributeID":275,"value":1},{"attributeID":180,"value":167},{"attributeID":181,"value":168}]', '[]'),
+			(3330, '[{"attributeID":275,"value":2},{"attributeID":180,"value":167},{"attributeID":181,"value":168},
+				{"attributeID":182,"value":3327},{"attributeID":277,"value":1}]', '[]'),
+			(603, '[{"attributeID":182,"value":3330},{"attributeID":277,"value":1}]', '[]'),
+			(561, '[{"attributeID":182,"value":3301},{"attributeID":277,"value":4},
+				{"attributeID":183,"value":3315},{"attributeID":278,"value":2}]', '[]');
+	`); err != nil {
+		t.Fatalf("Failed to create skills fixture: %v", err)
+	}
+	if err := InitializeSkillsViews(db); err != nil {
+		t.Fatalf("InitializeSkillsViews failed: %v", err)
+	}
+	return db
+}
+
+func TestSkills(t *testing.T) {
+	db := skillsDB(t)
+
+	var rank float64
+	var primary, secondary string
+	if err := db.QueryRow(`SELECT rank, primary_attribute, secondary_attribute FROM v_skills WHERE skill_type_id = 3315`).
+		Scan(&rank, &primary, &secondary); err != nil {
+		t.Fatalf("Query v_skills failed: %v", err)
+	}
+	if rank != 4 || primary != "Perception" || secondary != "Willpower" {
+		t.Errorf("Surgical Strike = rank %v, %s/%s", rank, primary, secondary)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM v_skills`).Scan(&count); err != nil || count != 5 {
+		t.Errorf("v_skills count = %d (%v), want 5", count, err)
+	}
+}
+
+func TestSkillPrerequisiteTree(t *testing.T) {
+	db := skillsDB(t)
+
+	type row struct {
+		SkillTypeID int64
+		Level       int
+		Depth       int
+		SP          int64
+	}
+	rows, err := db.Query(`SELECT skill_type_id, level, depth, sp FROM v_skill_prerequisite_tree
+		WHERE type_id = 561 ORDER BY skill_type_id`)
+	if err != nil {
+		t.Fatalf("Query tree failed: %v", err)
+	}
+	defer rows.Close()
+	var got []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.SkillTypeID, &r.Level, &r.Depth, &r.SP); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		got = append(got, r)
+	}
+
+	// Gunnery: Stufe 1 über Small Hybrid Turret, Stufe 4 über Surgical Strike → IV
+	want := []row{
+		{3300, 4, 2, 45255},
+		{3301, 4, 1, 45255},
+		{3315, 2, 1, 5657},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tree = %+v, want %+v", got, want)
+	}
+
+	var total int64
+	var skills int
+	if err := db.QueryRow(`SELECT skill_count, total_sp FROM v_type_skill_points WHERE type_id = 603`).
+		Scan(&skills, &total); err != nil {
+		t.Fatalf("Query v_type_skill_points failed: %v", err)
+	}
+	if skills != 2 || total != 750 {
+		t.Errorf("Merlin = %d skills, %d SP; want 2, 750", skills, total)
+	}
+}
@@ -0,0 +1,201 @@
+-- EVE Wormhole Space - SQL Views
+-- These views interpret wormholeClassID, system effects and wormhole types (J-space, Thera, drifter, Pochven)
+
+-- =============================================================================
+-- v_wormhole_classes: Reference list of wormhole class IDs
+-- class_kind: wormhole (C1-C6), k-space (target classes of exits),
+-- thera, shattered, drifter, pochven
+-- =============================================================================
+-- @view v_wormhole_classes
+CREATE VIEW v_wormhole_classes AS
+WITH classes(class_id, class_name, class_kind) AS (
+    VALUES
+        (1, 'C1', 'wormhole'),
+        (2, 'C2', 'wormhole'),
+        (3, 'C3', 'wormhole'),
+        (4, 'C4', 'wormhole'),
+        (5, 'C5', 'wormhole'),
+        (6, 'C6', 'wormhole'),
+        (7, 'High-Sec', 'k-space'),
+        (8, 'Low-Sec', 'k-space'),
+        (9, 'Null-Sec', 'k-space'),
+        (12, 'Thera', 'thera'),
+        (13, 'Shattered', 'shattered'),
+        (14, 'Sentinel', 'drifter'),
+        (15, 'Barbican', 'drifter'),
+        (16, 'Vidette', 'drifter'),
+        (17, 'Conflux', 'drifter'),
+        (18, 'Redoubt', 'drifter'),
+        (25, 'Pochven', 'pochven')
+)
+SELECT class_id, class_name, class_kind FROM classes;
+
+-- =============================================================================
+-- v_wormhole_systems: Wormhole class of every J-space and Pochven system
+-- wormholeClassID is inherited system -> constellation -> region;
+-- Pochven systems without class fall back to class 25
+-- =============================================================================
+-- @view v_wormhole_systems
+-- @depends mapSolarSystems, mapConstellations, mapRegions, v_wormhole_classes
+-- @materialize system_id, wormhole_class_id, region_id
+CREATE VIEW v_wormhole_systems AS
+WITH systems AS (
+    SELECT
+        sys._key as system_id,
+        COALESCE(json_extract(sys.name, '$.en'), json_extract(sys.name, '$.de')) as system_name,
+        sys.constellationID as constellation_id,
+        COALESCE(json_extract(c.name, '$.en'), json_extract(c.name, '$.de')) as constellation_name,
+        sys.regionID as region_id,
+        COALESCE(json_extract(r.name, '$.en'), json_extract(r.name, '$.de')) as region_name,
+        sys.securityZone as security_zone,
+        COALESCE(
+            NULLIF(sys.wormholeClassID, 0),
+            NULLIF(c.wormholeClassID, 0),
+            NULLIF(r.wormholeClassID, 0),
+            CASE WHEN sys.securityZone = 'Pochven' THEN 25 END
+        ) as wormhole_class_id
+    FROM mapSolarSystems sys
+    LEFT JOIN mapConstellations c ON sys.constellationID = c._key
+    LEFT JOIN mapRegions r ON sys.regionID = r._key
+    WHERE sys.securityZone IN ('Wormhole', 'Pochven')
+)
+SELECT
+    s.system_id,
+    s.system_name,
+    s.constellation_id,
+    s.constellation_name,
+    s.region_id,
+    s.region_name,
+    s.security_zone,
+    s.wormhole_class_id,
+    wc.class_name,
+    wc.class_kind
+FROM systems s
+LEFT JOIN v_wormhole_classes wc ON wc.class_id = s.wormhole_class_id;
+
+-- =============================================================================
+-- v_wormhole_system_effects: System effect of wormhole systems
+-- The effect is derived from the star type (Black Hole, Magnetar, Pulsar,
+-- Red Giant, Wolf-Rayet Star, Cataclysmic Variable); systems with a normal
+-- star have effect NULL. beacon_type_id is the matching
+-- "<effect> Effect Beacon Class <n>" type for C1-C6 (see v_wormhole_effect_beacons)
+-- =============================================================================
+-- @view v_wormhole_system_effects
+-- @depends v_wormhole_systems, mapStars, types
+CREATE VIEW v_wormhole_system_effects AS
+WITH effects(effect_name) AS (
+    VALUES ('Black Hole'), ('Magnetar'), ('Pulsar'), ('Red Giant'), ('Wolf-Rayet Star'), ('Cataclysmic Variable')
+),
+stars AS (
+    SELECT
+        st.solarSystemID as system_id,
+        st.typeID as star_type_id,
+        COALESCE(json_extract(t.name, '$.en'), json_extract(t.name, '$.de')) as star_type_name
+    FROM mapStars st
+    LEFT JOIN types t ON t._key = st.typeID
+)
+SELECT
+    w.system_id,
+    w.system_name,
+    w.wormhole_class_id,
+    w.class_name,
+    st.star_type_id,
+    st.star_type_name,
+    e.effect_name as effect,
+    b._key as beacon_type_id
+FROM v_wormhole_systems w
+LEFT JOIN stars st ON st.system_id = w.system_id
+LEFT JOIN effects e ON st.star_type_name = e.effect_name
+LEFT JOIN types b ON w.wormhole_class_id BETWEEN 1 AND 6
+    AND json_extract(b.name, '$.en') = e.effect_name || ' Effect Beacon Class ' || w.wormhole_class_id;
+
+-- =============================================================================
+-- v_wormhole_effect_beacons: Dogma attributes of the system effect beacons
+-- One row per beacon attribute, e.g. armor HP or signature radius multipliers
+-- =============================================================================
+-- @view v_wormhole_effect_beacons
+-- @depends types, typeDogma, dogmaAttributes
+CREATE VIEW v_wormhole_effect_beacons AS
+SELECT
+    t._key as beacon_type_id,
+    json_extract(t.name, '$.en') as beacon_name,
+    substr(json_extract(t.name, '$.en'), 1, instr(json_extract(t.name, '$.en'), ' Effect Beacon') - 1) as effect,
+    CAST(substr(json_extract(t.name, '$.en'), instr(json_extract(t.name, '$.en'), ' Class ') + 7) AS INTEGER) as class_id,
+    CAST(json_extract(a.value, '$.attributeID') AS INTEGER) as attribute_id,
+    da.name as attribute_name,
+    COALESCE(json_extract(da.displayName, '$.en'), da.name) as attribute_display_name,
+    CAST(json_extract(a.value, '$.value') AS REAL) as value
+FROM types t
+JOIN typeDogma d ON d._key = t._key
+JOIN json_each(d.dogmaAttributes) a
+LEFT JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+WHERE json_extract(t.name, '$.en') LIKE '% Effect Beacon Class %';
+
+-- =============================================================================
+-- v_wormhole_regions: Region/constellation structure of wormhole space
+-- One row per region and class with constellation, system and effect counts
+-- =============================================================================
+-- @view v_wormhole_regions
+-- @depends v_wormhole_systems
+CREATE VIEW v_wormhole_regions AS
+SELECT
+    region_id,
+    region_name,
+    wormhole_class_id,
+    class_name,
+    class_kind,
+    COUNT(DISTINCT constellation_id) as constellation_count,
+    COUNT(*) as system_count
+FROM v_wormhole_systems
+GROUP BY region_id, region_name, wormhole_class_id, class_name, class_kind;
+
+-- =============================================================================
+-- v_wormhole_types: Wormhole types (group "Wormhole") with their dogma attributes
+-- max_stable_time in minutes (lifetime), masses in kg; static_capable marks
+-- types with a target class (all except the K162 exit side)
+-- =============================================================================
+-- @view v_wormhole_types
+-- @depends types, groups, typeDogma, dogmaAttributes, v_wormhole_classes
+-- @materialize type_id, target_class_id
+CREATE VIEW v_wormhole_types AS
+WITH attrs AS (
+    SELECT
+        t._key as type_id,
+        da.name as attribute_name,
+        CAST(json_extract(a.value, '$.value') AS REAL) as value
+    FROM types t
+    JOIN groups g ON g._key = t.groupID
+    JOIN typeDogma d ON d._key = t._key
+    JOIN json_each(d.dogmaAttributes) a
+    JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+    WHERE json_extract(g.name, '$.en') = 'Wormhole'
+      AND da.name IN ('wormholeTargetSystemClass', 'wormholeMaxStableTime', 'wormholeMaxStableMass',
+                      'wormholeMaxJumpMass', 'wormholeMassRegeneration')
+),
+pivot AS (
+    SELECT
+        type_id,
+        CAST(MAX(CASE WHEN attribute_name = 'wormholeTargetSystemClass' THEN value END) AS INTEGER) as target_class_id,
+        MAX(CASE WHEN attribute_name = 'wormholeMaxStableTime' THEN value END) as max_stable_time,
+        MAX(CASE WHEN attribute_name = 'wormholeMaxStableMass' THEN value END) as max_stable_mass,
+        MAX(CASE WHEN attribute_name = 'wormholeMaxJumpMass' THEN value END) as max_jump_mass,
+        MAX(CASE WHEN attribute_name = 'wormholeMassRegeneration' THEN value END) as mass_regeneration
+    FROM attrs
+    GROUP BY type_id
+)
+SELECT
+    t._key as type_id,
+    json_extract(t.name, '$.en') as type_name,
+    TRIM(REPLACE(json_extract(t.name, '$.en'), 'Wormhole', '')) as wormhole_code,
+    p.target_class_id,
+    wc.class_name as target_class_name,
+    wc.class_kind as target_class_kind,
+    p.max_stable_time,
+    p.max_stable_time / 60.0 as lifetime_hours,
+    p.max_stable_mass,
+    p.max_jump_mass,
+    p.mass_regeneration,
+    CASE WHEN p.target_class_id > 0 AND json_extract(t.name, '$.en') NOT LIKE '%K162%' THEN 1 ELSE 0 END as static_capable
+FROM pivot p
+JOIN types t ON t._key = p.type_id
+LEFT JOIN v_wormhole_classes wc ON wc.class_id = p.target_class_id;
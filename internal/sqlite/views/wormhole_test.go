@@ -0,0 +1,196 @@
+package views
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/security"
+)
+
+// wormholeDB legt eine Fixture mit J-space, Thera, Drifter, Pochven und k-space an
+func wormholeDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTestDB(t)
+
+	if _, err := db.Exec(`
+		CREATE TABLE mapRegions (_key INTEGER PRIMARY KEY, name TEXT, wormholeClassID INTEGER);
+		CREATE TABLE mapConstellations (_key INTEGER PRIMARY KEY, name TEXT, wormholeClassID INTEGER);
+		CREATE TABLE mapSolarSystems (_key INTEGER PRIMARY KEY, name TEXT, securityStatus REAL,
+			constellationID INTEGER, regionID INTEGER, wormholeClassID INTEGER,
+			` + security.DisplayColumn + ` REAL GENERATED ALWAYS AS (` + security.DisplaySQL + `) VIRTUAL,
+			` + security.ZoneColumn + ` TEXT GENERATED ALWAYS AS (` + security.ZoneSQL + `) VIRTUAL);
+		CREATE TABLE mapStars (_key INTEGER PRIMARY KEY, solarSystemID INTEGER, typeID INTEGER);
+		CREATE TABLE groups (_key INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE types (_key INTEGER PRIMARY KEY, name TEXT, groupID INTEGER);
+		CREATE TABLE typeDogma (_key INTEGER PRIMARY KEY, dogmaAttributes TEXT);
+		CREATE TABLE dogmaAttributes (_key INTEGER PRIMARY KEY, name TEXT, displayName TEXT);
+
+		INSERT INTO mapRegions VALUES
+			(11000001, '{"en":"A-R00001"}', 1),
+			(11000003, '{"en":"A-R00003"}', 3),
+			(11000031, '{"en":"G-R00031"}', NULL),
+			(11000033, '{"en":"K-R00033"}', 14),
+			(10000070, '{"en":"Pochven"}', NULL),
+			(10000002, '{"en":"The Forge"}', 7);
+		INSERT INTO mapConstellations VALUES
+			(21000001, '{"en":"A-C00001"}', NULL),
+			(21000002, '{"en":"A-C00002"}', 2),
+			(21000003, '{"en":"C-C00003"}', NULL),
+			(21000331, '{"en":"G-C00311"}', NULL),
+			(21000333, '{"en":"K-C00333"}', NULL),
+			(20000788, '{"en":"Krai Perun"}', NULL),
+			(20000020, '{"en":"Kimotoro"}', NULL);
+		INSERT INTO mapSolarSystems (_key, name, securityStatus, constellationID, regionID, wormholeClassID) VALUES
+			(31000001, '{"en":"J100001"}', -0.99, 21000001, 11000001, NULL),
+			(31000002, '{"en":"J100002"}', -0.99, 21000002, 11000001, NULL),
+			(31000003, '{"en":"J100003"}', -0.99, 21000003, 11000003, 0),
+			(31000005, '{"en":"Thera"}', -0.99, 21000331, 11000031, 12),
+			(31001000, '{"en":"J005299"}', -0.99, 21000331, 11000031, 13),
+			(31001001, '{"en":"J055520"}', -0.99, 21000333, 11000033, NULL),
+			(30000157, '{"en":"Skarkon"}', -1.0, 20000788, 10000070, NULL),
+			(30000142, '{"en":"Jita"}', 0.946, 20000020, 10000002, NULL);
+
+		INSERT INTO groups VALUES (6, '{"en":"Sun"}'), (988, '{"en":"Wormhole"}'), (920, '{"en":"Effect Beacon"}');
+		INSERT INTO types VALUES
+			(3802, '{"en":"Sun K7 (Orange)"}', 6),
+			(30577, '{"en":"Pulsar"}', 6),
+			(30844, '{"en":"Pulsar Effect Beacon Class 3"}', 920),
+			(30583, '{"en":"Wormhole B274"}', 988),
+			(30642, '{"en":"Wormhole K162"}', 988);
+		INSERT INTO mapStars VALUES
+			(40000001, 31000001, 3802),
+			(40000003, 31000003, 30577),
+			(40000005, 31000002, 30577);
+		INSERT INTO dogmaAttributes VALUES
+			(1381, 'wormholeTargetSystemClass', '{"en":"Target System Class"}'),
+			(1382, 'wormholeMaxStableTime', '{"en":"Maximum Stable Time"}'),
+			(1383, 'wormholeMaxStableMass', '{"en":"Maximum Stable Mass"}'),
+			(1384, 'wormholeMassRegeneration', '{"en":"Mass Regeneration"}'),
+			(1385, 'wormholeMaxJumpMass', '{"en":"Maximum Jump Mass"}'),
+			(554, 'signatureRadiusMultiplier', '{"en":"Signature Radius"}');
+		INSERT INTO typeDogma VALUES
+			(30583, '[{"attributeID":1381,"value":7},{"attributeID":1382,"value":1440},{"attributeID":1383,"value":2000000000},{"attributeID":1385,"value":300000000},{"attributeID":1384,"value":0}]'),
+			(30642, '[{"attributeID":1382,"value":1440},{"attributeID":1383,"value":2000000000}]'),
+			(30844, '[{"attributeID":554,"value":1.44}]');
+	`); err != nil {
+		t.Fatalf("Failed to create wormhole fixture: %v", err)
+	}
+
+	if err := InitializeWormholeViews(db); err != nil {
+		t.Fatalf("InitializeWormholeViews failed: %v", err)
+	}
+	return db
+}
+
+func TestWormholeSystems(t *testing.T) {
+	db := wormholeDB(t)
+
+	rows, err := db.Query(`SELECT system_id, wormhole_class_id, COALESCE(class_name, ''), COALESCE(class_kind, '')
+		FROM v_wormhole_systems ORDER BY system_id`)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	defer rows.Close()
+
+	type row struct {
+		id, class  int64
+		name, kind string
+	}
+	var got []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.id, &r.class, &r.name, &r.kind); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		got = append(got, r)
+	}
+
+	want := []row{
+		{30000157, 25, "Pochven", "pochven"}, // Fallback über Security-Zone
+		{31000001, 1, "C1", "wormhole"},      // von der Region geerbt
+		{31000002, 2, "C2", "wormhole"},      // Konstellation vor Region
+		{31000003, 3, "C3", "wormhole"},      // 0 zählt als nicht gesetzt
+		{31000005, 12, "Thera", "thera"},     // System-Ebene
+		{31001000, 13, "Shattered", "shattered"},
+		{31001001, 14, "Sentinel", "drifter"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("v_wormhole_systems =\n%v\nwant\n%v", got, want)
+	}
+
+	var regions int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM v_wormhole_regions WHERE region_id = 11000031 AND system_count = 1`).Scan(&regions); err != nil {
+		t.Fatalf("Query regions failed: %v", err)
+	}
+	if regions != 2 {
+		t.Errorf("Thera region rows = %d, want 2 (Thera + Shattered)", regions)
+	}
+}
+
+func TestWormholeSystemEffects(t *testing.T) {
+	db := wormholeDB(t)
+
+	tests := []struct {
+		systemID   int64
+		wantEffect sql.NullString
+		wantBeacon sql.NullInt64
+	}{
+		{31000001, sql.NullString{}, sql.NullInt64{}},
+		{31000002, sql.NullString{String: "Pulsar", Valid: true}, sql.NullInt64{}},
+		{31000003, sql.NullString{String: "Pulsar", Valid: true}, sql.NullInt64{Int64: 30844, Valid: true}},
+		{31000005, sql.NullString{}, sql.NullInt64{}},
+	}
+	for _, tt := range tests {
+		var effect sql.NullString
+		var beacon sql.NullInt64
+		err := db.QueryRow(`SELECT effect, beacon_type_id FROM v_wormhole_system_effects WHERE system_id = ?`, tt.systemID).Scan(&effect, &beacon)
+		if err != nil {
+			t.Fatalf("%d: Query failed: %v", tt.systemID, err)
+		}
+		if effect != tt.wantEffect || beacon != tt.wantBeacon {
+			t.Errorf("%d: effect = %v, beacon = %v; want %v, %v", tt.systemID, effect, beacon, tt.wantEffect, tt.wantBeacon)
+		}
+	}
+
+	var effect, attribute string
+	var classID int64
+	var value float64
+	err := db.QueryRow(`SELECT effect, class_id, attribute_name, value FROM v_wormhole_effect_beacons WHERE beacon_type_id = 30844`).
+		Scan(&effect, &classID, &attribute, &value)
+	if err != nil {
+		t.Fatalf("Query beacons failed: %v", err)
+	}
+	if effect != "Pulsar" || classID != 3 || attribute != "signatureRadiusMultiplier" || value != 1.44 {
+		t.Errorf("beacon = %s/%d/%s/%v", effect, classID, attribute, value)
+	}
+}
+
+func TestWormholeTypes(t *testing.T) {
+	db := wormholeDB(t)
+
+	var (
+		code, className               string
+		targetClass, staticCapable    int64
+		lifetime, stableMass, jumpMax float64
+	)
+	err := db.QueryRow(`SELECT wormhole_code, target_class_id, target_class_name, lifetime_hours, max_stable_mass,
+		max_jump_mass, static_capable FROM v_wormhole_types WHERE type_id = 30583`).
+		Scan(&code, &targetClass, &className, &lifetime, &stableMass, &jumpMax, &staticCapable)
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if code != "B274" || targetClass != 7 || className != "High-Sec" || lifetime != 24 ||
+		stableMass != 2e9 || jumpMax != 3e8 || staticCapable != 1 {
+		t.Errorf("B274 = %s/%d/%s/%v/%v/%v/%d", code, targetClass, className, lifetime, stableMass, jumpMax, staticCapable)
+	}
+
+	var k162Target sql.NullInt64
+	if err := db.QueryRow(`SELECT target_class_id, static_capable FROM v_wormhole_types WHERE wormhole_code = 'K162'`).
+		Scan(&k162Target, &staticCapable); err != nil {
+		t.Fatalf("Query K162 failed: %v", err)
+	}
+	if k162Target.Valid || staticCapable != 0 {
+		t.Errorf("K162 target = %v, static_capable = %d; want NULL, 0", k162Target, staticCapable)
+	}
+}
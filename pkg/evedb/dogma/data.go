@@ -0,0 +1,276 @@
+// Package dogma wertet Dogma-Attribute eines Fittings aus: Schiff, Module, Charges, Skills und Implantate
+// modifizieren sich über dogmaEffects.modifierInfo, inklusive Stacking-Penalty für nicht stapelbare Attribute
+package dogma
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrUnknownType: Typ-ID existiert nicht in types
+	ErrUnknownType = errors.New("unknown type")
+	// ErrInvalidFit: Fitting ist unvollständig oder enthält ungültige Stufen/Zustände
+	ErrInvalidFit = errors.New("invalid fit")
+)
+
+// Operation ist die Rechenart eines Modifiers (dogmaEffects.modifierInfo.operation)
+type Operation int
+
+const (
+	OpPreAssign   Operation = -1
+	OpPreMul      Operation = 0
+	OpPreDiv      Operation = 1
+	OpModAdd      Operation = 2
+	OpModSub      Operation = 3
+	OpPostMul     Operation = 4
+	OpPostDiv     Operation = 5
+	OpPostPercent Operation = 6
+	OpPostAssign  Operation = 7
+)
+
+// Effekt-Kategorien (dogmaEffects.effectCategoryID)
+const (
+	EffectPassive  = 0
+	EffectActive   = 1
+	EffectTarget   = 2
+	EffectOnline   = 4
+	EffectOverload = 5
+)
+
+// Kategorien, deren Modifier keiner Stacking-Penalty unterliegen
+const (
+	CategoryShip      int64 = 6
+	CategoryCharge    int64 = 8
+	CategorySkill     int64 = 16
+	CategoryImplant   int64 = 20
+	CategorySubsystem int64 = 32
+)
+
+// Attribute ist ein Dogma-Attribut
+type Attribute struct {
+	ID           int64
+	Name         string
+	DefaultValue float64
+	Stackable    bool
+	HighIsGood   bool
+	// MaxAttributeID: Attribut, dessen Wert die Obergrenze bildet (0 = keine)
+	MaxAttributeID int64
+}
+
+// Modifier ist ein Eintrag aus dogmaEffects.modifierInfo
+type Modifier struct {
+	Domain               string    `json:"domain"`
+	Func                 string    `json:"func"`
+	ModifiedAttributeID  int64     `json:"modifiedAttributeID"`
+	ModifyingAttributeID int64     `json:"modifyingAttributeID"`
+	Operation            Operation `json:"operation"`
+	GroupID              int64     `json:"groupID"`
+	SkillTypeID          int64     `json:"skillTypeID"`
+}
+
+// Effect ist ein Dogma-Effekt mit seinen Modifiern
+type Effect struct {
+	ID        int64
+	Name      string
+	Category  int
+	Modifiers []Modifier
+}
+
+// Type ist ein Typ mit Basis-Attributen und Effekten
+type Type struct {
+	ID         int64
+	Name       string
+	GroupID    int64
+	CategoryID int64
+	Attributes map[int64]float64
+	Effects    []int64
+	// RequiredSkills: Skills aus requiredSkill1..6
+	RequiredSkills []int64
+}
+
+// Data enthält Attribute, Effekte und (lazy aus der Datenbank geladen) Typen
+type Data struct {
+	db         *sql.DB
+	attributes map[int64]Attribute
+	byName     map[string]int64
+	effects    map[int64]Effect
+
+	mu    sync.Mutex
+	types map[int64]*Type
+}
+
+// NewData erstellt Dogma-Daten ohne Datenbank (alle Typen müssen übergeben werden)
+func NewData(attributes []Attribute, effects []Effect, types []*Type) *Data {
+	d := &Data{
+		attributes: make(map[int64]Attribute, len(attributes)),
+		byName:     make(map[string]int64, len(attributes)),
+		effects:    make(map[int64]Effect, len(effects)),
+		types:      make(map[int64]*Type, len(types)),
+	}
+	for _, a := range attributes {
+		d.attributes[a.ID] = a
+		d.byName[a.Name] = a.ID
+	}
+	for _, e := range effects {
+		d.effects[e.ID] = e
+	}
+	for _, t := range types {
+		if t.RequiredSkills == nil {
+			t.RequiredSkills = d.requiredSkills(t.Attributes)
+		}
+		d.types[t.ID] = t
+	}
+	return d
+}
+
+// LoadData lädt alle dogmaAttributes und dogmaEffects; Typen werden bei Bedarf aus typeDogma gelesen
+func LoadData(db *sql.DB) (*Data, error) {
+	var attributes []Attribute
+	rows, err := db.Query(`SELECT _key, COALESCE(name, ''), COALESCE(defaultValue, 0), COALESCE(stackable, 0),
+		COALESCE(highIsGood, 0), COALESCE(maxAttributeID, 0) FROM dogmaAttributes`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dogma attributes: %w", err)
+	}
+	for rows.Next() {
+		var a Attribute
+		if err := rows.Scan(&a.ID, &a.Name, &a.DefaultValue, &a.Stackable, &a.HighIsGood, &a.MaxAttributeID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan dogma attribute: %w", err)
+		}
+		attributes = append(attributes, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var effects []Effect
+	rows, err = db.Query(`SELECT _key, COALESCE(name, ''), COALESCE(effectCategoryID, 0), COALESCE(modifierInfo, '[]') FROM dogmaEffects`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dogma effects: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e Effect
+		var info string
+		if err := rows.Scan(&e.ID, &e.Name, &e.Category, &info); err != nil {
+			return nil, fmt.Errorf("failed to scan dogma effect: %w", err)
+		}
+		if err := json.Unmarshal([]byte(info), &e.Modifiers); err != nil {
+			return nil, fmt.Errorf("failed to parse modifierInfo of effect %d: %w", e.ID, err)
+		}
+		effects = append(effects, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	d := NewData(attributes, effects, nil)
+	d.db = db
+	return d, nil
+}
+
+// AttributeID liefert die ID eines Attributs anhand seines Namens
+func (d *Data) AttributeID(name string) (int64, bool) {
+	id, ok := d.byName[name]
+	return id, ok
+}
+
+// Attribute liefert ein Attribut
+func (d *Data) Attribute(id int64) (Attribute, bool) {
+	a, ok := d.attributes[id]
+	return a, ok
+}
+
+// Effect liefert einen Effekt
+func (d *Data) Effect(id int64) (Effect, bool) {
+	e, ok := d.effects[id]
+	return e, ok
+}
+
+// Type liefert einen Typ aus dem Cache oder lädt ihn aus types, groups und typeDogma
+func (d *Data) Type(id int64) (*Type, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if t, ok := d.types[id]; ok {
+		return t, nil
+	}
+	if d.db == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownType, id)
+	}
+
+	t := &Type{ID: id, Attributes: make(map[int64]float64)}
+	var name sql.NullString
+	var mass, volume, capacity sql.NullFloat64
+	err := d.db.QueryRow(`SELECT json_extract(t.name, '$.en'), COALESCE(t.groupID, 0), COALESCE(g.categoryID, 0),
+		t.mass, t.volume, t.capacity
+		FROM types t LEFT JOIN groups g ON g._key = t.groupID WHERE t._key = ?`, id).
+		Scan(&name, &t.GroupID, &t.CategoryID, &mass, &volume, &capacity)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownType, id)
+	case err != nil:
+		return nil, fmt.Errorf("failed to query type %d: %w", id, err)
+	}
+	t.Name = name.String
+
+	// Masse, Volumen und Kapazität stehen in types, nicht immer in typeDogma
+	for attr, v := range map[string]sql.NullFloat64{"mass": mass, "volume": volume, "capacity": capacity} {
+		if attrID, ok := d.byName[attr]; ok && v.Valid {
+			t.Attributes[attrID] = v.Float64
+		}
+	}
+
+	var attrs, effects sql.NullString
+	err = d.db.QueryRow(`SELECT dogmaAttributes, dogmaEffects FROM typeDogma WHERE _key = ?`, id).Scan(&attrs, &effects)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query type dogma %d: %w", id, err)
+	}
+	if attrs.Valid {
+		var list []struct {
+			AttributeID int64   `json:"attributeID"`
+			Value       float64 `json:"value"`
+		}
+		if err := json.Unmarshal([]byte(attrs.String), &list); err != nil {
+			return nil, fmt.Errorf("failed to parse dogma attributes of type %d: %w", id, err)
+		}
+		for _, a := range list {
+			t.Attributes[a.AttributeID] = a.Value
+		}
+	}
+	if effects.Valid {
+		var list []struct {
+			EffectID int64 `json:"effectID"`
+		}
+		if err := json.Unmarshal([]byte(effects.String), &list); err != nil {
+			return nil, fmt.Errorf("failed to parse dogma effects of type %d: %w", id, err)
+		}
+		for _, e := range list {
+			t.Effects = append(t.Effects, e.EffectID)
+		}
+	}
+	t.RequiredSkills = d.requiredSkills(t.Attributes)
+
+	d.types[id] = t
+	return t, nil
+}
+
+// requiredSkills liest requiredSkill1..6 aus den Attributen
+func (d *Data) requiredSkills(attrs map[int64]float64) []int64 {
+	var skills []int64
+	for i := 1; i <= 6; i++ {
+		attrID, ok := d.byName[fmt.Sprintf("requiredSkill%d", i)]
+		if !ok {
+			continue
+		}
+		if v, ok := attrs[attrID]; ok && v > 0 {
+			skills = append(skills, int64(v))
+		}
+	}
+	return skills
+}
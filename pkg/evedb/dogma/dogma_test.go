@@ -0,0 +1,201 @@
+package dogma
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+)
+
+// Attribute der Fixture
+const (
+	attrMass          int64 = 4
+	attrCapacity      int64 = 38
+	attrMaxVelocity   int64 = 37
+	attrEMDamage      int64 = 114
+	attrRequiredSkill int64 = 182
+	attrSkillLevel    int64 = 280
+	attrCargoBonus    int64 = 1000
+	attrCargoMult     int64 = 1001
+	attrVelocityMult  int64 = 1002
+	attrDamageBonus   int64 = 1003
+	attrVelocityAdd   int64 = 1004
+	attrSpeedFactor   int64 = 20
+)
+
+// Typen der Fixture
+const (
+	ship         int64 = 600
+	cargoSkill   int64 = 3340
+	missileSkill int64 = 3319
+	expander     int64 = 1319
+	launcher     int64 = 499
+	missile      int64 = 2000
+	implant      int64 = 3000
+	afterburner  int64 = 2001
+)
+
+func fixtureData() *Data {
+	attributes := []Attribute{
+		{ID: attrMass, Name: "mass", Stackable: true},
+		{ID: attrCapacity, Name: "capacity", Stackable: true},
+		{ID: attrMaxVelocity, Name: "maxVelocity"},
+		{ID: attrEMDamage, Name: "emDamage", Stackable: true},
+		{ID: attrRequiredSkill, Name: "requiredSkill1"},
+		{ID: attrSkillLevel, Name: "skillLevel", Stackable: true},
+		{ID: attrCargoBonus, Name: "cargoCapacityBonus", Stackable: true},
+		{ID: attrCargoMult, Name: "cargoCapacityMultiplier", Stackable: true},
+		{ID: attrVelocityMult, Name: "maxVelocityModifier", Stackable: true},
+		{ID: attrDamageBonus, Name: "damageMultiplierBonus", Stackable: true},
+		{ID: attrVelocityAdd, Name: "velocityBonusFlat", Stackable: true},
+		{ID: attrSpeedFactor, Name: "speedFactor", Stackable: true},
+	}
+	effects := []Effect{
+		// Skill-Bonus je Stufe
+		{ID: 5000, Name: "skillLevelBonus", Category: EffectPassive, Modifiers: []Modifier{
+			{Domain: "itemID", Func: "ItemModifier", ModifiedAttributeID: attrCargoBonus, ModifyingAttributeID: attrSkillLevel, Operation: OpPreMul},
+			{Domain: "itemID", Func: "ItemModifier", ModifiedAttributeID: attrDamageBonus, ModifyingAttributeID: attrSkillLevel, Operation: OpPreMul},
+		}},
+		{ID: 5001, Name: "shipCargoBonus", Category: EffectPassive, Modifiers: []Modifier{
+			{Domain: "shipID", Func: "ItemModifier", ModifiedAttributeID: attrCapacity, ModifyingAttributeID: attrCargoBonus, Operation: OpPostPercent},
+		}},
+		{ID: 5002, Name: "cargoExpander", Category: EffectOnline, Modifiers: []Modifier{
+			{Domain: "shipID", Func: "ItemModifier", ModifiedAttributeID: attrCapacity, ModifyingAttributeID: attrCargoMult, Operation: OpPostMul},
+			{Domain: "shipID", Func: "ItemModifier", ModifiedAttributeID: attrMaxVelocity, ModifyingAttributeID: attrVelocityMult, Operation: OpPostMul},
+		}},
+		{ID: 5003, Name: "missileDamageBonus", Category: EffectPassive, Modifiers: []Modifier{
+			{Domain: "charID", Func: "OwnerRequiredSkillModifier", SkillTypeID: -1, ModifiedAttributeID: attrEMDamage, ModifyingAttributeID: attrDamageBonus, Operation: OpPostPercent},
+		}},
+		{ID: 5004, Name: "implantVelocity", Category: EffectPassive, Modifiers: []Modifier{
+			{Domain: "shipID", Func: "ItemModifier", ModifiedAttributeID: attrMaxVelocity, ModifyingAttributeID: attrVelocityAdd, Operation: OpModAdd},
+		}},
+		{ID: 5005, Name: "afterburner", Category: EffectActive, Modifiers: []Modifier{
+			{Domain: "shipID", Func: "ItemModifier", ModifiedAttributeID: attrMaxVelocity, ModifyingAttributeID: attrSpeedFactor, Operation: OpPostPercent},
+		}},
+	}
+	types := []*Type{
+		{ID: ship, Name: "Hauler", GroupID: 28, CategoryID: CategoryShip, Attributes: map[int64]float64{attrCapacity: 400, attrMaxVelocity: 300}},
+		{ID: cargoSkill, Name: "Cargo Skill", GroupID: 255, CategoryID: CategorySkill, Attributes: map[int64]float64{attrCargoBonus: 5}, Effects: []int64{5000, 5001}},
+		{ID: missileSkill, Name: "Missile Skill", GroupID: 255, CategoryID: CategorySkill, Attributes: map[int64]float64{attrDamageBonus: 10}, Effects: []int64{5000, 5003}},
+		{ID: expander, Name: "Expanded Cargohold I", GroupID: 762, CategoryID: 7, Attributes: map[int64]float64{attrCargoMult: 1.275, attrVelocityMult: 0.9}, Effects: []int64{5002}},
+		{ID: launcher, Name: "Launcher", GroupID: 507, CategoryID: 7, Attributes: map[int64]float64{}},
+		{ID: missile, Name: "Missile", GroupID: 385, CategoryID: CategoryCharge, Attributes: map[int64]float64{attrEMDamage: 100, attrRequiredSkill: float64(missileSkill)}},
+		{ID: implant, Name: "Velocity Implant", GroupID: 300, CategoryID: CategoryImplant, Attributes: map[int64]float64{attrVelocityAdd: 10}, Effects: []int64{5004}},
+		{ID: afterburner, Name: "Afterburner", GroupID: 46, CategoryID: 7, Attributes: map[int64]float64{attrSpeedFactor: 135}, Effects: []int64{5005}},
+	}
+	return NewData(attributes, effects, types)
+}
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+func TestEvaluate(t *testing.T) {
+	d := fixtureData()
+
+	fit := Fit{
+		ShipTypeID: ship,
+		Modules: []Module{
+			{TypeID: expander, State: StateOnline},
+			{TypeID: expander, State: StateOnline},
+			{TypeID: expander, State: StateOffline},
+			{TypeID: launcher, State: StateActive, ChargeTypeID: missile},
+			{TypeID: afterburner, State: StateOnline},
+		},
+		Implants: []int64{implant},
+		Skills:   map[int64]int{cargoSkill: 5, missileSkill: 4},
+	}
+	r, err := d.Evaluate(fit)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	// capacity stapelbar: 400 × 1.25 (Skill V) × 1.275² (zwei Expander online, dritter offline)
+	if got, want := r.Ship.Attributes["capacity"], 400*1.25*1.275*1.275; !near(got, want) {
+		t.Errorf("capacity = %v, want %v", got, want)
+	}
+	// maxVelocity nicht stapelbar: (300 + 10) × 0.9 × (1 - 0.1 × Penalty(1)); Afterburner nur online
+	if got, want := r.Ship.Attributes["maxVelocity"], 310*0.9*(1-0.1*StackingPenalty(1)); !near(got, want) {
+		t.Errorf("maxVelocity = %v, want %v", got, want)
+	}
+	// OwnerRequiredSkillModifier: Charge verlangt den Skill, 10 % je Stufe
+	if r.Charges[3] == nil || !near(r.Charges[3].Attributes["emDamage"], 140) {
+		t.Errorf("missile = %+v, want emDamage 140", r.Charges[3])
+	}
+	if r.Charges[0] != nil || len(r.Modules) != 5 {
+		t.Errorf("modules = %d, charges[0] = %+v", len(r.Modules), r.Charges[0])
+	}
+
+	// Aktiver Afterburner: +135 % als eigene Operation, daher ohne Penalty gegenüber den Expandern
+	fit.Modules[4].State = StateActive
+	r, err = d.Evaluate(fit)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if got, want := r.Ship.Attributes["maxVelocity"], 310*0.9*(1-0.1*StackingPenalty(1))*2.35; !near(got, want) {
+		t.Errorf("maxVelocity (AB aktiv) = %v, want %v", got, want)
+	}
+}
+
+func TestEvaluate_Invalid(t *testing.T) {
+	d := fixtureData()
+
+	tests := map[string]Fit{
+		"ohne Schiff":      {},
+		"Skill-Stufe 6":    {ShipTypeID: ship, Skills: map[int64]int{cargoSkill: 6}},
+		"ungültiger State": {ShipTypeID: ship, Modules: []Module{{TypeID: expander, State: 7}}},
+	}
+	for name, fit := range tests {
+		if _, err := d.Evaluate(fit); !errors.Is(err, ErrInvalidFit) {
+			t.Errorf("%s: err = %v, want ErrInvalidFit", name, err)
+		}
+	}
+	if _, err := d.Evaluate(Fit{ShipTypeID: 99}); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("unknown ship: err = %v, want ErrUnknownType", err)
+	}
+}
+
+func TestStackingPenalty(t *testing.T) {
+	want := []float64{1, 0.8691199806, 0.5705831430, 0.2829551534}
+	for i, w := range want {
+		if got := StackingPenalty(i); math.Abs(got-w) > 1e-9 {
+			t.Errorf("StackingPenalty(%d) = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestLoadData(t *testing.T) {
+	db := sqlitetest.Open(t, "groups", "types", "typeDogma", "dogmaAttributes", "dogmaEffects")
+
+	if _, err := db.Exec(`
+		INSERT INTO groups (_key, categoryID) VALUES (28, 6), (255, 16);
+		INSERT INTO types (_key, name, groupID, mass, volume, capacity) VALUES (600, '{"en":"Hauler"}', 28, 1000000, 50000, 400), (3340, '{"en":"Cargo Skill"}', 255, 0, 0.01, 0);
+		INSERT INTO dogmaAttributes (_key, name, defaultValue, stackable, highIsGood, maxAttributeID) VALUES (4, 'mass', 0, 1, 0, NULL), (38, 'capacity', 0, 1, 1, NULL),
+			(280, 'skillLevel', 0, 1, 1, NULL), (1000, 'cargoCapacityBonus', 0, 1, 1, NULL), (182, 'requiredSkill1', 0, 1, 1, NULL);
+		INSERT INTO dogmaEffects (_key, name, effectCategoryID, modifierInfo) VALUES
+			(5000, 'skillLevelBonus', 0, '[{"domain":"itemID","func":"ItemModifier","modifiedAttributeID":1000,"modifyingAttributeID":280,"operation":0}]'),
+			(5001, 'shipCargoBonus', 0, '[{"domain":"shipID","func":"ItemModifier","modifiedAttributeID":38,"modifyingAttributeID":1000,"operation":6}]');
+		INSERT INTO typeDogma (_key, dogmaAttributes, dogmaEffects) VALUES (3340, '[{"attributeID":1000,"value":5}]', '[{"effectID":5000,"isDefault":false},{"effectID":5001,"isDefault":false}]');
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+
+	d, err := LoadData(db)
+	if err != nil {
+		t.Fatalf("LoadData failed: %v", err)
+	}
+
+	// Kapazität und Masse aus types, Schiff ohne typeDogma-Eintrag
+	r, err := d.Evaluate(Fit{ShipTypeID: 600, Skills: map[int64]int{3340: 4}})
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if got := r.Ship.Attributes["capacity"]; !near(got, 480) {
+		t.Errorf("capacity = %v, want 480", got)
+	}
+	if got := r.Ship.Attributes["mass"]; got != 1000000 {
+		t.Errorf("mass = %v, want 1000000", got)
+	}
+	if r.Ship.Name != "Hauler" {
+		t.Errorf("ship name = %q", r.Ship.Name)
+	}
+}
@@ -0,0 +1,401 @@
+package dogma
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// State ist der Zustand eines Moduls
+type State int
+
+const (
+	StateOffline State = iota
+	StateOnline
+	StateActive
+	StateOverload
+)
+
+// Module ist ein gefittetes Modul mit optionaler Charge
+type Module struct {
+	TypeID       int64 `json:"type_id"`
+	State        State `json:"state"`
+	ChargeTypeID int64 `json:"charge_type_id,omitempty"`
+}
+
+// Fit ist ein Schiff mit Modulen, Implantaten und Skills des Charakters
+type Fit struct {
+	ShipTypeID int64    `json:"ship_type_id"`
+	Modules    []Module `json:"modules"`
+	Implants   []int64  `json:"implants,omitempty"`
+	// Skills: Skill-TypeID → Stufe; nicht aufgeführte Skills gelten als nicht trainiert
+	Skills map[int64]int `json:"skills,omitempty"`
+}
+
+// Values sind die ausgewerteten Attribute eines Items (Attributname → Wert)
+type Values struct {
+	TypeID     int64              `json:"type_id"`
+	Name       string             `json:"name,omitempty"`
+	Attributes map[string]float64 `json:"attributes"`
+}
+
+// Result ist das ausgewertete Fitting; Modules und Charges folgen der Reihenfolge von Fit.Modules
+type Result struct {
+	Ship      Values   `json:"ship"`
+	Character Values   `json:"character"`
+	Modules   []Values `json:"modules"`
+	// Charges: nil-Einträge für Module ohne Charge
+	Charges []*Values `json:"charges"`
+}
+
+// item ist ein Dogma-Item der Auswertung (Schiff, Charakter, Modul, Charge, Skill, Implantat)
+type item struct {
+	t       *Type
+	state   State
+	located []*item // Items in diesem Item (Schiff: Module/Charges, Charakter: Skills/Implantate)
+	other   *item   // Modul ↔ Charge
+	// skillLevel: gesetzt für Skills
+	skillLevel float64
+	isSkill    bool
+
+	incoming map[int64][]appliedModifier
+}
+
+// appliedModifier ist ein aufgelöster Modifier auf ein Zielattribut
+type appliedModifier struct {
+	source     *item
+	sourceAttr int64
+	op         Operation
+}
+
+// evaluation wertet Attributwerte lazy mit Cache und Zyklusschutz aus
+type evaluation struct {
+	data      *Data
+	skillAttr int64
+	cache     map[*item]map[int64]float64
+	computing map[*item]map[int64]bool
+}
+
+// Evaluate wertet alle Attribute eines Fittings aus
+func (d *Data) Evaluate(fit Fit) (*Result, error) {
+	if fit.ShipTypeID == 0 {
+		return nil, fmt.Errorf("%w: ship is required", ErrInvalidFit)
+	}
+	newItem := func(typeID int64, state State) (*item, error) {
+		t, err := d.Type(typeID)
+		if err != nil {
+			return nil, err
+		}
+		return &item{t: t, state: state, incoming: make(map[int64][]appliedModifier)}, nil
+	}
+
+	ship, err := newItem(fit.ShipTypeID, StateOnline)
+	if err != nil {
+		return nil, err
+	}
+	char := &item{t: &Type{Attributes: map[int64]float64{}}, state: StateOnline, incoming: make(map[int64][]appliedModifier)}
+
+	var all []*item
+	var modules []*item
+	charges := make([]*item, len(fit.Modules))
+	for i, m := range fit.Modules {
+		if m.State < StateOffline || m.State > StateOverload {
+			return nil, fmt.Errorf("%w: module %d has invalid state %d", ErrInvalidFit, m.TypeID, m.State)
+		}
+		mod, err := newItem(m.TypeID, m.State)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, mod)
+		ship.located = append(ship.located, mod)
+		all = append(all, mod)
+
+		if m.ChargeTypeID != 0 {
+			// Charges wirken, solange das Modul online ist
+			charge, err := newItem(m.ChargeTypeID, min(m.State, StateOnline))
+			if err != nil {
+				return nil, err
+			}
+			charge.other, mod.other = mod, charge
+			charges[i] = charge
+			ship.located = append(ship.located, charge)
+			all = append(all, charge)
+		}
+	}
+
+	skillIDs := make([]int64, 0, len(fit.Skills))
+	for id := range fit.Skills {
+		skillIDs = append(skillIDs, id)
+	}
+	sort.Slice(skillIDs, func(i, j int) bool { return skillIDs[i] < skillIDs[j] })
+	for _, id := range skillIDs {
+		level := fit.Skills[id]
+		if level < 0 || level > 5 {
+			return nil, fmt.Errorf("%w: skill %d level %d", ErrInvalidFit, id, level)
+		}
+		skill, err := newItem(id, StateOnline)
+		if err != nil {
+			return nil, err
+		}
+		skill.isSkill, skill.skillLevel = true, float64(level)
+		char.located = append(char.located, skill)
+		all = append(all, skill)
+	}
+	for _, id := range fit.Implants {
+		implant, err := newItem(id, StateOnline)
+		if err != nil {
+			return nil, err
+		}
+		char.located = append(char.located, implant)
+		all = append(all, implant)
+	}
+
+	sources := append([]*item{ship, char}, all...)
+	for _, src := range sources {
+		d.register(src, ship, char, all)
+	}
+
+	skillAttr, _ := d.AttributeID("skillLevel")
+	ev := &evaluation{
+		data:      d,
+		skillAttr: skillAttr,
+		cache:     make(map[*item]map[int64]float64),
+		computing: make(map[*item]map[int64]bool),
+	}
+
+	r := &Result{Ship: ev.values(ship), Character: ev.values(char), Charges: make([]*Values, len(charges))}
+	for _, m := range modules {
+		r.Modules = append(r.Modules, ev.values(m))
+	}
+	for i, c := range charges {
+		if c != nil {
+			v := ev.values(c)
+			r.Charges[i] = &v
+		}
+	}
+	return r, nil
+}
+
+// register trägt die Modifier aller aktiven Effekte eines Items bei ihren Zielen ein
+func (d *Data) register(src, ship, char *item, owned []*item) {
+	for _, effectID := range src.t.Effects {
+		e, ok := d.effects[effectID]
+		if !ok || !effectActive(e.Category, src.state) {
+			continue
+		}
+		for _, m := range e.Modifiers {
+			var domain *item
+			switch m.Domain {
+			case "itemID":
+				domain = src
+			case "shipID":
+				domain = ship
+			case "charID":
+				domain = char
+			case "otherID":
+				domain = src.other
+			}
+			if domain == nil && m.Func != "OwnerRequiredSkillModifier" {
+				continue
+			}
+
+			skill := m.SkillTypeID
+			if skill == -1 {
+				skill = src.t.ID
+			}
+
+			var targets []*item
+			switch m.Func {
+			case "ItemModifier":
+				targets = []*item{domain}
+			case "LocationModifier":
+				targets = domain.located
+			case "LocationGroupModifier":
+				for _, it := range domain.located {
+					if it.t.GroupID == m.GroupID {
+						targets = append(targets, it)
+					}
+				}
+			case "LocationRequiredSkillModifier":
+				for _, it := range domain.located {
+					if requires(it.t, skill) {
+						targets = append(targets, it)
+					}
+				}
+			case "OwnerRequiredSkillModifier":
+				for _, it := range owned {
+					if !it.isSkill && requires(it.t, skill) {
+						targets = append(targets, it)
+					}
+				}
+			}
+
+			for _, target := range targets {
+				target.incoming[m.ModifiedAttributeID] = append(target.incoming[m.ModifiedAttributeID],
+					appliedModifier{source: src, sourceAttr: m.ModifyingAttributeID, op: m.Operation})
+			}
+		}
+	}
+}
+
+// effectActive prüft, ob eine Effekt-Kategorie im Zustand des Items wirkt (Target-Effekte nie, ohne Ziel)
+func effectActive(category int, state State) bool {
+	switch category {
+	case EffectPassive, EffectOnline:
+		return state >= StateOnline
+	case EffectActive:
+		return state >= StateActive
+	case EffectOverload:
+		return state >= StateOverload
+	}
+	return false
+}
+
+// requires prüft, ob ein Typ einen Skill voraussetzt
+func requires(t *Type, skill int64) bool {
+	for _, s := range t.RequiredSkills {
+		if s == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// values wertet alle Basis- und modifizierten Attribute eines Items aus
+func (ev *evaluation) values(it *item) Values {
+	v := Values{TypeID: it.t.ID, Name: it.t.Name, Attributes: make(map[string]float64)}
+	ids := make(map[int64]bool)
+	for id := range it.t.Attributes {
+		ids[id] = true
+	}
+	for id := range it.incoming {
+		ids[id] = true
+	}
+	for id := range ids {
+		name := fmt.Sprintf("%d", id)
+		if a, ok := ev.data.attributes[id]; ok && a.Name != "" {
+			name = a.Name
+		}
+		v.Attributes[name] = ev.value(it, id)
+	}
+	return v
+}
+
+// base liefert den unmodifizierten Wert (Skill-Stufe, Typ-Attribut oder Default des Attributs)
+func (ev *evaluation) base(it *item, attrID int64) float64 {
+	if it.isSkill && attrID == ev.skillAttr && ev.skillAttr != 0 {
+		return it.skillLevel
+	}
+	if v, ok := it.t.Attributes[attrID]; ok {
+		return v
+	}
+	return ev.data.attributes[attrID].DefaultValue
+}
+
+// value wertet ein Attribut aus. Reihenfolge: PreAssign, PreMul, PreDiv, ModAdd, ModSub, PostMul, PostDiv,
+// PostPercent, PostAssign. Zyklen liefern den Basiswert.
+func (ev *evaluation) value(it *item, attrID int64) float64 {
+	if v, ok := ev.cache[it][attrID]; ok {
+		return v
+	}
+	if ev.computing[it][attrID] {
+		return ev.base(it, attrID)
+	}
+	if ev.computing[it] == nil {
+		ev.computing[it] = make(map[int64]bool)
+	}
+	ev.computing[it][attrID] = true
+	defer delete(ev.computing[it], attrID)
+
+	attr := ev.data.attributes[attrID]
+	mods := make(map[Operation][]contribution)
+	for _, m := range it.incoming[attrID] {
+		mods[m.op] = append(mods[m.op], contribution{
+			value:    ev.value(m.source, m.sourceAttr),
+			penalize: !attr.Stackable && !exempt(m.source.t.CategoryID),
+		})
+	}
+
+	v := ev.base(it, attrID)
+	if c := mods[OpPreAssign]; len(c) > 0 {
+		v = c[len(c)-1].value
+	}
+	v *= multiply(mods[OpPreMul], func(x float64) float64 { return x })
+	v *= multiply(mods[OpPreDiv], inverse)
+	for _, c := range mods[OpModAdd] {
+		v += c.value
+	}
+	for _, c := range mods[OpModSub] {
+		v -= c.value
+	}
+	v *= multiply(mods[OpPostMul], func(x float64) float64 { return x })
+	v *= multiply(mods[OpPostDiv], inverse)
+	v *= multiply(mods[OpPostPercent], func(x float64) float64 { return 1 + x/100 })
+	if c := mods[OpPostAssign]; len(c) > 0 {
+		v = c[len(c)-1].value
+	}
+
+	if attr.MaxAttributeID != 0 && attr.MaxAttributeID != attrID {
+		v = math.Min(v, ev.value(it, attr.MaxAttributeID))
+	}
+
+	if ev.cache[it] == nil {
+		ev.cache[it] = make(map[int64]float64)
+	}
+	ev.cache[it][attrID] = v
+	return v
+}
+
+// contribution ist der Wert eines Modifiers und ob er der Stacking-Penalty unterliegt
+type contribution struct {
+	value    float64
+	penalize bool
+}
+
+// exempt prüft, ob Modifier einer Kategorie von der Stacking-Penalty ausgenommen sind
+func exempt(category int64) bool {
+	switch category {
+	case CategoryShip, CategoryCharge, CategorySkill, CategoryImplant, CategorySubsystem:
+		return true
+	}
+	return false
+}
+
+// inverse wandelt einen Divisor in einen Faktor (Division durch 0 wirkt nicht)
+func inverse(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return 1 / x
+}
+
+// multiply bildet das Produkt aller Faktoren; penalisierte Faktoren werden getrennt nach Boni und Mali
+// nach Stärke sortiert und mit exp(-(i/2.67)²) gewichtet
+func multiply(cs []contribution, factor func(float64) float64) float64 {
+	product := 1.0
+	var bonuses, maluses []float64
+	for _, c := range cs {
+		f := factor(c.value)
+		switch {
+		case !c.penalize:
+			product *= f
+		case f >= 1:
+			bonuses = append(bonuses, f)
+		default:
+			maluses = append(maluses, f)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(bonuses)))
+	sort.Float64s(maluses)
+	for _, group := range [][]float64{bonuses, maluses} {
+		for i, f := range group {
+			product *= 1 + (f-1)*StackingPenalty(i)
+		}
+	}
+	return product
+}
+
+// StackingPenalty liefert die Wirksamkeit des i-ten (0-basiert) penalisierten Modifiers
+func StackingPenalty(i int) float64 {
+	return math.Exp(-math.Pow(float64(i)/2.67, 2))
+}
@@ -0,0 +1,145 @@
+package fitting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Sternrassler/eve-sde/pkg/evedb/dogma"
+)
+
+// dnaSections: Reihenfolge der Module im DNA-String
+var dnaSections = []Slot{SlotSubsystem, SlotHigh, SlotMid, SlotLow, SlotRig}
+
+// ParseDNA liest einen Ship-DNA-String ("shipID:typeID;Anzahl:...::"). Module werden je Anzahl einzeln gefittet,
+// Munition in passende Module geladen (Rest in den Frachtraum), Einträge mit "_" landen im Frachtraum.
+func (c *Catalog) ParseDNA(dna string) (*Fit, error) {
+	parts := strings.Split(strings.TrimRight(strings.TrimSpace(dna), ":"), ":")
+	shipID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid ship %q", ErrSyntax, parts[0])
+	}
+	ship, err := c.ship(shipID)
+	if err != nil {
+		return nil, err
+	}
+	f := &Fit{ShipTypeID: shipID, ShipName: ship.Name}
+
+	var charges []Stack
+	for _, p := range parts[1:] {
+		if p == "" {
+			continue
+		}
+		ref, qtyRef, hasQty := strings.Cut(p, ";")
+		ref, cargo := strings.CutSuffix(ref, "_")
+		id, err := strconv.ParseInt(ref, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid type %q", ErrSyntax, p)
+		}
+		qty := int64(1)
+		if hasQty {
+			if qty, err = strconv.ParseInt(qtyRef, 10, 64); err != nil || qty <= 0 {
+				return nil, fmt.Errorf("%w: invalid quantity %q", ErrSyntax, p)
+			}
+		}
+		t, err := c.dogma.Type(id)
+		if err != nil {
+			return nil, err
+		}
+		s := Stack{TypeID: id, Name: t.Name, Quantity: qty}
+
+		slot, fittable := c.slot(t)
+		switch {
+		case cargo:
+			f.Cargo = append(f.Cargo, s)
+		case t.CategoryID == dogma.CategoryCharge:
+			charges = append(charges, s)
+		case isDrone(t):
+			f.Drones = append(f.Drones, s)
+		case fittable:
+			for range qty {
+				f.Modules = append(f.Modules, Module{TypeID: id, Name: t.Name, Slot: slot})
+			}
+		default:
+			f.Cargo = append(f.Cargo, s)
+		}
+	}
+
+	for _, s := range charges {
+		loaded, err := c.load(f, s)
+		if err != nil {
+			return nil, err
+		}
+		if rest := s.Quantity - loaded; rest > 0 {
+			f.Cargo = append(f.Cargo, Stack{TypeID: s.TypeID, Name: s.Name, Quantity: rest})
+		}
+	}
+	return f, nil
+}
+
+// load lädt eine Charge in bis zu s.Quantity passende, leere Module und liefert die Anzahl
+func (c *Catalog) load(f *Fit, s Stack) (int64, error) {
+	charge, err := c.dogma.Type(s.TypeID)
+	if err != nil {
+		return 0, err
+	}
+	var loaded int64
+	for i := range f.Modules {
+		m := &f.Modules[i]
+		if loaded == s.Quantity {
+			break
+		}
+		if m.ChargeTypeID != 0 {
+			continue
+		}
+		module, err := c.dogma.Type(m.TypeID)
+		if err != nil {
+			return 0, err
+		}
+		if c.chargeProblem(module, charge) == "" {
+			m.ChargeTypeID, m.ChargeName = s.TypeID, s.Name
+			loaded++
+		}
+	}
+	return loaded, nil
+}
+
+// DNA schreibt ein Fitting als Ship-DNA: Subsysteme, High, Mid, Low, Rigs, geladene Munition, Drohnen und
+// Fracht (mit "_"). Offline-Zustände gehen verloren.
+func (f *Fit) DNA() string {
+	var order []string
+	counts := make(map[string]int64)
+	add := func(ref string, qty int64) {
+		if _, ok := counts[ref]; !ok {
+			order = append(order, ref)
+		}
+		counts[ref] += qty
+	}
+
+	for _, slot := range dnaSections {
+		for _, m := range f.Modules {
+			if m.Slot == slot {
+				add(strconv.FormatInt(m.TypeID, 10), 1)
+			}
+		}
+	}
+	for _, m := range f.Modules {
+		if m.ChargeTypeID != 0 {
+			add(strconv.FormatInt(m.ChargeTypeID, 10), 1)
+		}
+	}
+	for _, s := range f.Drones {
+		add(strconv.FormatInt(s.TypeID, 10), s.Quantity)
+	}
+	for _, s := range f.Cargo {
+		add(strconv.FormatInt(s.TypeID, 10)+"_", s.Quantity)
+	}
+
+	var b strings.Builder
+	b.WriteString(strconv.FormatInt(f.ShipTypeID, 10))
+	for _, ref := range order {
+		fmt.Fprintf(&b, ":%s;%d", ref, counts[ref])
+	}
+	b.WriteString("::")
+	return b.String()
+}
@@ -0,0 +1,219 @@
+package fitting
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// stackLine erkennt Drohnen- und Frachtzeilen ("Hobgoblin II x5")
+var stackLine = regexp.MustCompile(`^(.+?)\s+x(\d+)$`)
+
+// eftSections: Reihenfolge der Modul-Abschnitte im EFT-Format
+var eftSections = []Slot{SlotLow, SlotMid, SlotHigh, SlotRig, SlotSubsystem}
+
+// ParseEFT liest ein Fitting im EFT-Format: Kopfzeile "[Schiff, Name]", je Zeile ein Modul mit optionaler
+// Munition (", Charge") und "/OFFLINE", Drohnen und Fracht als "Name xN". Der Slot eines Moduls ergibt sich aus
+// seinen Dogma-Effekten; der erste Abschnitt, der nur Drohnen enthält, wird zum Drohnenhangar.
+func (c *Catalog) ParseEFT(text string) (*Fit, error) {
+	f := &Fit{}
+	header := false
+	var section []Stack
+	flush := func() error {
+		if len(section) == 0 {
+			return nil
+		}
+		drones := len(f.Drones) == 0 && len(f.Cargo) == 0
+		for _, s := range section {
+			t, err := c.dogma.Type(s.TypeID)
+			if err != nil {
+				return err
+			}
+			drones = drones && isDrone(t)
+		}
+		if drones {
+			f.Drones = section
+		} else {
+			f.Cargo = append(f.Cargo, section...)
+		}
+		section = nil
+		return nil
+	}
+
+	for i, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case !header:
+			if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%w: line %d: expected [ship, name] header", ErrSyntax, i+1)
+			}
+			shipName, name, _ := strings.Cut(line[1:len(line)-1], ",")
+			id, err := c.TypeID(shipName)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			t, err := c.ship(id)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			f.ShipTypeID, f.ShipName, f.Name = id, t.Name, strings.TrimSpace(name)
+			header = true
+		case strings.HasPrefix(line, "["):
+			if !strings.HasPrefix(strings.ToLower(line), "[empty ") {
+				return nil, fmt.Errorf("%w: line %d: unexpected %s", ErrSyntax, i+1, line)
+			}
+		default:
+			if m := stackLine.FindStringSubmatch(line); m != nil {
+				qty, err := strconv.ParseInt(m[2], 10, 64)
+				if err != nil || qty <= 0 {
+					return nil, fmt.Errorf("%w: line %d: invalid quantity %q", ErrSyntax, i+1, m[2])
+				}
+				s, err := c.stack(m[1], qty)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", i+1, err)
+				}
+				section = append(section, s)
+				continue
+			}
+			if err := c.parseModule(f, line, &section); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, fmt.Errorf("%w: missing [ship, name] header", ErrSyntax)
+	}
+	return f, nil
+}
+
+// parseModule liest eine Modulzeile; Typen ohne Slot landen als Einzelstück im aktuellen Abschnitt
+func (c *Catalog) parseModule(f *Fit, line string, section *[]Stack) error {
+	m := Module{}
+	if rest, ok := strings.CutSuffix(line, "/OFFLINE"); ok {
+		line, m.Offline = strings.TrimSpace(rest), true
+	}
+	name, charge := line, ""
+	if i := strings.LastIndex(line, ","); i >= 0 {
+		name, charge = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+	}
+
+	id, err := c.TypeID(name)
+	if err != nil {
+		return err
+	}
+	t, err := c.dogma.Type(id)
+	if err != nil {
+		return err
+	}
+	slot, ok := c.slot(t)
+	if !ok {
+		if charge != "" || m.Offline {
+			return fmt.Errorf("%w: %s has no slot", ErrNotFittable, t.Name)
+		}
+		*section = append(*section, Stack{TypeID: id, Name: t.Name, Quantity: 1})
+		return nil
+	}
+	m.TypeID, m.Name, m.Slot = id, t.Name, slot
+
+	if charge != "" {
+		chargeID, err := c.TypeID(charge)
+		if err != nil {
+			return err
+		}
+		ct, err := c.dogma.Type(chargeID)
+		if err != nil {
+			return err
+		}
+		m.ChargeTypeID, m.ChargeName = chargeID, ct.Name
+	}
+	f.Modules = append(f.Modules, m)
+	return nil
+}
+
+// stack löst einen Drohnen- oder Frachtstapel auf
+func (c *Catalog) stack(name string, qty int64) (Stack, error) {
+	id, err := c.TypeID(name)
+	if err != nil {
+		return Stack{}, err
+	}
+	t, err := c.dogma.Type(id)
+	if err != nil {
+		return Stack{}, err
+	}
+	return Stack{TypeID: id, Name: t.Name, Quantity: qty}, nil
+}
+
+// EFT schreibt ein Fitting im EFT-Format mit Namen in lang ("" = Englisch); Abschnitte Low, Mid, High, Rigs,
+// Subsysteme, Drohnen und Fracht, getrennt durch Leerzeilen
+func (c *Catalog) EFT(f *Fit, lang string) (string, error) {
+	shipName, err := c.Name(f.ShipTypeID, lang)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if f.Name != "" {
+		fmt.Fprintf(&b, "[%s, %s]\n", shipName, f.Name)
+	} else {
+		fmt.Fprintf(&b, "[%s]\n", shipName)
+	}
+
+	var sections [][]string
+	for _, slot := range eftSections {
+		var lines []string
+		for _, m := range f.Modules {
+			if m.Slot != slot {
+				continue
+			}
+			line, err := c.Name(m.TypeID, lang)
+			if err != nil {
+				return "", err
+			}
+			if m.ChargeTypeID != 0 {
+				charge, err := c.Name(m.ChargeTypeID, lang)
+				if err != nil {
+					return "", err
+				}
+				line += ", " + charge
+			}
+			if m.Offline {
+				line += " /OFFLINE"
+			}
+			lines = append(lines, line)
+		}
+		sections = append(sections, lines)
+	}
+	for _, stacks := range [][]Stack{f.Drones, f.Cargo} {
+		var lines []string
+		for _, s := range stacks {
+			name, err := c.Name(s.TypeID, lang)
+			if err != nil {
+				return "", err
+			}
+			lines = append(lines, fmt.Sprintf("%s x%d", name, s.Quantity))
+		}
+		sections = append(sections, lines)
+	}
+
+	first := true
+	for _, lines := range sections {
+		if len(lines) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteString("\n")
+		}
+		first = false
+		for _, l := range lines {
+			b.WriteString(l + "\n")
+		}
+	}
+	return b.String(), nil
+}
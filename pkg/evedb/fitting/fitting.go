@@ -0,0 +1,267 @@
+// Package fitting liest und schreibt Fittings im EFT- und DNA-Format und prüft sie gegen die Dogma-Attribute
+// (Slots, Hardpoints, Rig-Größe, Kalibrierung, CPU/Powergrid, Munition)
+package fitting
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Sternrassler/eve-sde/pkg/evedb/dogma"
+)
+
+var (
+	// ErrUnknownName: Name existiert in keiner Sprache von types.name
+	ErrUnknownName = errors.New("unknown type name")
+	// ErrSyntax: EFT-Block oder DNA-String ist fehlerhaft
+	ErrSyntax = errors.New("invalid fit syntax")
+	// ErrNotFittable: Typ ist kein Schiff bzw. kein Modul
+	ErrNotFittable = errors.New("type is not fittable")
+)
+
+// Slot ist die Slot-Art eines Moduls
+type Slot int
+
+const (
+	SlotHigh Slot = iota
+	SlotMid
+	SlotLow
+	SlotRig
+	SlotSubsystem
+)
+
+// String liefert den Namen des Slots (wie in "[Empty High slot]")
+func (s Slot) String() string {
+	switch s {
+	case SlotHigh:
+		return "High"
+	case SlotMid:
+		return "Med"
+	case SlotLow:
+		return "Low"
+	case SlotRig:
+		return "Rig"
+	case SlotSubsystem:
+		return "Subsystem"
+	}
+	return fmt.Sprintf("Slot(%d)", int(s))
+}
+
+// slotEffects: Dogma-Effekte, die den Slot eines Moduls festlegen
+var slotEffects = map[string]Slot{
+	"hiPower":   SlotHigh,
+	"medPower":  SlotMid,
+	"loPower":   SlotLow,
+	"rigSlot":   SlotRig,
+	"subSystem": SlotSubsystem,
+}
+
+// Kategorien für Drohnen-Stapel
+const (
+	categoryDrone   int64 = 18
+	categoryFighter int64 = 87
+)
+
+// Module ist ein gefittetes Modul mit optionaler Munition
+type Module struct {
+	TypeID       int64  `json:"type_id"`
+	Name         string `json:"name,omitempty"`
+	Slot         Slot   `json:"slot"`
+	ChargeTypeID int64  `json:"charge_type_id,omitempty"`
+	ChargeName   string `json:"charge_name,omitempty"`
+	Offline      bool   `json:"offline,omitempty"`
+}
+
+// Stack ist ein Stapel in Drohnenhangar oder Frachtraum
+type Stack struct {
+	TypeID   int64  `json:"type_id"`
+	Name     string `json:"name,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+// Fit ist ein Schiff mit Modulen, Drohnen und Fracht
+type Fit struct {
+	ShipTypeID int64    `json:"ship_type_id"`
+	ShipName   string   `json:"ship_name,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	Modules    []Module `json:"modules"`
+	Drones     []Stack  `json:"drones,omitempty"`
+	Cargo      []Stack  `json:"cargo,omitempty"`
+}
+
+// DogmaFit wandelt das Fitting für dogma.Data.Evaluate (Module online bzw. offline)
+func (f *Fit) DogmaFit(skills map[int64]int) dogma.Fit {
+	df := dogma.Fit{ShipTypeID: f.ShipTypeID, Skills: skills}
+	for _, m := range f.Modules {
+		state := dogma.StateOnline
+		if m.Offline {
+			state = dogma.StateOffline
+		}
+		df.Modules = append(df.Modules, dogma.Module{TypeID: m.TypeID, State: state, ChargeTypeID: m.ChargeTypeID})
+	}
+	return df
+}
+
+// Catalog löst Namen über types.name auf und liefert Slots und Attribute aus den Dogma-Daten
+type Catalog struct {
+	db    *sql.DB
+	dogma *dogma.Data
+
+	mu  sync.Mutex
+	ids map[string]int64
+}
+
+// NewCatalog erstellt einen Katalog auf bereits geladenen Dogma-Daten
+func NewCatalog(db *sql.DB, data *dogma.Data) *Catalog {
+	return &Catalog{db: db, dogma: data, ids: make(map[string]int64)}
+}
+
+// LoadCatalog lädt die Dogma-Daten und erstellt den Katalog
+func LoadCatalog(db *sql.DB) (*Catalog, error) {
+	data, err := dogma.LoadData(db)
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalog(db, data), nil
+}
+
+// Dogma liefert die Dogma-Daten des Katalogs
+func (c *Catalog) Dogma() *dogma.Data {
+	return c.dogma
+}
+
+// TypeID löst einen Namen in einer beliebigen Sprache von types.name auf (Groß-/Kleinschreibung egal);
+// englische Namen und veröffentlichte Typen haben Vorrang
+func (c *Catalog) TypeID(name string) (int64, error) {
+	name = strings.TrimSpace(name)
+	key := strings.ToLower(name)
+
+	c.mu.Lock()
+	id, ok := c.ids[key]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	err := c.db.QueryRow(`SELECT _key FROM types WHERE json_extract(name, '$.en') = ? COLLATE NOCASE
+		ORDER BY COALESCE(published, 0) DESC, _key LIMIT 1`, name).Scan(&id)
+	if err == sql.ErrNoRows {
+		err = c.db.QueryRow(`SELECT t._key FROM types t, json_each(t.name) n WHERE n.value = ? COLLATE NOCASE
+			ORDER BY COALESCE(t.published, 0) DESC, t._key LIMIT 1`, name).Scan(&id)
+	}
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownName, name)
+	case err != nil:
+		return 0, fmt.Errorf("failed to resolve type name %q: %w", name, err)
+	}
+
+	c.mu.Lock()
+	c.ids[key] = id
+	c.mu.Unlock()
+	return id, nil
+}
+
+// Name liefert den Namen eines Typs in einer Sprache ("" = Englisch, Fallback Englisch)
+func (c *Catalog) Name(typeID int64, lang string) (string, error) {
+	if lang == "" {
+		lang = "en"
+	}
+	var name sql.NullString
+	err := c.db.QueryRow(`SELECT COALESCE(json_extract(name, '$.' || ?), json_extract(name, '$.en'))
+		FROM types WHERE _key = ?`, lang, typeID).Scan(&name)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", fmt.Errorf("%w: %d", dogma.ErrUnknownType, typeID)
+	case err != nil:
+		return "", fmt.Errorf("failed to query type name %d: %w", typeID, err)
+	}
+	return name.String, nil
+}
+
+// Slot liefert den Slot eines Typs anhand seiner Dogma-Effekte
+func (c *Catalog) Slot(typeID int64) (Slot, bool, error) {
+	t, err := c.dogma.Type(typeID)
+	if err != nil {
+		return 0, false, err
+	}
+	s, ok := c.slot(t)
+	return s, ok, nil
+}
+
+func (c *Catalog) slot(t *dogma.Type) (Slot, bool) {
+	for _, id := range t.Effects {
+		if e, ok := c.dogma.Effect(id); ok {
+			if s, ok := slotEffects[e.Name]; ok {
+				return s, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// hasEffect prüft, ob ein Typ einen Effekt (Name aus dogmaEffects) besitzt
+func (c *Catalog) hasEffect(t *dogma.Type, name string) bool {
+	for _, id := range t.Effects {
+		if e, ok := c.dogma.Effect(id); ok && e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// attr liefert ein Basis-Attribut eines Typs anhand des Attributnamens
+func (c *Catalog) attr(t *dogma.Type, name string) float64 {
+	id, ok := c.dogma.AttributeID(name)
+	if !ok {
+		return 0
+	}
+	return t.Attributes[id]
+}
+
+// isDrone prüft, ob ein Typ in den Drohnenhangar gehört
+func isDrone(t *dogma.Type) bool {
+	return t.CategoryID == categoryDrone || t.CategoryID == categoryFighter
+}
+
+// ship lädt den Schiffstyp eines Fittings
+func (c *Catalog) ship(typeID int64) (*dogma.Type, error) {
+	t, err := c.dogma.Type(typeID)
+	if err != nil {
+		return nil, err
+	}
+	if t.CategoryID != dogma.CategoryShip {
+		return nil, fmt.Errorf("%w: %d is not a ship", ErrNotFittable, typeID)
+	}
+	return t, nil
+}
+
+// chargeProblem prüft, ob eine Charge in ein Modul passt (chargeGroup1..5, chargeSize, Volumen ≤ capacity);
+// leer, wenn sie passt
+func (c *Catalog) chargeProblem(module, charge *dogma.Type) string {
+	var groups []int64
+	for i := 1; i <= 5; i++ {
+		if g := c.attr(module, fmt.Sprintf("chargeGroup%d", i)); g > 0 {
+			groups = append(groups, int64(g))
+		}
+	}
+	if len(groups) == 0 {
+		return fmt.Sprintf("%s takes no charges", module.Name)
+	}
+	accepted := false
+	for _, g := range groups {
+		accepted = accepted || g == charge.GroupID
+	}
+	if !accepted {
+		return fmt.Sprintf("%s does not accept %s", module.Name, charge.Name)
+	}
+	if ms, cs := c.attr(module, "chargeSize"), c.attr(charge, "chargeSize"); ms > 0 && cs > 0 && ms != cs {
+		return fmt.Sprintf("%s has charge size %g, %s requires %g", charge.Name, cs, module.Name, ms)
+	}
+	if capacity, volume := c.attr(module, "capacity"), c.attr(charge, "volume"); capacity > 0 && volume > capacity {
+		return fmt.Sprintf("%s (%g m³) exceeds the capacity of %s (%g m³)", charge.Name, volume, module.Name, capacity)
+	}
+	return ""
+}
@@ -0,0 +1,321 @@
+package fitting
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+	"github.com/Sternrassler/eve-sde/pkg/evedb/dogma"
+)
+
+const testEFT = `[Rifter, Test Fit]
+Gyrostabilizer II
+Gyrostabilisator II
+[Empty Low slot]
+
+Medium Shield Extender II
+1MN Afterburner II
+
+125mm Gatling AutoCannon II, Hail S
+125mm Gatling AutoCannon II, Hail S
+125mm Gatling AutoCannon II, Hail S /OFFLINE
+[Empty High slot]
+
+Small Projectile Burst Aerator I
+Small Projectile Burst Aerator I
+
+
+Warrior II x2
+
+EMP S x100
+`
+
+// Skills für die Fixture: CPU Management V (+25 % CPU)
+var testSkills = map[int64]int{3426: 5}
+
+func setupTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t, "groups", "types", "typeDogma", "dogmaAttributes", "dogmaEffects")
+
+	if _, err := db.Exec(`
+		INSERT INTO groups (_key, categoryID) VALUES (25, 6), (55, 7), (507, 7), (38, 7), (46, 7), (59, 7), (773, 7),
+			(83, 8), (384, 8), (100, 18), (18, 4), (256, 16);
+
+		INSERT INTO types (_key, name, groupID, published, mass, volume, capacity) VALUES
+			(587, '{"en":"Rifter","de":"Rifter"}', 25, 1, 1067000, 27289, 140),
+			(2873, '{"en":"125mm Gatling AutoCannon II","de":"125-mm-Gatling-Maschinenkanone II"}', 55, 1, 500, 5, 0.5),
+			(2410, '{"en":"Light Missile Launcher II"}', 507, 1, 500, 5, 0.54),
+			(3841, '{"en":"Medium Shield Extender II"}', 38, 1, 1000, 10, 0),
+			(438, '{"en":"1MN Afterburner II"}', 46, 1, 50, 5, 0),
+			(519, '{"en":"Gyrostabilizer II","de":"Gyrostabilisator II"}', 59, 1, 1, 5, 0),
+			(31788, '{"en":"Small Projectile Burst Aerator I"}', 773, 1, 1, 5, 0),
+			(31790, '{"en":"Medium Projectile Burst Aerator I"}', 773, 1, 1, 10, 0),
+			(12608, '{"en":"Hail S"}', 83, 1, 1, 0.0125, 0),
+			(185, '{"en":"EMP S"}', 83, 1, 1, 0.0025, 0),
+			(12625, '{"en":"Hail M"}', 83, 1, 1, 0.0125, 0),
+			(210, '{"en":"Scourge Light Missile"}', 384, 1, 1, 0.015, 0),
+			(2488, '{"en":"Warrior II"}', 100, 1, 3000, 5, 0),
+			(34, '{"en":"Tritanium","de":"Tritanium"}', 18, 1, 0, 0.01, 0),
+			(3426, '{"en":"CPU Management"}', 256, 1, 0, 0.01, 0);
+
+		INSERT INTO dogmaAttributes (_key, name, defaultValue, stackable, highIsGood, maxAttributeID) VALUES
+			(4, 'mass', 0, 1, 0, NULL), (161, 'volume', 0, 1, 0, NULL), (38, 'capacity', 0, 1, 1, NULL),
+			(48, 'cpuOutput', 0, 1, 1, NULL), (50, 'cpu', 0, 1, 0, NULL),
+			(11, 'powerOutput', 0, 1, 1, NULL), (30, 'power', 0, 1, 0, NULL),
+			(14, 'hiSlots', 0, 1, 1, NULL), (13, 'medSlots', 0, 1, 1, NULL), (12, 'lowSlots', 0, 1, 1, NULL),
+			(1137, 'rigSlots', 0, 1, 1, NULL), (1367, 'maxSubSystems', 0, 1, 1, NULL),
+			(102, 'turretSlotsLeft', 0, 1, 1, NULL), (101, 'launcherSlotsLeft', 0, 1, 1, NULL),
+			(1132, 'upgradeCapacity', 0, 1, 1, NULL), (1153, 'upgradeCost', 0, 1, 0, NULL),
+			(1547, 'rigSize', 0, 1, 1, NULL), (604, 'chargeGroup1', 0, 1, 1, NULL), (128, 'chargeSize', 0, 1, 1, NULL),
+			(280, 'skillLevel', 0, 1, 1, NULL), (424, 'cpuOutputBonus2', 0, 1, 1, NULL);
+
+		INSERT INTO dogmaEffects (_key, name, effectCategoryID, modifierInfo) VALUES
+			(11, 'loPower', 0, '[]'), (12, 'hiPower', 0, '[]'), (13, 'medPower', 0, '[]'),
+			(2663, 'rigSlot', 0, '[]'), (3772, 'subSystem', 0, '[]'),
+			(42, 'turretFitted', 0, '[]'), (40, 'launcherFitted', 0, '[]'),
+			(5000, 'skillLevelBonus', 0, '[{"domain":"itemID","func":"ItemModifier","modifiedAttributeID":424,"modifyingAttributeID":280,"operation":0}]'),
+			(5001, 'cpuOutputBonus', 0, '[{"domain":"shipID","func":"ItemModifier","modifiedAttributeID":48,"modifyingAttributeID":424,"operation":6}]');
+
+		INSERT INTO typeDogma (_key, dogmaAttributes, dogmaEffects) VALUES
+			(587, '[{"attributeID":14,"value":4},{"attributeID":13,"value":3},{"attributeID":12,"value":3},
+				{"attributeID":1137,"value":3},{"attributeID":102,"value":3},{"attributeID":101,"value":2},
+				{"attributeID":48,"value":100},{"attributeID":11,"value":41},{"attributeID":1132,"value":400},
+				{"attributeID":1547,"value":1}]', '[]'),
+			(2873, '[{"attributeID":50,"value":8},{"attributeID":30,"value":4},{"attributeID":604,"value":83},
+				{"attributeID":128,"value":1}]', '[{"effectID":12},{"effectID":42}]'),
+			(2410, '[{"attributeID":50,"value":20},{"attributeID":30,"value":6},{"attributeID":604,"value":384},
+				{"attributeID":128,"value":1}]', '[{"effectID":12},{"effectID":40}]'),
+			(3841, '[{"attributeID":50,"value":26},{"attributeID":30,"value":23}]', '[{"effectID":13}]'),
+			(438, '[{"attributeID":50,"value":15},{"attributeID":30,"value":1}]', '[{"effectID":13}]'),
+			(519, '[{"attributeID":50,"value":20},{"attributeID":30,"value":1}]', '[{"effectID":11}]'),
+			(31788, '[{"attributeID":1153,"value":150},{"attributeID":1547,"value":1}]', '[{"effectID":2663}]'),
+			(31790, '[{"attributeID":1153,"value":150},{"attributeID":1547,"value":2}]', '[{"effectID":2663}]'),
+			(12608, '[{"attributeID":128,"value":1}]', '[]'),
+			(185, '[{"attributeID":128,"value":1}]', '[]'),
+			(12625, '[{"attributeID":128,"value":2}]', '[]'),
+			(210, '[{"attributeID":128,"value":1}]', '[]'),
+			(3426, '[{"attributeID":424,"value":5}]', '[{"effectID":5000},{"effectID":5001}]');
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	return db
+}
+
+func loadCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	c, err := LoadCatalog(setupTestDB(t))
+	if err != nil {
+		t.Fatalf("LoadCatalog failed: %v", err)
+	}
+	return c
+}
+
+func TestParseEFT(t *testing.T) {
+	c := loadCatalog(t)
+
+	f, err := c.ParseEFT(testEFT)
+	if err != nil {
+		t.Fatalf("ParseEFT failed: %v", err)
+	}
+	if f.ShipTypeID != 587 || f.ShipName != "Rifter" || f.Name != "Test Fit" {
+		t.Errorf("ship = %d %q %q", f.ShipTypeID, f.ShipName, f.Name)
+	}
+	if len(f.Modules) != 9 {
+		t.Fatalf("modules = %d, want 9", len(f.Modules))
+	}
+
+	// Deutscher Name über types.name
+	if f.Modules[1].TypeID != 519 || f.Modules[1].Name != "Gyrostabilizer II" || f.Modules[1].Slot != SlotLow {
+		t.Errorf("localized module = %+v", f.Modules[1])
+	}
+	if m := f.Modules[6]; m.Slot != SlotHigh || m.ChargeTypeID != 12608 || !m.Offline {
+		t.Errorf("offline gatling = %+v", m)
+	}
+	if m := f.Modules[8]; m.TypeID != 31788 || m.Slot != SlotRig {
+		t.Errorf("rig = %+v", m)
+	}
+	if want := []Stack{{TypeID: 2488, Name: "Warrior II", Quantity: 2}}; !reflect.DeepEqual(f.Drones, want) {
+		t.Errorf("drones = %+v", f.Drones)
+	}
+	if want := []Stack{{TypeID: 185, Name: "EMP S", Quantity: 100}}; !reflect.DeepEqual(f.Cargo, want) {
+		t.Errorf("cargo = %+v", f.Cargo)
+	}
+}
+
+func TestParseEFT_Errors(t *testing.T) {
+	c := loadCatalog(t)
+
+	tests := []struct {
+		name string
+		text string
+		want error
+	}{
+		{"ohne Kopfzeile", "Gyrostabilizer II\n", ErrSyntax},
+		{"leer", "\n\n", ErrSyntax},
+		{"unbekanntes Modul", "[Rifter, X]\nWarp Core Stabilizer I\n", ErrUnknownName},
+		{"kein Schiff", "[Gyrostabilizer II, X]\n", ErrNotFittable},
+		{"Munition ohne Slot", "[Rifter, X]\nTritanium, Hail S\n", ErrNotFittable},
+	}
+	for _, tt := range tests {
+		if _, err := c.ParseEFT(tt.text); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestEFT_RoundTrip(t *testing.T) {
+	c := loadCatalog(t)
+
+	f, err := c.ParseEFT(testEFT)
+	if err != nil {
+		t.Fatalf("ParseEFT failed: %v", err)
+	}
+	text, err := c.EFT(f, "")
+	if err != nil {
+		t.Fatalf("EFT failed: %v", err)
+	}
+	want := `[Rifter, Test Fit]
+Gyrostabilizer II
+Gyrostabilizer II
+
+Medium Shield Extender II
+1MN Afterburner II
+
+125mm Gatling AutoCannon II, Hail S
+125mm Gatling AutoCannon II, Hail S
+125mm Gatling AutoCannon II, Hail S /OFFLINE
+
+Small Projectile Burst Aerator I
+Small Projectile Burst Aerator I
+
+Warrior II x2
+
+EMP S x100
+`
+	if text != want {
+		t.Errorf("EFT =\n%s\nwant\n%s", text, want)
+	}
+
+	again, err := c.ParseEFT(text)
+	if err != nil {
+		t.Fatalf("ParseEFT (round trip) failed: %v", err)
+	}
+	if !reflect.DeepEqual(again, f) {
+		t.Errorf("round trip = %+v, want %+v", again, f)
+	}
+
+	// Namen in anderer Sprache mit Fallback Englisch
+	text, err = c.EFT(f, "de")
+	if err != nil {
+		t.Fatalf("EFT (de) failed: %v", err)
+	}
+	if again, err := c.ParseEFT(text); err != nil || !reflect.DeepEqual(again.Modules, f.Modules) {
+		t.Errorf("German round trip failed: %v\n%s", err, text)
+	}
+}
+
+func TestDNA(t *testing.T) {
+	c := loadCatalog(t)
+
+	f, err := c.ParseEFT(testEFT)
+	if err != nil {
+		t.Fatalf("ParseEFT failed: %v", err)
+	}
+	dna := f.DNA()
+	if want := "587:2873;3:3841;1:438;1:519;2:31788;2:12608;3:2488;2:185_;100::"; dna != want {
+		t.Errorf("DNA = %s, want %s", dna, want)
+	}
+
+	parsed, err := c.ParseDNA(dna)
+	if err != nil {
+		t.Fatalf("ParseDNA failed: %v", err)
+	}
+	if parsed.DNA() != dna {
+		t.Errorf("round trip DNA = %s", parsed.DNA())
+	}
+	if len(parsed.Modules) != 9 || parsed.Modules[0].ChargeTypeID != 12608 || parsed.Modules[2].ChargeTypeID != 12608 {
+		t.Errorf("modules = %+v", parsed.Modules)
+	}
+
+	// Überzählige Munition und Typen ohne Slot landen im Frachtraum
+	parsed, err = c.ParseDNA("587:2873;2:12608;5:34;10::")
+	if err != nil {
+		t.Fatalf("ParseDNA failed: %v", err)
+	}
+	want := []Stack{{TypeID: 34, Name: "Tritanium", Quantity: 10}, {TypeID: 12608, Name: "Hail S", Quantity: 3}}
+	if !reflect.DeepEqual(parsed.Cargo, want) {
+		t.Errorf("cargo = %+v, want %+v", parsed.Cargo, want)
+	}
+
+	for _, dna := range []string{"", "587:abc;1::", "587:2873;0::", "34::"} {
+		if _, err := c.ParseDNA(dna); err == nil {
+			t.Errorf("ParseDNA(%q) succeeded", dna)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := loadCatalog(t)
+
+	parse := func(t *testing.T) *Fit {
+		t.Helper()
+		f, err := c.ParseEFT(testEFT)
+		if err != nil {
+			t.Fatalf("ParseEFT failed: %v", err)
+		}
+		return f
+	}
+	module := func(id int64, slot Slot) Module { return Module{TypeID: id, Slot: slot} }
+
+	tests := []struct {
+		name   string
+		modify func(f *Fit)
+		skills map[int64]int
+		want   []Rule
+	}{
+		{"gültig", func(f *Fit) {}, testSkills, nil},
+		// 8 + 8 + 40 + 41 = 97 tf ohne Offline-Modul; mit allen Modulen 105 tf > 100 tf ohne Skill
+		{"CPU ohne Skill", func(f *Fit) { f.Modules[6].Offline = false }, nil, []Rule{RuleCPU}},
+		{"CPU mit Skill", func(f *Fit) { f.Modules[6].Offline = false }, testSkills, nil},
+		{"Powergrid", func(f *Fit) { f.Modules[3] = module(3841, SlotMid) }, testSkills, []Rule{RulePowergrid}},
+		// Slots zählen auch für Offline-Module
+		{"Low Slots", func(f *Fit) {
+			f.Modules = append(f.Modules, Module{TypeID: 519, Slot: SlotLow, Offline: true}, Module{TypeID: 519, Slot: SlotLow, Offline: true})
+		}, testSkills, []Rule{RuleSlots}},
+		{"Turrets", func(f *Fit) { f.Modules = append(f.Modules, module(2873, SlotHigh)) }, testSkills, []Rule{RuleHardpoints}},
+		// Drei Launcher statt der Kanonen: 2 Launcher-Hardpoints, 141 tf CPU, 44 MW Powergrid
+		{"Launcher", func(f *Fit) {
+			f.Modules = append(f.Modules[:4], append([]Module{module(2410, SlotHigh), module(2410, SlotHigh), module(2410, SlotHigh)}, f.Modules[7:]...)...)
+		}, testSkills, []Rule{RuleHardpoints, RuleCPU, RulePowergrid}},
+		{"Rig-Größe", func(f *Fit) { f.Modules[8] = module(31790, SlotRig) }, testSkills, []Rule{RuleRigSize}},
+		{"Kalibrierung", func(f *Fit) { f.Modules = append(f.Modules, module(31788, SlotRig)) }, testSkills, []Rule{RuleCalibration}},
+		{"falscher Slot", func(f *Fit) { f.Modules[0].Slot = SlotMid }, testSkills, []Rule{RuleSlots}},
+		{"Munitionsgröße", func(f *Fit) { f.Modules[4].ChargeTypeID = 12625 }, testSkills, []Rule{RuleCharge}},
+		{"Munitionsgruppe", func(f *Fit) { f.Modules[4].ChargeTypeID = 210 }, testSkills, []Rule{RuleCharge}},
+		{"Modul ohne Munition", func(f *Fit) { f.Modules[0].ChargeTypeID = 12608 }, testSkills, []Rule{RuleCharge}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := parse(t)
+			tt.modify(f)
+			vs, err := c.Validate(f, tt.skills)
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			var got []Rule
+			for _, v := range vs {
+				got = append(got, v.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rules = %v, want %v (%+v)", got, tt.want, vs)
+			}
+		})
+	}
+
+	if _, err := c.Validate(&Fit{ShipTypeID: 99}, nil); !errors.Is(err, dogma.ErrUnknownType) {
+		t.Errorf("unknown ship: err = %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package fitting
+
+import (
+	"fmt"
+)
+
+// Rule ist die verletzte Fitting-Regel
+type Rule string
+
+const (
+	RuleSlots       Rule = "slots"
+	RuleHardpoints  Rule = "hardpoints"
+	RuleRigSize     Rule = "rig-size"
+	RuleCalibration Rule = "calibration"
+	RuleCPU         Rule = "cpu"
+	RulePowergrid   Rule = "powergrid"
+	RuleCharge      Rule = "charge"
+)
+
+// Violation ist ein Verstoß gegen eine Fitting-Regel
+type Violation struct {
+	Rule Rule `json:"rule"`
+	// Module: Index in Fit.Modules, -1 für schiffsweite Regeln
+	Module  int    `json:"module"`
+	Message string `json:"message"`
+}
+
+// slotAttributes: Schiffsattribute mit der Anzahl Slots je Art
+var slotAttributes = map[Slot]string{
+	SlotHigh:      "hiSlots",
+	SlotMid:       "medSlots",
+	SlotLow:       "lowSlots",
+	SlotRig:       "rigSlots",
+	SlotSubsystem: "maxSubSystems",
+}
+
+// tolerance gleicht Rundungsfehler bei CPU, Powergrid und Kalibrierung aus
+const tolerance = 1e-6
+
+// Validate prüft ein Fitting gegen die per Dogma berechneten Werte (Skills: TypeID → Stufe, nil = keine Skills).
+// Geprüft werden Slots je Art, Turret-/Launcher-Hardpoints, Rig-Größe, Kalibrierung, CPU und Powergrid der
+// online Module sowie die Munition (chargeGroup1..5, chargeSize, Volumen).
+func (c *Catalog) Validate(f *Fit, skills map[int64]int) ([]Violation, error) {
+	if _, err := c.ship(f.ShipTypeID); err != nil {
+		return nil, err
+	}
+	r, err := c.dogma.Evaluate(f.DogmaFit(skills))
+	if err != nil {
+		return nil, err
+	}
+	ship := r.Ship.Attributes
+
+	var vs []Violation
+	add := func(rule Rule, module int, format string, args ...any) {
+		vs = append(vs, Violation{Rule: rule, Module: module, Message: fmt.Sprintf(format, args...)})
+	}
+
+	used := make(map[Slot]int)
+	var turrets, launchers int
+	var cpu, power, calibration float64
+	for i, m := range f.Modules {
+		t, err := c.dogma.Type(m.TypeID)
+		if err != nil {
+			return nil, err
+		}
+		if slot, ok := c.slot(t); !ok || slot != m.Slot {
+			add(RuleSlots, i, "%s does not fit into a %s slot", t.Name, m.Slot)
+		}
+		used[m.Slot]++
+		if c.hasEffect(t, "turretFitted") {
+			turrets++
+		}
+		if c.hasEffect(t, "launcherFitted") {
+			launchers++
+		}
+
+		values := r.Modules[i].Attributes
+		if m.Slot == SlotRig {
+			if size := ship["rigSize"]; values["rigSize"] != size {
+				add(RuleRigSize, i, "%s has rig size %g, ship requires %g", t.Name, values["rigSize"], size)
+			}
+			calibration += values["upgradeCost"]
+		}
+		if !m.Offline {
+			cpu += values["cpu"]
+			power += values["power"]
+		}
+
+		if m.ChargeTypeID != 0 {
+			charge, err := c.dogma.Type(m.ChargeTypeID)
+			if err != nil {
+				return nil, err
+			}
+			if msg := c.chargeProblem(t, charge); msg != "" {
+				add(RuleCharge, i, "%s", msg)
+			}
+		}
+	}
+
+	for _, slot := range []Slot{SlotHigh, SlotMid, SlotLow, SlotRig, SlotSubsystem} {
+		if n, limit := used[slot], int(ship[slotAttributes[slot]]); n > limit {
+			add(RuleSlots, -1, "%d %s modules, ship has %d slots", n, slot, limit)
+		}
+	}
+	if limit := int(ship["turretSlotsLeft"]); turrets > limit {
+		add(RuleHardpoints, -1, "%d turrets, ship has %d turret hardpoints", turrets, limit)
+	}
+	if limit := int(ship["launcherSlotsLeft"]); launchers > limit {
+		add(RuleHardpoints, -1, "%d launchers, ship has %d launcher hardpoints", launchers, limit)
+	}
+	if limit := ship["upgradeCapacity"]; calibration > limit+tolerance {
+		add(RuleCalibration, -1, "rigs use %g calibration, ship has %g", calibration, limit)
+	}
+	if limit := ship["cpuOutput"]; cpu > limit+tolerance {
+		add(RuleCPU, -1, "modules use %.2f tf CPU, ship has %.2f tf", cpu, limit)
+	}
+	if limit := ship["powerOutput"]; power > limit+tolerance {
+		add(RulePowergrid, -1, "modules use %.2f MW powergrid, ship has %.2f MW", power, limit)
+	}
+	return vs, nil
+}
@@ -0,0 +1,296 @@
+package industry
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Grenzen der Blueprint-Forschung
+const (
+	MaxME = 10
+	MaxTE = 20
+)
+
+// Bonus ist ein Multiplikator auf Materialbedarf bzw. Bauzeit (0 = kein Bonus)
+type Bonus struct {
+	// Material: z.B. 0.99 für einen Engineering Complex, 0.958 für ein T1-Rig in Null-Sec
+	Material float64 `json:"material"`
+	// Time: z.B. 0.85 für einen Azbel
+	Time float64 `json:"time"`
+}
+
+func (b Bonus) material() float64 {
+	if b.Material == 0 {
+		return 1
+	}
+	return b.Material
+}
+
+func (b Bonus) time() float64 {
+	if b.Time == 0 {
+		return 1
+	}
+	return b.Time
+}
+
+// Options steuert die Expansion eines Bauplans
+type Options struct {
+	// Runs: Runs des Produkt-Blueprints (0 = 1)
+	Runs int64
+	// ME/TE: Forschungsstufe des Produkt-Blueprints
+	ME int
+	TE int
+	// ComponentME/ComponentTE: Forschungsstufe aller Blueprints von Zwischenprodukten
+	ComponentME int
+	ComponentTE int
+	// Structure/Rig: Boni für Manufacturing-Jobs
+	Structure Bonus
+	Rig       Bonus
+	// Reaction: Kombinierter Struktur- und Rig-Bonus für Reaktionen (Reaktionen haben kein ME/TE)
+	Reaction Bonus
+	// Buy: Typen, die eingekauft statt gebaut werden (gelten als Rohmaterial)
+	Buy map[int64]bool
+}
+
+func (o Options) validate() error {
+	if o.Runs < 0 {
+		return fmt.Errorf("%w: runs %d", ErrInvalidOptions, o.Runs)
+	}
+	for _, me := range []int{o.ME, o.ComponentME} {
+		if me < 0 || me > MaxME {
+			return fmt.Errorf("%w: ME %d not in 0..%d", ErrInvalidOptions, me, MaxME)
+		}
+	}
+	for _, te := range []int{o.TE, o.ComponentTE} {
+		if te < 0 || te > MaxTE {
+			return fmt.Errorf("%w: TE %d not in 0..%d", ErrInvalidOptions, te, MaxTE)
+		}
+	}
+	for _, b := range []Bonus{o.Structure, o.Rig, o.Reaction} {
+		if b.Material < 0 || b.Time < 0 {
+			return fmt.Errorf("%w: negative bonus %+v", ErrInvalidOptions, b)
+		}
+	}
+	return nil
+}
+
+// Item ist ein Typ mit Menge
+type Item struct {
+	TypeID   int64  `json:"type_id"`
+	Name     string `json:"name,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+// Job ist ein Manufacturing- bzw. Reaction-Job
+type Job struct {
+	TypeID          int64  `json:"type_id"`
+	Name            string `json:"name,omitempty"`
+	BlueprintTypeID int64  `json:"blueprint_type_id"`
+	Activity        string `json:"activity"`
+	Runs            int64  `json:"runs"`
+	// Quantity: Benötigte Menge, Produced: hergestellte Menge (≥ Quantity, Rest ist Überschuss)
+	Quantity int64 `json:"quantity"`
+	Produced int64 `json:"produced"`
+	ME       int   `json:"me"`
+	TE       int   `json:"te"`
+	// Time: Bauzeit in Sekunden (ohne Skills)
+	Time      float64 `json:"time"`
+	Materials []Item  `json:"materials"`
+}
+
+// Node ist ein Knoten des Bauplan-Baums. Job ist nil für Rohmaterial und eingekaufte Typen.
+type Node struct {
+	Item
+	Job      *Job    `json:"job,omitempty"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+// BOM ist der expandierte Bauplan eines Produkts
+type BOM struct {
+	TypeID int64 `json:"type_id"`
+	Runs   int64 `json:"runs"`
+	// Tree: Baum je Zweig; jedes Vorkommen eines Zwischenprodukts ist ein eigener Job
+	Tree *Node `json:"tree"`
+	// Jobs: Je Zwischenprodukt ein Job über den Gesamtbedarf, in Bau-Reihenfolge (Vorprodukte zuerst)
+	Jobs []Job `json:"jobs"`
+	// RawMaterials: Gesamtbedarf an Rohmaterial bei zusammengefassten Jobs
+	RawMaterials []Item `json:"raw_materials"`
+	// Time: Summe der Bauzeiten aller Jobs in Sekunden
+	Time float64 `json:"time"`
+}
+
+// Expand expandiert ein Produkt rekursiv bis zu Rohmaterialien (Mineralien, PI, Moon Materials, ...).
+// Reaktionen werden ebenfalls expandiert; Typen in Options.Buy gelten als Rohmaterial.
+func (c *Catalog) Expand(typeID int64, opts Options) (*BOM, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	root, ok := c.producers[typeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNoBlueprint, typeID)
+	}
+	runs := opts.Runs
+	if runs == 0 {
+		runs = 1
+	}
+
+	order, err := c.buildOrder(typeID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	bom := &BOM{TypeID: typeID, Runs: runs}
+	bom.Tree = c.node(typeID, runs*root.ProductQuantity, runs, true, opts, map[int64]bool{})
+
+	// Gesamtbedarf: Zwischenprodukte in topologischer Reihenfolge, damit alle Verbraucher vorher addiert sind
+	demand := map[int64]int64{typeID: runs * root.ProductQuantity}
+	raw := make(map[int64]int64)
+	for _, id := range order {
+		jobRuns := ceilDiv(demand[id], c.producers[id].ProductQuantity)
+		if id == typeID {
+			jobRuns = runs
+		}
+		job := c.job(id, demand[id], jobRuns, id == typeID, opts)
+		for _, m := range job.Materials {
+			if c.expandable(m.TypeID, opts) {
+				demand[m.TypeID] += m.Quantity
+			} else {
+				raw[m.TypeID] += m.Quantity
+			}
+		}
+		bom.Jobs = append(bom.Jobs, job)
+		bom.Time += job.Time
+	}
+	for i, j := 0, len(bom.Jobs)-1; i < j; i, j = i+1, j-1 {
+		bom.Jobs[i], bom.Jobs[j] = bom.Jobs[j], bom.Jobs[i]
+	}
+
+	bom.RawMaterials = c.items(raw)
+	return bom, nil
+}
+
+// expandable prüft ob ein Typ gebaut statt eingekauft wird
+func (c *Catalog) expandable(typeID int64, opts Options) bool {
+	_, ok := c.producers[typeID]
+	return ok && !opts.Buy[typeID]
+}
+
+// buildOrder liefert alle zu bauenden Typen, jeder Typ vor seinen Vorprodukten
+func (c *Catalog) buildOrder(typeID int64, opts Options) ([]int64, error) {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[int64]int)
+	var post []int64
+
+	var visit func(id int64) error
+	visit = func(id int64) error {
+		switch state[id] {
+		case active:
+			return fmt.Errorf("%w: type %d", ErrCycle, id)
+		case done:
+			return nil
+		}
+		state[id] = active
+		for _, m := range c.producers[id].Materials {
+			if c.expandable(m.TypeID, opts) {
+				if err := visit(m.TypeID); err != nil {
+					return err
+				}
+			}
+		}
+		state[id] = done
+		post = append(post, id)
+		return nil
+	}
+	if err := visit(typeID); err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(post)-1; i < j; i, j = i+1, j-1 {
+		post[i], post[j] = post[j], post[i]
+	}
+	return post, nil
+}
+
+// node expandiert einen Zweig des Baums
+func (c *Catalog) node(typeID, quantity, runs int64, root bool, opts Options, path map[int64]bool) *Node {
+	n := &Node{Item: Item{TypeID: typeID, Name: c.names[typeID], Quantity: quantity}}
+	if (!root && !c.expandable(typeID, opts)) || path[typeID] {
+		return n
+	}
+
+	job := c.job(typeID, quantity, runs, root, opts)
+	n.Job = &job
+	path[typeID] = true
+	for _, m := range job.Materials {
+		childRuns := int64(0)
+		if bp, ok := c.producers[m.TypeID]; ok {
+			childRuns = ceilDiv(m.Quantity, bp.ProductQuantity)
+		}
+		n.Children = append(n.Children, c.node(m.TypeID, m.Quantity, childRuns, false, opts, path))
+	}
+	delete(path, typeID)
+	return n
+}
+
+// job berechnet Materialbedarf und Bauzeit eines Jobs
+func (c *Catalog) job(typeID, quantity, runs int64, root bool, opts Options) Job {
+	bp := c.producers[typeID]
+	job := Job{
+		TypeID:          typeID,
+		Name:            c.names[typeID],
+		BlueprintTypeID: bp.BlueprintTypeID,
+		Activity:        bp.Activity,
+		Runs:            runs,
+		Quantity:        quantity,
+		Produced:        runs * bp.ProductQuantity,
+	}
+
+	materialFactor, timeFactor := opts.Reaction.material(), opts.Reaction.time()
+	if bp.Activity == ActivityManufacturing {
+		job.ME, job.TE = opts.ComponentME, opts.ComponentTE
+		if root {
+			job.ME, job.TE = opts.ME, opts.TE
+		}
+		materialFactor = (1 - float64(job.ME)/100) * opts.Structure.material() * opts.Rig.material()
+		timeFactor = (1 - float64(job.TE)/100) * opts.Structure.time() * opts.Rig.time()
+	}
+
+	for _, m := range bp.Materials {
+		job.Materials = append(job.Materials, Item{
+			TypeID:   m.TypeID,
+			Name:     c.names[m.TypeID],
+			Quantity: MaterialQuantity(m.Quantity, runs, materialFactor),
+		})
+	}
+	job.Time = float64(bp.Time) * float64(runs) * timeFactor
+	return job
+}
+
+// MaterialQuantity berechnet den Materialbedarf eines Jobs nach der EVE-Formel:
+// max(runs, ceil(round(base × runs × factor, 2))). factor ist das Produkt aus (1 - ME/100) und allen Boni.
+func MaterialQuantity(base, runs int64, factor float64) int64 {
+	q := math.Round(float64(base)*float64(runs)*factor*100) / 100
+	return max(runs, int64(math.Ceil(q)))
+}
+
+func ceilDiv(a, b int64) int64 {
+	if b <= 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
+
+// items wandelt eine Mengen-Map in eine nach TypeID sortierte Liste
+func (c *Catalog) items(quantities map[int64]int64) []Item {
+	items := make([]Item, 0, len(quantities))
+	for id, q := range quantities {
+		items = append(items, Item{TypeID: id, Name: c.names[id], Quantity: q})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].TypeID < items[j].TypeID })
+	return items
+}
@@ -0,0 +1,173 @@
+package industry
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Fixture-Typen
+const (
+	tritanium = 34
+	fuelBlock = 4051
+	moonGoo   = 16633
+	ship      = 1000
+	compA     = 2100
+	compB     = 2200
+	alloy     = 2300
+)
+
+// fixtureCatalog: Ship ← Tritanium, Component A, Component B; A/B ← Tritanium, Alloy (Reaktion, 200 je Run)
+func fixtureCatalog() *Catalog {
+	return NewCatalog([]Blueprint{
+		{BlueprintTypeID: 2000, Activity: ActivityManufacturing, ProductTypeID: ship, ProductQuantity: 1, Time: 6000,
+			Materials: []Material{{tritanium, 1000}, {compA, 10}, {compB, 5}}},
+		{BlueprintTypeID: 3100, Activity: ActivityManufacturing, ProductTypeID: compA, ProductQuantity: 1, Time: 600,
+			Materials: []Material{{tritanium, 100}, {alloy, 3}}},
+		{BlueprintTypeID: 3200, Activity: ActivityManufacturing, ProductTypeID: compB, ProductQuantity: 10, Time: 300,
+			Materials: []Material{{tritanium, 25}, {alloy, 2}}},
+		{BlueprintTypeID: 3300, Activity: ActivityReaction, ProductTypeID: alloy, ProductQuantity: 200, Time: 10800,
+			Materials: []Material{{moonGoo, 100}, {fuelBlock, 5}}},
+	}, map[int64]string{ship: "Ship", tritanium: "Tritanium"})
+}
+
+func TestMaterialQuantity(t *testing.T) {
+	tests := []struct {
+		base, runs int64
+		factor     float64
+		want       int64
+	}{
+		{1000, 1, 1, 1000},
+		{1000, 1, 0.9, 900},
+		{1000, 1, 0.9 * 0.99, 891},
+		{1, 10, 0.9, 10},        // nie weniger als ein Stück je Run
+		{10, 1, 0.9, 9},         // 9.000000000000002 → Rundung auf 2 Stellen verhindert 10
+		{3, 18, 0.9, 49},        // 48.6 → 49
+		{25, 1, 0.9 * 0.99, 23}, // 22.275 → 23
+	}
+	for _, tt := range tests {
+		if got := MaterialQuantity(tt.base, tt.runs, tt.factor); got != tt.want {
+			t.Errorf("MaterialQuantity(%d, %d, %v) = %d, want %d", tt.base, tt.runs, tt.factor, got, tt.want)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	c := fixtureCatalog()
+
+	bom, err := c.Expand(ship, Options{Runs: 2, ME: 10, TE: 20, ComponentME: 10})
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+
+	// Ship: 1800 Tritanium, 18 A, 9 B; A (18 Runs): 1620 Tritanium, 49 Alloy; B (1 Run): 23 Tritanium, 2 Alloy;
+	// Alloy: 51 → 1 Reaktion
+	wantRaw := []Item{
+		{TypeID: tritanium, Name: "Tritanium", Quantity: 1800 + 1620 + 23},
+		{TypeID: fuelBlock, Quantity: 5},
+		{TypeID: moonGoo, Quantity: 100},
+	}
+	if !reflect.DeepEqual(bom.RawMaterials, wantRaw) {
+		t.Errorf("RawMaterials = %+v, want %+v", bom.RawMaterials, wantRaw)
+	}
+
+	var order []int64
+	runs := make(map[int64]int64)
+	for _, j := range bom.Jobs {
+		order = append(order, j.TypeID)
+		runs[j.TypeID] = j.Runs
+	}
+	if want := []int64{alloy, compA, compB, ship}; !reflect.DeepEqual(order, want) {
+		t.Errorf("job order = %v, want %v", order, want)
+	}
+	if want := map[int64]int64{ship: 2, compA: 18, compB: 1, alloy: 1}; !reflect.DeepEqual(runs, want) {
+		t.Errorf("job runs = %v, want %v", runs, want)
+	}
+	if j := bom.Jobs[0]; j.Quantity != 51 || j.Produced != 200 {
+		t.Errorf("alloy job quantity/produced = %d/%d, want 51/200", j.Quantity, j.Produced)
+	}
+	if j := bom.Jobs[3]; j.Time != 6000*2*0.8 || j.ME != 10 || j.TE != 20 {
+		t.Errorf("ship job = %+v", j)
+	}
+
+	// Im Baum hat jeder Zweig einen eigenen Alloy-Job
+	if len(bom.Tree.Children) != 3 || bom.Tree.Job == nil || bom.Tree.Quantity != 2 {
+		t.Fatalf("tree root = %+v", bom.Tree)
+	}
+	b := bom.Tree.Children[2]
+	if b.TypeID != compB || b.Quantity != 9 || b.Job == nil || b.Job.Runs != 1 {
+		t.Errorf("tree component B = %+v", b)
+	}
+	if a := b.Children[1]; a.TypeID != alloy || a.Quantity != 2 || a.Job == nil || a.Job.Runs != 1 {
+		t.Errorf("tree alloy under B = %+v", a)
+	}
+	if leaf := bom.Tree.Children[0]; leaf.Job != nil || leaf.Quantity != 1800 {
+		t.Errorf("tree tritanium = %+v", leaf)
+	}
+}
+
+func TestExpand_Bonuses(t *testing.T) {
+	c := fixtureCatalog()
+
+	bom, err := c.Expand(compA, Options{
+		Runs:      10,
+		ME:        10,
+		Structure: Bonus{Material: 0.99, Time: 0.85},
+		Rig:       Bonus{Material: 0.958},
+		Reaction:  Bonus{Material: 0.976},
+		Buy:       map[int64]bool{},
+	})
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+
+	// A: 100×10×0.9×0.99×0.958 = 853.578 → 854, Alloy 3×10×… = 25.6 → 26; Reaktion: 100×0.976 → 98
+	want := []Item{{TypeID: tritanium, Name: "Tritanium", Quantity: 854}, {TypeID: fuelBlock, Quantity: 5}, {TypeID: moonGoo, Quantity: 98}}
+	if !reflect.DeepEqual(bom.RawMaterials, want) {
+		t.Errorf("RawMaterials = %+v, want %+v", bom.RawMaterials, want)
+	}
+	if got := bom.Jobs[1].Time; got != 600*10*0.85 {
+		t.Errorf("component job time = %v, want %v", got, 600*10*0.85)
+	}
+}
+
+func TestExpand_Buy(t *testing.T) {
+	c := fixtureCatalog()
+
+	bom, err := c.Expand(ship, Options{Buy: map[int64]bool{alloy: true, compB: true}})
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	want := []Item{
+		{TypeID: tritanium, Name: "Tritanium", Quantity: 2000},
+		{TypeID: compB, Quantity: 5},
+		{TypeID: alloy, Quantity: 30},
+	}
+	if !reflect.DeepEqual(bom.RawMaterials, want) {
+		t.Errorf("RawMaterials = %+v, want %+v", bom.RawMaterials, want)
+	}
+	if len(bom.Jobs) != 2 {
+		t.Errorf("jobs = %d, want 2", len(bom.Jobs))
+	}
+}
+
+func TestExpand_Errors(t *testing.T) {
+	c := fixtureCatalog()
+
+	if _, err := c.Expand(tritanium, Options{}); !errors.Is(err, ErrNoBlueprint) {
+		t.Errorf("raw material: err = %v, want ErrNoBlueprint", err)
+	}
+	for _, opts := range []Options{{ME: 11}, {ComponentTE: 21}, {Runs: -1}, {Rig: Bonus{Material: -1}}} {
+		if _, err := c.Expand(ship, opts); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("%+v: err = %v, want ErrInvalidOptions", opts, err)
+		}
+	}
+
+	cyclic := NewCatalog([]Blueprint{
+		{BlueprintTypeID: 1, Activity: ActivityManufacturing, ProductTypeID: 10, ProductQuantity: 1, Materials: []Material{{20, 1}}},
+		{BlueprintTypeID: 2, Activity: ActivityManufacturing, ProductTypeID: 20, ProductQuantity: 1, Materials: []Material{{10, 1}}},
+	}, nil)
+	if _, err := cyclic.Expand(10, Options{}); !errors.Is(err, ErrCycle) {
+		t.Errorf("cycle: err = %v, want ErrCycle", err)
+	}
+}
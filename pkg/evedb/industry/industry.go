@@ -0,0 +1,165 @@
+// Package industry expandiert Blueprints einer eve-sde Datenbank zu Bauplänen (Bill of Materials)
+// unter ME/TE-Stufen, Run-Anzahl sowie Struktur- und Rig-Boni
+package industry
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrNoBlueprint: Kein Manufacturing- bzw. Reaction-Blueprint stellt den Typ her
+	ErrNoBlueprint = errors.New("no blueprint produces type")
+	// ErrInvalidOptions: Ungültige ME/TE-Stufen, Runs, Boni oder Skill-Stufen
+	ErrInvalidOptions = errors.New("invalid industry options")
+	// ErrCycle: Die Blueprints bilden einen Kreis (Produkt ist eigenes Vorprodukt)
+	ErrCycle = errors.New("blueprint cycle")
+)
+
+// Aktivitäten, die ein Produkt herstellen und expandiert werden
+const (
+	ActivityManufacturing = "manufacturing"
+	ActivityReaction      = "reaction"
+)
+
+// Material ist ein Input eines Blueprints je Run (ME 0)
+type Material struct {
+	TypeID   int64 `json:"type_id"`
+	Quantity int64 `json:"quantity"`
+}
+
+// Blueprint ist eine herstellende Aktivität (Manufacturing oder Reaction) eines Blueprints
+type Blueprint struct {
+	BlueprintTypeID int64  `json:"blueprint_type_id"`
+	Activity        string `json:"activity"`
+	ProductTypeID   int64  `json:"product_type_id"`
+	ProductQuantity int64  `json:"product_quantity"`
+	// Time: Basiszeit je Run in Sekunden
+	Time      int64      `json:"time"`
+	Materials []Material `json:"materials"`
+}
+
+// Catalog ordnet jedem Produkt seinen Blueprint zu
+type Catalog struct {
+	producers map[int64]Blueprint
+	names     map[int64]string
+}
+
+// NewCatalog erstellt einen Katalog. Stellen mehrere Blueprints dasselbe Produkt her,
+// gewinnt die kleinste Blueprint-ID (wie v_bom_tree).
+func NewCatalog(blueprints []Blueprint, names map[int64]string) *Catalog {
+	c := &Catalog{
+		producers: make(map[int64]Blueprint, len(blueprints)),
+		names:     make(map[int64]string, len(names)),
+	}
+	for _, bp := range blueprints {
+		if bp.ProductQuantity <= 0 {
+			continue
+		}
+		if existing, ok := c.producers[bp.ProductTypeID]; ok && existing.BlueprintTypeID <= bp.BlueprintTypeID {
+			continue
+		}
+		c.producers[bp.ProductTypeID] = bp
+	}
+	for id, name := range names {
+		c.names[id] = name
+	}
+	return c
+}
+
+// LoadCatalog lädt alle Manufacturing- und Reaction-Blueprints aus v_blueprint_products und v_blueprint_materials
+func LoadCatalog(db *sql.DB) (*Catalog, error) {
+	names := make(map[int64]string)
+	type key struct {
+		blueprintID int64
+		activity    string
+	}
+	byKey := make(map[key]*Blueprint)
+	var order []key
+
+	rows, err := db.Query(`SELECT blueprint_type_id, activity, product_type_id, COALESCE(product_name, ''),
+		COALESCE(quantity, 0), COALESCE(time, 0)
+		FROM v_blueprint_products
+		WHERE activity IN (?, ?)
+		ORDER BY blueprint_type_id, activity, product_type_id`, ActivityManufacturing, ActivityReaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blueprint products: %w", err)
+	}
+	for rows.Next() {
+		var bp Blueprint
+		var name string
+		if err := rows.Scan(&bp.BlueprintTypeID, &bp.Activity, &bp.ProductTypeID, &name, &bp.ProductQuantity, &bp.Time); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan blueprint product: %w", err)
+		}
+		if name != "" {
+			names[bp.ProductTypeID] = name
+		}
+		// Ein Produkt je Aktivität; weitere Produkte (kommen im SDE nicht vor) werden ignoriert
+		k := key{bp.BlueprintTypeID, bp.Activity}
+		if _, ok := byKey[k]; !ok {
+			byKey[k] = &bp
+			order = append(order, k)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(`SELECT blueprint_type_id, activity, material_type_id, COALESCE(material_name, ''), COALESCE(quantity, 0)
+		FROM v_blueprint_materials
+		WHERE activity IN (?, ?)
+		ORDER BY blueprint_type_id, activity, material_type_id`, ActivityManufacturing, ActivityReaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blueprint materials: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var k key
+		var m Material
+		var name string
+		if err := rows.Scan(&k.blueprintID, &k.activity, &m.TypeID, &name, &m.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan blueprint material: %w", err)
+		}
+		if name != "" {
+			names[m.TypeID] = name
+		}
+		if bp, ok := byKey[k]; ok && m.Quantity > 0 {
+			bp.Materials = append(bp.Materials, m)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	blueprints := make([]Blueprint, 0, len(order))
+	for _, k := range order {
+		blueprints = append(blueprints, *byKey[k])
+	}
+	return NewCatalog(blueprints, names), nil
+}
+
+// Blueprint liefert den Blueprint, der ein Produkt herstellt
+func (c *Catalog) Blueprint(productTypeID int64) (Blueprint, bool) {
+	bp, ok := c.producers[productTypeID]
+	return bp, ok
+}
+
+// Name liefert den Namen eines Typs (leer falls unbekannt)
+func (c *Catalog) Name(typeID int64) string {
+	return c.names[typeID]
+}
+
+// Products liefert alle herstellbaren Produkte (sortiert)
+func (c *Catalog) Products() []int64 {
+	ids := make([]int64, 0, len(c.producers))
+	for id := range c.producers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -0,0 +1,125 @@
+package industry
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/blueprints"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/views"
+)
+
+// fixtureDB legt blueprints/types mit den Daten von fixtureCatalog an und erstellt Lookup-Tabellen und Industry-Views
+func fixtureDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t, "types", "blueprints", "typeDogma", "dogmaAttributes")
+
+	if _, err := db.Exec(`
+		INSERT INTO types (_key, name) VALUES (34, '{"en":"Tritanium"}'), (1000, '{"en":"Ship"}'), (2100, '{"en":"Component A"}'),
+			(2000, '{"en":"Ship Blueprint"}'), (2001, '{"en":"Ship II Blueprint"}'), (1001, '{"en":"Ship II"}'),
+			(20410, '{"en":"Datacore - Mechanical Engineering"}'), (20411, '{"en":"Datacore - Electronic Engineering"}'),
+			(11442, '{"en":"Mechanical Engineering"}'), (11453, '{"en":"Electronic Engineering"}'),
+			(21791, '{"en":"Minmatar Encryption Methods"}'),
+			(34201, '{"en":"Accelerant Decryptor"}'), (34206, '{"en":"Symmetry Decryptor"}');
+		INSERT INTO dogmaAttributes (_key, name) VALUES (182, 'requiredSkill1'), (1112, 'inventionPropabilityMultiplier'),
+			(1113, 'inventionMEModifier'), (1114, 'inventionTEModifier'), (1124, 'inventionMaxRunModifier');
+		INSERT INTO typeDogma (_key, dogmaAttributes) VALUES
+			(20410, '[{"attributeID":182,"value":11442}]'),
+			(20411, '[{"attributeID":182,"value":11453}]'),
+			(34201, '[{"attributeID":1112,"value":1.2},{"attributeID":1113,"value":2},{"attributeID":1114,"value":10},{"attributeID":1124,"value":1}]'),
+			(34206, '[{"attributeID":1112,"value":1.0},{"attributeID":1113,"value":1},{"attributeID":1114,"value":8},{"attributeID":1124,"value":2}]');
+		INSERT INTO blueprints (_key, activities, blueprintTypeID, maxProductionLimit) VALUES
+			(2000, '{"copying":{"time":4800},"manufacturing":{"materials":[{"quantity":1000,"typeID":34},{"quantity":10,"typeID":2100},{"quantity":5,"typeID":2200}],"products":[{"quantity":1,"typeID":1000}],"time":6000}}', 2000, 10),
+			(3100, '{"manufacturing":{"materials":[{"quantity":100,"typeID":34},{"quantity":3,"typeID":2300}],"products":[{"quantity":1,"typeID":2100}],"time":600}}', 3100, 100),
+			(3200, '{"manufacturing":{"materials":[{"quantity":25,"typeID":34},{"quantity":2,"typeID":2300}],"products":[{"quantity":10,"typeID":2200}],"time":300}}', 3200, 100),
+			(3300, '{"reaction":{"materials":[{"quantity":100,"typeID":16633},{"quantity":5,"typeID":4051}],"products":[{"quantity":200,"typeID":2300}],"time":10800}}', 3300, 1000),
+			(4000, '{"invention":{"materials":[{"quantity":2,"typeID":20410},{"quantity":2,"typeID":20411}],"products":[{"probability":0.3,"quantity":10,"typeID":2001}],"skills":[{"level":1,"typeID":11442},{"level":1,"typeID":11453},{"level":1,"typeID":21791}],"time":63900}}', 4000, 1),
+			(2001, '{"manufacturing":{"materials":[{"quantity":1,"typeID":1000}],"products":[{"quantity":1,"typeID":1001}],"time":12000}}', 2001, 1);
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+
+	if _, err := blueprints.BuildLookupTables(db); err != nil {
+		t.Fatalf("BuildLookupTables failed: %v", err)
+	}
+	if err := views.InitializeIndustryViews(db); err != nil {
+		t.Fatalf("InitializeIndustryViews failed: %v", err)
+	}
+	return db
+}
+
+func TestLoadCatalog(t *testing.T) {
+	db := fixtureDB(t)
+
+	c, err := LoadCatalog(db)
+	if err != nil {
+		t.Fatalf("LoadCatalog failed: %v", err)
+	}
+	if got := c.Products(); len(got) != 5 {
+		t.Errorf("Products = %v, want 5 (invention ignored)", got)
+	}
+	if c.Name(compA) != "Component A" {
+		t.Errorf("Name(compA) = %q", c.Name(compA))
+	}
+
+	bp, ok := c.Blueprint(alloy)
+	if !ok || bp.Activity != ActivityReaction || bp.ProductQuantity != 200 || len(bp.Materials) != 2 {
+		t.Errorf("Blueprint(alloy) = %+v", bp)
+	}
+
+	// Aus der DB geladen liefert der Katalog dasselbe Ergebnis wie die Fixture
+	opts := Options{Runs: 2, ME: 10, ComponentME: 10}
+	fromDB, err := c.Expand(ship, opts)
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	want, _ := fixtureCatalog().Expand(ship, opts)
+	if len(fromDB.RawMaterials) != len(want.RawMaterials) {
+		t.Fatalf("RawMaterials = %+v, want %+v", fromDB.RawMaterials, want.RawMaterials)
+	}
+	for i := range want.RawMaterials {
+		if fromDB.RawMaterials[i].Quantity != want.RawMaterials[i].Quantity {
+			t.Errorf("RawMaterials[%d] = %+v, want %+v", i, fromDB.RawMaterials[i], want.RawMaterials[i])
+		}
+	}
+}
+
+func TestBOMViews(t *testing.T) {
+	db := fixtureDB(t)
+
+	var depth int
+	if err := db.QueryRow(`SELECT MAX(depth) FROM v_bom_tree WHERE root_type_id = ?`, ship).Scan(&depth); err != nil {
+		t.Fatalf("Query tree failed: %v", err)
+	}
+	if depth != 3 {
+		t.Errorf("max depth = %d, want 3 (Ship → A → Alloy → Moon Goo)", depth)
+	}
+
+	// Je Ship (ME 0): Tritanium 1000 + 10×100 + 5×25/10, Alloy 10×3 + 5×2/10 = 31 → 31×100/200 Moon Goo
+	want := map[int64]float64{tritanium: 2012.5, moonGoo: 15.5, fuelBlock: 0.775}
+	rows, err := db.Query(`SELECT material_type_id, quantity FROM v_bom_raw_materials WHERE root_type_id = ?`, ship)
+	if err != nil {
+		t.Fatalf("Query raw materials failed: %v", err)
+	}
+	defer rows.Close()
+
+	got := make(map[int64]float64)
+	for rows.Next() {
+		var id int64
+		var q float64
+		if err := rows.Scan(&id, &q); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		got[id] = q
+	}
+	if len(got) != len(want) {
+		t.Fatalf("raw materials = %v, want %v", got, want)
+	}
+	for id, q := range want {
+		if math.Abs(got[id]-q) > 1e-9 {
+			t.Errorf("material %d = %v, want %v", id, got[id], q)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package industry
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ActivityInvention erzeugt T2-Blueprints aus T1-Kopien bzw. T3-Blueprints aus Relikten
+const ActivityInvention = "invention"
+
+// Werte eines erfundenen Blueprints ohne Decryptor
+const (
+	InventedME = 2
+	InventedTE = 4
+)
+
+// SkillRole ist die Rolle eines Skills in der Invention-Formel
+type SkillRole string
+
+const (
+	// RoleScience: Science-Skill eines Datacores, +1/30 je Stufe
+	RoleScience SkillRole = "science"
+	// RoleEncryption: Encryption-Skill, +1/40 je Stufe
+	RoleEncryption SkillRole = "encryption"
+)
+
+// InventionSkill ist ein für die Invention benötigter Skill
+type InventionSkill struct {
+	TypeID int64     `json:"type_id"`
+	Name   string    `json:"name,omitempty"`
+	Role   SkillRole `json:"role"`
+	// Required: Mindeststufe laut Blueprint, Level: übergebene Stufe des Charakters
+	Required int `json:"required"`
+	Level    int `json:"level"`
+}
+
+// Decryptor verändert Wahrscheinlichkeit, ME, TE und Runs des erfundenen Blueprints
+type Decryptor struct {
+	TypeID                int64   `json:"type_id"`
+	Name                  string  `json:"name"`
+	ProbabilityMultiplier float64 `json:"probability_multiplier"`
+	ME                    int     `json:"me"`
+	TE                    int     `json:"te"`
+	Runs                  int64   `json:"runs"`
+}
+
+// InventionBlueprint ist die Invention-Aktivität eines T1-Blueprints bzw. Relikts
+type InventionBlueprint struct {
+	SourceTypeID    int64      `json:"source_type_id"`
+	OutputTypeID    int64      `json:"output_type_id"`
+	BaseProbability float64    `json:"base_probability"`
+	BaseRuns        int64      `json:"base_runs"`
+	Time            int64      `json:"time"`
+	Materials       []Material `json:"materials"`
+	// Skills: Rollen sind bereits zugeordnet (Science = von einem Datacore verlangt)
+	Skills []InventionSkill `json:"skills"`
+}
+
+// ExpectedInput ist der erwartete Verbrauch eines Inputs
+type ExpectedInput struct {
+	TypeID     int64   `json:"type_id"`
+	Name       string  `json:"name,omitempty"`
+	PerAttempt int64   `json:"per_attempt"`
+	PerSuccess float64 `json:"per_success"`
+	// PerRun: Verbrauch je Run des erfundenen Blueprints
+	PerRun float64 `json:"per_run"`
+}
+
+// InventionOutcome ist das Ergebnis mit einem Decryptor (DecryptorTypeID 0 = ohne)
+type InventionOutcome struct {
+	DecryptorTypeID int64   `json:"decryptor_type_id,omitempty"`
+	DecryptorName   string  `json:"decryptor_name,omitempty"`
+	Probability     float64 `json:"probability"`
+	Runs            int64   `json:"runs"`
+	ME              int     `json:"me"`
+	TE              int     `json:"te"`
+	// Attempts: Erwartete Versuche je erfolgreichem Blueprint (1 / Probability)
+	Attempts float64 `json:"attempts"`
+	// Time: Erwartete Invention-Zeit je Erfolg in Sekunden (ohne Skills und Boni)
+	Time float64 `json:"time"`
+	// Inputs: Quelle (T1-Kopie-Run bzw. Relikt), Datacores und Decryptor
+	Inputs []ExpectedInput `json:"inputs"`
+}
+
+// Invention ist die Auswertung einer Invention-Quelle
+type Invention struct {
+	SourceTypeID    int64              `json:"source_type_id"`
+	SourceName      string             `json:"source_name,omitempty"`
+	OutputTypeID    int64              `json:"output_type_id"`
+	OutputName      string             `json:"output_name,omitempty"`
+	BaseProbability float64            `json:"base_probability"`
+	BaseRuns        int64              `json:"base_runs"`
+	Skills          []InventionSkill   `json:"skills"`
+	Outcomes        []InventionOutcome `json:"outcomes"`
+}
+
+// InventionData enthält alle Invention-Aktivitäten und Decryptoren
+type InventionData struct {
+	byOutput   map[int64][]InventionBlueprint
+	blueprints map[int64]int64
+	decryptors []Decryptor
+	names      map[int64]string
+}
+
+// NewInventionData erstellt die Invention-Daten. manufacturing ordnet Produkte ihrem Blueprint zu,
+// damit Invent auch mit dem T2/T3-Produkt statt dem Blueprint aufgerufen werden kann.
+func NewInventionData(inventions []InventionBlueprint, decryptors []Decryptor, manufacturing map[int64]int64, names map[int64]string) *InventionData {
+	d := &InventionData{
+		byOutput:   make(map[int64][]InventionBlueprint),
+		blueprints: make(map[int64]int64, len(manufacturing)),
+		decryptors: append([]Decryptor(nil), decryptors...),
+		names:      make(map[int64]string, len(names)),
+	}
+	for _, inv := range inventions {
+		d.byOutput[inv.OutputTypeID] = append(d.byOutput[inv.OutputTypeID], inv)
+	}
+	for id := range d.byOutput {
+		sources := d.byOutput[id]
+		sort.Slice(sources, func(i, j int) bool { return sources[i].SourceTypeID < sources[j].SourceTypeID })
+	}
+	for product, bp := range manufacturing {
+		d.blueprints[product] = bp
+	}
+	for id, name := range names {
+		d.names[id] = name
+	}
+	sort.Slice(d.decryptors, func(i, j int) bool { return d.decryptors[i].TypeID < d.decryptors[j].TypeID })
+	return d
+}
+
+// LoadInventionData lädt Invention-Aktivitäten, Datacore-Skills und Decryptoren
+// (v_blueprint_products, v_blueprint_materials, v_blueprint_skills, v_decryptors, typeDogma)
+func LoadInventionData(db *sql.DB) (*InventionData, error) {
+	names := make(map[int64]string)
+	byKey := make(map[[2]int64]*InventionBlueprint)
+	var order [][2]int64
+
+	rows, err := db.Query(`SELECT p.blueprint_type_id, COALESCE(json_extract(t.name, '$.en'), ''), p.product_type_id,
+		COALESCE(p.product_name, ''), COALESCE(p.probability, 0), COALESCE(p.quantity, 0), COALESCE(p.time, 0)
+		FROM v_blueprint_products p
+		LEFT JOIN types t ON t._key = p.blueprint_type_id
+		WHERE p.activity = ?
+		ORDER BY p.blueprint_type_id, p.product_type_id`, ActivityInvention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invention products: %w", err)
+	}
+	for rows.Next() {
+		var inv InventionBlueprint
+		var sourceName, outputName string
+		if err := rows.Scan(&inv.SourceTypeID, &sourceName, &inv.OutputTypeID, &outputName,
+			&inv.BaseProbability, &inv.BaseRuns, &inv.Time); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan invention product: %w", err)
+		}
+		names[inv.SourceTypeID], names[inv.OutputTypeID] = sourceName, outputName
+		k := [2]int64{inv.SourceTypeID, inv.OutputTypeID}
+		byKey[k] = &inv
+		order = append(order, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Materialien und Skills gelten für alle Produkte einer Invention-Aktivität
+	bySource := make(map[int64][]*InventionBlueprint)
+	for _, k := range order {
+		bySource[k[0]] = append(bySource[k[0]], byKey[k])
+	}
+
+	rows, err = db.Query(`SELECT blueprint_type_id, material_type_id, COALESCE(material_name, ''), COALESCE(quantity, 0)
+		FROM v_blueprint_materials WHERE activity = ? ORDER BY blueprint_type_id, material_type_id`, ActivityInvention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invention materials: %w", err)
+	}
+	for rows.Next() {
+		var source int64
+		var m Material
+		var name string
+		if err := rows.Scan(&source, &m.TypeID, &name, &m.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan invention material: %w", err)
+		}
+		names[m.TypeID] = name
+		for _, inv := range bySource[source] {
+			inv.Materials = append(inv.Materials, m)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Science-Skills: von einem Datacore verlangte Skills (requiredSkill1)
+	science := make(map[int64]bool)
+	rows, err = db.Query(`SELECT DISTINCT CAST(json_extract(a.value, '$.value') AS INTEGER)
+		FROM typeDogma d
+		JOIN json_each(d.dogmaAttributes) a
+		JOIN dogmaAttributes da ON da._key = json_extract(a.value, '$.attributeID')
+		WHERE da.name = 'requiredSkill1'
+		  AND d._key IN (SELECT material_type_id FROM v_blueprint_materials WHERE activity = ?)`, ActivityInvention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query datacore skills: %w", err)
+	}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan datacore skill: %w", err)
+		}
+		science[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(`SELECT blueprint_type_id, skill_type_id, COALESCE(skill_name, ''), COALESCE(level, 0)
+		FROM v_blueprint_skills WHERE activity = ? ORDER BY blueprint_type_id, skill_type_id`, ActivityInvention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query invention skills: %w", err)
+	}
+	for rows.Next() {
+		var source int64
+		var s InventionSkill
+		if err := rows.Scan(&source, &s.TypeID, &s.Name, &s.Required); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan invention skill: %w", err)
+		}
+		s.Role = RoleEncryption
+		if science[s.TypeID] {
+			s.Role = RoleScience
+		}
+		names[s.TypeID] = s.Name
+		for _, inv := range bySource[source] {
+			inv.Skills = append(inv.Skills, s)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var decryptors []Decryptor
+	rows, err = db.Query(`SELECT type_id, COALESCE(type_name, ''), probability_multiplier, me_modifier, te_modifier, run_modifier
+		FROM v_decryptors ORDER BY type_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query decryptors: %w", err)
+	}
+	for rows.Next() {
+		var dec Decryptor
+		if err := rows.Scan(&dec.TypeID, &dec.Name, &dec.ProbabilityMultiplier, &dec.ME, &dec.TE, &dec.Runs); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan decryptor: %w", err)
+		}
+		decryptors = append(decryptors, dec)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	manufacturing := make(map[int64]int64)
+	rows, err = db.Query(`SELECT product_type_id, MIN(blueprint_type_id) FROM v_blueprint_products
+		WHERE activity = ? GROUP BY product_type_id`, ActivityManufacturing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query manufacturing products: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var product, bp int64
+		if err := rows.Scan(&product, &bp); err != nil {
+			return nil, fmt.Errorf("failed to scan manufacturing product: %w", err)
+		}
+		manufacturing[product] = bp
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	inventions := make([]InventionBlueprint, 0, len(order))
+	for _, k := range order {
+		inventions = append(inventions, *byKey[k])
+	}
+	return NewInventionData(inventions, decryptors, manufacturing, names), nil
+}
+
+// Decryptors liefert alle Decryptoren
+func (d *InventionData) Decryptors() []Decryptor {
+	return d.decryptors
+}
+
+// Invent wertet alle Invention-Quellen eines T2/T3-Blueprints aus (für T3 je Relikt-Stufe eine).
+// typeID darf auch das T2/T3-Produkt sein. skills ordnet Skill-TypeIDs die Stufe des Charakters zu
+// (fehlend = 0, sonst 0–5). Je Quelle gibt es ein Ergebnis ohne und eines je Decryptor.
+func (d *InventionData) Invent(typeID int64, skills map[int64]int) ([]Invention, error) {
+	for id, level := range skills {
+		if level < 0 || level > 5 {
+			return nil, fmt.Errorf("%w: skill %d level %d", ErrInvalidOptions, id, level)
+		}
+	}
+
+	sources, ok := d.byOutput[typeID]
+	if !ok {
+		if bp, isProduct := d.blueprints[typeID]; isProduct {
+			sources, ok = d.byOutput[bp]
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: no invention for %d", ErrNoBlueprint, typeID)
+	}
+
+	inventions := make([]Invention, 0, len(sources))
+	for _, src := range sources {
+		inv := Invention{
+			SourceTypeID:    src.SourceTypeID,
+			SourceName:      d.names[src.SourceTypeID],
+			OutputTypeID:    src.OutputTypeID,
+			OutputName:      d.names[src.OutputTypeID],
+			BaseProbability: src.BaseProbability,
+			BaseRuns:        src.BaseRuns,
+		}
+
+		bonus := 1.0
+		for _, s := range src.Skills {
+			s.Level = skills[s.TypeID]
+			switch s.Role {
+			case RoleScience:
+				bonus += float64(s.Level) / 30
+			case RoleEncryption:
+				bonus += float64(s.Level) / 40
+			}
+			inv.Skills = append(inv.Skills, s)
+		}
+
+		inv.Outcomes = append(inv.Outcomes, d.outcome(src, bonus, Decryptor{ProbabilityMultiplier: 1}))
+		for _, dec := range d.decryptors {
+			inv.Outcomes = append(inv.Outcomes, d.outcome(src, bonus, dec))
+		}
+		inventions = append(inventions, inv)
+	}
+	return inventions, nil
+}
+
+// outcome berechnet Wahrscheinlichkeit, Blueprint-Werte und erwartete Inputs mit einem Decryptor
+func (d *InventionData) outcome(src InventionBlueprint, skillBonus float64, dec Decryptor) InventionOutcome {
+	o := InventionOutcome{
+		DecryptorTypeID: dec.TypeID,
+		DecryptorName:   dec.Name,
+		Probability:     math.Min(1, src.BaseProbability*skillBonus*dec.ProbabilityMultiplier),
+		Runs:            max(1, src.BaseRuns+dec.Runs),
+		ME:              InventedME + dec.ME,
+		TE:              InventedTE + dec.TE,
+	}
+	if o.Probability <= 0 {
+		return o
+	}
+	o.Attempts = 1 / o.Probability
+	o.Time = float64(src.Time) * o.Attempts
+
+	add := func(typeID, perAttempt int64) {
+		perSuccess := float64(perAttempt) * o.Attempts
+		o.Inputs = append(o.Inputs, ExpectedInput{
+			TypeID:     typeID,
+			Name:       d.names[typeID],
+			PerAttempt: perAttempt,
+			PerSuccess: perSuccess,
+			PerRun:     perSuccess / float64(o.Runs),
+		})
+	}
+	add(src.SourceTypeID, 1)
+	for _, m := range src.Materials {
+		add(m.TypeID, m.Quantity)
+	}
+	if dec.TypeID != 0 {
+		add(dec.TypeID, 1)
+	}
+	return o
+}
@@ -0,0 +1,98 @@
+package industry
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLoadInventionData(t *testing.T) {
+	db := fixtureDB(t)
+
+	d, err := LoadInventionData(db)
+	if err != nil {
+		t.Fatalf("LoadInventionData failed: %v", err)
+	}
+
+	decs := d.Decryptors()
+	if len(decs) != 2 {
+		t.Fatalf("Decryptors = %+v, want 2", decs)
+	}
+	if want := (Decryptor{TypeID: 34201, Name: "Accelerant Decryptor", ProbabilityMultiplier: 1.2, ME: 2, TE: 10, Runs: 1}); decs[0] != want {
+		t.Errorf("Decryptors[0] = %+v, want %+v", decs[0], want)
+	}
+
+	// Über das T2-Produkt aufgelöst: Ship II → Ship II Blueprint
+	inventions, err := d.Invent(1001, map[int64]int{11442: 4, 11453: 5, 21791: 3})
+	if err != nil {
+		t.Fatalf("Invent failed: %v", err)
+	}
+	if len(inventions) != 1 {
+		t.Fatalf("Invent = %+v, want 1 source", inventions)
+	}
+	inv := inventions[0]
+	if inv.SourceTypeID != 4000 || inv.OutputTypeID != 2001 || inv.OutputName != "Ship II Blueprint" || inv.BaseRuns != 10 {
+		t.Errorf("Invention = %+v", inv)
+	}
+
+	roles := make(map[int64]SkillRole)
+	for _, s := range inv.Skills {
+		roles[s.TypeID] = s.Role
+	}
+	if roles[11442] != RoleScience || roles[11453] != RoleScience || roles[21791] != RoleEncryption {
+		t.Errorf("skill roles = %v", roles)
+	}
+
+	if len(inv.Outcomes) != 3 {
+		t.Fatalf("Outcomes = %d, want 3 (none + 2 decryptors)", len(inv.Outcomes))
+	}
+
+	// 0.3 × (1 + 9/30 + 3/40) = 0.4125
+	none := inv.Outcomes[0]
+	if none.DecryptorTypeID != 0 || math.Abs(none.Probability-0.4125) > 1e-9 || none.Runs != 10 || none.ME != 2 || none.TE != 4 {
+		t.Errorf("outcome without decryptor = %+v", none)
+	}
+	if len(none.Inputs) != 3 {
+		t.Fatalf("inputs without decryptor = %+v, want source + 2 datacores", none.Inputs)
+	}
+
+	acc := inv.Outcomes[1]
+	if acc.DecryptorTypeID != 34201 || math.Abs(acc.Probability-0.495) > 1e-9 || acc.Runs != 11 || acc.ME != 4 || acc.TE != 14 {
+		t.Errorf("outcome with accelerant = %+v", acc)
+	}
+	if len(acc.Inputs) != 4 || acc.Inputs[3].TypeID != 34201 {
+		t.Fatalf("inputs with accelerant = %+v", acc.Inputs)
+	}
+	datacore := acc.Inputs[1]
+	if datacore.TypeID != 20410 || datacore.PerAttempt != 2 ||
+		math.Abs(datacore.PerSuccess-2/0.495) > 1e-9 || math.Abs(datacore.PerRun-2/0.495/11) > 1e-9 {
+		t.Errorf("datacore input = %+v", datacore)
+	}
+}
+
+func TestInventProbabilityCap(t *testing.T) {
+	d := NewInventionData(
+		[]InventionBlueprint{{SourceTypeID: 1, OutputTypeID: 2, BaseProbability: 0.9, BaseRuns: 1,
+			Skills: []InventionSkill{{TypeID: 10, Role: RoleScience}}}},
+		[]Decryptor{{TypeID: 3, ProbabilityMultiplier: 1.9, Runs: -4}},
+		nil, nil)
+
+	inventions, err := d.Invent(2, map[int64]int{10: 5})
+	if err != nil {
+		t.Fatalf("Invent failed: %v", err)
+	}
+	o := inventions[0].Outcomes[1]
+	if o.Probability != 1 || o.Attempts != 1 || o.Runs != 1 {
+		t.Errorf("outcome = %+v, want probability 1 and at least one run", o)
+	}
+
+	if _, err := d.Invent(99, nil); !errors.Is(err, ErrNoBlueprint) {
+		t.Errorf("Invent(99): err = %v, want ErrNoBlueprint", err)
+	}
+
+	for _, level := range []int{-1, 6} {
+		if _, err := d.Invent(2, map[int64]int{10: level}); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Invent with level %d: err = %v, want ErrInvalidOptions", level, err)
+		}
+	}
+}
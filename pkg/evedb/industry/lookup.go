@@ -0,0 +1,108 @@
+package industry
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownType: Typ-ID bzw. Name existiert nicht
+var ErrUnknownType = errors.New("unknown type")
+
+// Usage ist die Verwendung eines Materials in einer Blueprint-Aktivität
+type Usage struct {
+	MaterialTypeID  int64  `json:"material_type_id"`
+	Activity        string `json:"activity"`
+	Quantity        int64  `json:"quantity"`
+	BlueprintTypeID int64  `json:"blueprint_type_id"`
+	BlueprintName   string `json:"blueprint_name"`
+	// ProductTypeID: Produkt derselben Aktivität (0 für Research/Copying)
+	ProductTypeID int64  `json:"product_type_id,omitempty"`
+	ProductName   string `json:"product_name,omitempty"`
+}
+
+// Source ist eine Blueprint-Aktivität, die einen Typ herstellt
+type Source struct {
+	ProductTypeID   int64  `json:"product_type_id"`
+	Activity        string `json:"activity"`
+	Quantity        int64  `json:"quantity"`
+	BlueprintTypeID int64  `json:"blueprint_type_id"`
+	BlueprintName   string `json:"blueprint_name"`
+	// Probability: Erfolgswahrscheinlichkeit (nur Invention, sonst 0)
+	Probability float64 `json:"probability,omitempty"`
+}
+
+// UsedIn liefert alle Blueprint-Aktivitäten, die ein Material verbrauchen (v_material_usage)
+func UsedIn(db *sql.DB, materialTypeID int64) ([]Usage, error) {
+	rows, err := db.Query(`SELECT material_type_id, activity, quantity, blueprint_type_id, COALESCE(blueprint_name, ''),
+		COALESCE(product_type_id, 0), COALESCE(product_name, '')
+		FROM v_material_usage
+		WHERE material_type_id = ?
+		ORDER BY activity, blueprint_type_id, product_type_id`, materialTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query material usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usages []Usage
+	for rows.Next() {
+		var u Usage
+		if err := rows.Scan(&u.MaterialTypeID, &u.Activity, &u.Quantity, &u.BlueprintTypeID, &u.BlueprintName,
+			&u.ProductTypeID, &u.ProductName); err != nil {
+			return nil, fmt.Errorf("failed to scan material usage: %w", err)
+		}
+		usages = append(usages, u)
+	}
+	return usages, rows.Err()
+}
+
+// ProducedBy liefert alle Blueprint-Aktivitäten, die einen Typ herstellen (v_product_sources),
+// inklusive Reaktionen und Invention
+func ProducedBy(db *sql.DB, productTypeID int64) ([]Source, error) {
+	rows, err := db.Query(`SELECT product_type_id, activity, quantity, blueprint_type_id, COALESCE(blueprint_name, ''),
+		COALESCE(probability, 0)
+		FROM v_product_sources
+		WHERE product_type_id = ?
+		ORDER BY activity, blueprint_type_id`, productTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []Source
+	for rows.Next() {
+		var s Source
+		if err := rows.Scan(&s.ProductTypeID, &s.Activity, &s.Quantity, &s.BlueprintTypeID, &s.BlueprintName,
+			&s.Probability); err != nil {
+			return nil, fmt.Errorf("failed to scan product source: %w", err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
+
+// ResolveType löst eine Typ-ID oder einen englischen Namen (Groß-/Kleinschreibung egal) auf
+func ResolveType(db *sql.DB, ref string) (int64, string, error) {
+	ref = strings.TrimSpace(ref)
+
+	var (
+		id   int64
+		name sql.NullString
+		err  error
+	)
+	if n, convErr := strconv.ParseInt(ref, 10, 64); convErr == nil {
+		err = db.QueryRow(`SELECT _key, json_extract(name, '$.en') FROM types WHERE _key = ?`, n).Scan(&id, &name)
+	} else {
+		err = db.QueryRow(`SELECT _key, json_extract(name, '$.en') FROM types
+			WHERE json_extract(name, '$.en') = ? COLLATE NOCASE ORDER BY _key LIMIT 1`, ref).Scan(&id, &name)
+	}
+	switch {
+	case err == sql.ErrNoRows:
+		return 0, "", fmt.Errorf("%w: %s", ErrUnknownType, ref)
+	case err != nil:
+		return 0, "", fmt.Errorf("failed to resolve type %s: %w", ref, err)
+	}
+	return id, name.String, nil
+}
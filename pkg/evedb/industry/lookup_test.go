@@ -0,0 +1,70 @@
+package industry
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUsedIn(t *testing.T) {
+	db := fixtureDB(t)
+
+	usages, err := UsedIn(db, tritanium)
+	if err != nil {
+		t.Fatalf("UsedIn failed: %v", err)
+	}
+	want := []Usage{
+		{MaterialTypeID: tritanium, Activity: ActivityManufacturing, Quantity: 1000, BlueprintTypeID: 2000,
+			BlueprintName: "Ship Blueprint", ProductTypeID: ship, ProductName: "Ship"},
+		{MaterialTypeID: tritanium, Activity: ActivityManufacturing, Quantity: 100, BlueprintTypeID: 3100,
+			ProductTypeID: compA, ProductName: "Component A"},
+		{MaterialTypeID: tritanium, Activity: ActivityManufacturing, Quantity: 25, BlueprintTypeID: 3200,
+			ProductTypeID: compB},
+	}
+	if !reflect.DeepEqual(usages, want) {
+		t.Errorf("UsedIn =\n%+v\nwant\n%+v", usages, want)
+	}
+
+	usages, err = UsedIn(db, moonGoo)
+	if err != nil || len(usages) != 1 || usages[0].Activity != ActivityReaction || usages[0].ProductTypeID != alloy {
+		t.Errorf("UsedIn(moonGoo) = %+v, %v", usages, err)
+	}
+}
+
+func TestProducedBy(t *testing.T) {
+	db := fixtureDB(t)
+
+	sources, err := ProducedBy(db, alloy)
+	if err != nil {
+		t.Fatalf("ProducedBy failed: %v", err)
+	}
+	if want := []Source{{ProductTypeID: alloy, Activity: ActivityReaction, Quantity: 200, BlueprintTypeID: 3300}}; !reflect.DeepEqual(sources, want) {
+		t.Errorf("ProducedBy(alloy) = %+v, want %+v", sources, want)
+	}
+
+	// Invention-Ergebnis mit Wahrscheinlichkeit
+	sources, err = ProducedBy(db, 2001)
+	if err != nil || len(sources) != 1 || sources[0].Activity != "invention" || sources[0].Probability != 0.3 {
+		t.Errorf("ProducedBy(2001) = %+v, %v", sources, err)
+	}
+
+	if sources, _ := ProducedBy(db, tritanium); len(sources) != 0 {
+		t.Errorf("ProducedBy(tritanium) = %+v, want none", sources)
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	db := fixtureDB(t)
+
+	for _, ref := range []string{"34", "tritanium", " Tritanium "} {
+		id, name, err := ResolveType(db, ref)
+		if err != nil || id != tritanium || name != "Tritanium" {
+			t.Errorf("ResolveType(%q) = %d, %q, %v", ref, id, name, err)
+		}
+	}
+	for _, ref := range []string{"99999", "Veldspar"} {
+		if _, _, err := ResolveType(db, ref); !errors.Is(err, ErrUnknownType) {
+			t.Errorf("ResolveType(%q): err = %v, want ErrUnknownType", ref, err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package navigation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownArea: Region bzw. Konstellation hat keine Stargate-Verbindung nach außen
+var ErrUnknownArea = errors.New("unknown area")
+
+// AreaLevel ist die Ebene eines Nachbarschaftsgraphen
+type AreaLevel string
+
+const (
+	// LevelRegion: Regionen (v_region_adjacency)
+	LevelRegion AreaLevel = "region"
+	// LevelConstellation: Konstellationen (v_constellation_adjacency)
+	LevelConstellation AreaLevel = "constellation"
+)
+
+// GatePair ist ein Stargate-Paar zwischen zwei Systemen (ToGateID 0 = Gegenstück unbekannt)
+type GatePair struct {
+	FromSystemID int64 `json:"from_system_id"`
+	FromGateID   int64 `json:"from_gate_id"`
+	ToSystemID   int64 `json:"to_system_id"`
+	ToGateID     int64 `json:"to_gate_id"`
+}
+
+// Adjacency ist die (gerichtete) Nachbarschaft zweier Regionen bzw. Konstellationen
+type Adjacency struct {
+	FromID   int64      `json:"from_id"`
+	FromName string     `json:"from_name"`
+	ToID     int64      `json:"to_id"`
+	ToName   string     `json:"to_name"`
+	Gates    []GatePair `json:"gates"`
+}
+
+// AreaRoute ist eine grobe Route über Regionen bzw. Konstellationen
+type AreaRoute struct {
+	Level AreaLevel `json:"level"`
+	// Areas: Durchquerte Regionen/Konstellationen inkl. Start und Ziel
+	Areas []int64 `json:"areas"`
+	// Steps: Übergang je Grenze mit den möglichen Stargates
+	Steps []Adjacency `json:"steps"`
+}
+
+// AreaGraph ist der Nachbarschaftsgraph einer Ebene
+type AreaGraph struct {
+	level     AreaLevel
+	names     map[int64]string
+	adjacency map[int64][]Adjacency
+}
+
+// NewAreaGraph erstellt einen Nachbarschaftsgraphen aus gerichteten Nachbarschaften
+func NewAreaGraph(level AreaLevel, adjacencies []Adjacency) *AreaGraph {
+	g := &AreaGraph{
+		level:     level,
+		names:     make(map[int64]string),
+		adjacency: make(map[int64][]Adjacency),
+	}
+	for _, a := range adjacencies {
+		if a.FromID == a.ToID {
+			continue
+		}
+		g.names[a.FromID] = a.FromName
+		g.names[a.ToID] = a.ToName
+		g.adjacency[a.FromID] = append(g.adjacency[a.FromID], a)
+	}
+
+	// Deterministische Reihenfolge für gleich lange Routen
+	for id := range g.adjacency {
+		adj := g.adjacency[id]
+		sort.Slice(adj, func(i, j int) bool { return adj[i].ToID < adj[j].ToID })
+	}
+	return g
+}
+
+// LoadRegionGraph lädt den Regions-Nachbarschaftsgraphen aus v_region_adjacency
+func LoadRegionGraph(db *sql.DB) (*AreaGraph, error) {
+	return loadAreaGraph(db, LevelRegion, `SELECT from_region_id, COALESCE(from_region_name, ''),
+		to_region_id, COALESCE(to_region_name, ''), gates FROM v_region_adjacency`)
+}
+
+// LoadConstellationGraph lädt den Konstellations-Nachbarschaftsgraphen aus v_constellation_adjacency
+func LoadConstellationGraph(db *sql.DB) (*AreaGraph, error) {
+	return loadAreaGraph(db, LevelConstellation, `SELECT from_constellation_id, COALESCE(from_constellation_name, ''),
+		to_constellation_id, COALESCE(to_constellation_name, ''), gates FROM v_constellation_adjacency`)
+}
+
+func loadAreaGraph(db *sql.DB, level AreaLevel, query string) (*AreaGraph, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s adjacency: %w", level, err)
+	}
+	defer rows.Close()
+
+	var adjacencies []Adjacency
+	for rows.Next() {
+		var a Adjacency
+		var gates string
+		if err := rows.Scan(&a.FromID, &a.FromName, &a.ToID, &a.ToName, &gates); err != nil {
+			return nil, fmt.Errorf("failed to scan %s adjacency: %w", level, err)
+		}
+		if err := json.Unmarshal([]byte(gates), &a.Gates); err != nil {
+			return nil, fmt.Errorf("failed to decode gates of %d-%d: %w", a.FromID, a.ToID, err)
+		}
+		sort.Slice(a.Gates, func(i, j int) bool { return a.Gates[i].FromGateID < a.Gates[j].FromGateID })
+		adjacencies = append(adjacencies, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewAreaGraph(level, adjacencies), nil
+}
+
+// Level liefert die Ebene des Graphen
+func (g *AreaGraph) Level() AreaLevel {
+	return g.level
+}
+
+// Name liefert den Namen einer Region bzw. Konstellation
+func (g *AreaGraph) Name(id int64) (string, bool) {
+	name, ok := g.names[id]
+	return name, ok
+}
+
+// Neighbors liefert die angrenzenden Regionen bzw. Konstellationen samt verbindender Stargates
+func (g *AreaGraph) Neighbors(id int64) []Adjacency {
+	return g.adjacency[id]
+}
+
+// Route berechnet die Route mit den wenigsten Grenzübertritten von from nach to.
+// Vermiedene Regionen/Konstellationen werden nicht durchquert (außer Start und Ziel).
+func (g *AreaGraph) Route(from, to int64, avoid ...int64) (*AreaRoute, error) {
+	if _, ok := g.names[from]; !ok {
+		return nil, fmt.Errorf("%w: %s %d", ErrUnknownArea, g.level, from)
+	}
+	if _, ok := g.names[to]; !ok {
+		return nil, fmt.Errorf("%w: %s %d", ErrUnknownArea, g.level, to)
+	}
+
+	blocked := make(map[int64]bool, len(avoid))
+	for _, id := range avoid {
+		if id != from && id != to {
+			blocked[id] = true
+		}
+	}
+
+	// Breitensuche; via speichert den Übergang, über den ein Gebiet erreicht wurde
+	via := map[int64]*Adjacency{from: nil}
+	queue := []int64{from}
+	for len(queue) > 0 && via[to] == nil && from != to {
+		id := queue[0]
+		queue = queue[1:]
+		for i := range g.adjacency[id] {
+			a := &g.adjacency[id][i]
+			if _, seen := via[a.ToID]; seen || blocked[a.ToID] {
+				continue
+			}
+			via[a.ToID] = a
+			queue = append(queue, a.ToID)
+		}
+	}
+	if _, ok := via[to]; !ok {
+		return nil, fmt.Errorf("%w: %s %d -> %d", ErrNoRoute, g.level, from, to)
+	}
+
+	route := &AreaRoute{Level: g.level, Areas: []int64{to}}
+	for a := via[to]; a != nil; a = via[a.FromID] {
+		route.Steps = append(route.Steps, *a)
+		route.Areas = append(route.Areas, a.FromID)
+	}
+	for i, j := 0, len(route.Areas)-1; i < j; i, j = i+1, j-1 {
+		route.Areas[i], route.Areas[j] = route.Areas[j], route.Areas[i]
+	}
+	for i, j := 0, len(route.Steps)-1; i < j; i, j = i+1, j-1 {
+		route.Steps[i], route.Steps[j] = route.Steps[j], route.Steps[i]
+	}
+	return route, nil
+}
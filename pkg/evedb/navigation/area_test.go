@@ -0,0 +1,114 @@
+package navigation
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Regionen: 1 ── 2 ── 3 ── 5
+//
+//	│         │
+//	└── 4 ────┘
+func fixtureAreaGraph() *AreaGraph {
+	pairs := [][2]int64{{1, 2}, {2, 3}, {1, 4}, {4, 3}, {3, 5}}
+	var adjacencies []Adjacency
+	for i, p := range pairs {
+		gate := GatePair{FromSystemID: p[0] * 100, FromGateID: int64(i), ToSystemID: p[1] * 100, ToGateID: int64(i) + 100}
+		adjacencies = append(adjacencies,
+			Adjacency{FromID: p[0], ToID: p[1], Gates: []GatePair{gate}},
+			Adjacency{FromID: p[1], ToID: p[0], Gates: []GatePair{{
+				FromSystemID: gate.ToSystemID, FromGateID: gate.ToGateID, ToSystemID: gate.FromSystemID, ToGateID: gate.FromGateID,
+			}}},
+		)
+	}
+	return NewAreaGraph(LevelRegion, adjacencies)
+}
+
+func TestAreaGraph_Route(t *testing.T) {
+	g := fixtureAreaGraph()
+
+	tests := []struct {
+		name     string
+		from, to int64
+		avoid    []int64
+		want     []int64
+	}{
+		{"direct", 1, 2, nil, []int64{1, 2}},
+		{"two borders", 1, 5, nil, []int64{1, 2, 3, 5}},
+		{"avoid region", 1, 5, []int64{2}, []int64{1, 4, 3, 5}},
+		{"avoid start ignored", 1, 3, []int64{1}, []int64{1, 2, 3}},
+		{"same region", 3, 3, nil, []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := g.Route(tt.from, tt.to, tt.avoid...)
+			if err != nil {
+				t.Fatalf("Route failed: %v", err)
+			}
+			if !reflect.DeepEqual(route.Areas, tt.want) {
+				t.Errorf("Areas = %v, want %v", route.Areas, tt.want)
+			}
+			if len(route.Steps) != len(tt.want)-1 {
+				t.Fatalf("Steps = %d, want %d", len(route.Steps), len(tt.want)-1)
+			}
+			for i, step := range route.Steps {
+				if step.FromID != tt.want[i] || step.ToID != tt.want[i+1] || len(step.Gates) == 0 {
+					t.Errorf("step %d = %+v", i, step)
+				}
+			}
+		})
+	}
+
+	if _, err := g.Route(1, 5, 3); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Route avoiding the only border = %v, want ErrNoRoute", err)
+	}
+	if _, err := g.Route(1, 99); !errors.Is(err, ErrUnknownArea) {
+		t.Errorf("Route to unknown region = %v, want ErrUnknownArea", err)
+	}
+}
+
+func TestLoadAreaGraphs(t *testing.T) {
+	db := fixtureDB(t)
+
+	regions, err := LoadRegionGraph(db)
+	if err != nil {
+		t.Fatalf("LoadRegionGraph failed: %v", err)
+	}
+
+	// B (Region Two) grenzt über A, C und L an Region One
+	neighbors := regions.Neighbors(10000002)
+	if len(neighbors) != 1 || neighbors[0].ToID != 10000001 || neighbors[0].ToName != "Region One" {
+		t.Fatalf("Neighbors(Region Two) = %+v", neighbors)
+	}
+	var targets []int64
+	for _, gate := range neighbors[0].Gates {
+		if gate.FromSystemID != sysB || gate.FromGateID == 0 && gate.ToGateID == 0 {
+			t.Errorf("gate = %+v", gate)
+		}
+		targets = append(targets, gate.ToSystemID)
+	}
+	if len(targets) != 3 {
+		t.Errorf("gate targets = %v, want A, C and L", targets)
+	}
+
+	route, err := regions.Route(10000001, 10000002)
+	if err != nil || !reflect.DeepEqual(route.Areas, []int64{10000001, 10000002}) {
+		t.Errorf("Route = %+v, %v", route, err)
+	}
+
+	// Eine Konstellation je Region: B in 20000002, alle übrigen in 20000001
+	if _, err := db.Exec(`UPDATE mapSolarSystems SET constellationID =
+		CASE WHEN regionID = 10000002 THEN 20000002 ELSE 20000001 END`); err != nil {
+		t.Fatalf("Update constellations failed: %v", err)
+	}
+	constellations, err := LoadConstellationGraph(db)
+	if err != nil {
+		t.Fatalf("LoadConstellationGraph failed: %v", err)
+	}
+	// Grenzen: A-B, B-C, B-L
+	if n := constellations.Neighbors(20000001); len(n) != 1 || len(n[0].Gates) != 3 {
+		t.Errorf("Neighbors(20000001) = %+v, want 3 gate pairs to 20000002", n)
+	}
+}
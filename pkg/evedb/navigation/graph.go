@@ -0,0 +1,140 @@
+// Package navigation berechnet Routen über das Stargate-Netz einer eve-sde Datenbank
+// unter EVE-typischen Routen-Policies (kürzeste, sicherere, weniger sichere Route)
+package navigation
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// HighSecMin: Ab diesem angezeigten Security-Status gilt ein System als High-Sec
+const HighSecMin = 0.5
+
+var (
+	// ErrUnknownSystem: System ist nicht Teil des Stargate-Netzes
+	ErrUnknownSystem = errors.New("unknown solar system")
+	// ErrNoRoute: Unter den Optionen existiert keine Route
+	ErrNoRoute = errors.New("no route found")
+)
+
+// System ist ein Knoten des Stargate-Netzes
+type System struct {
+	SystemID       int64   `json:"system_id"`
+	Name           string  `json:"system_name"`
+	RegionID       int64   `json:"region_id"`
+	RegionName     string  `json:"region_name"`
+	SecurityStatus float64 `json:"security_status"`
+	// DisplaySecurity: Angezeigter Security-Status (EVE-Rundung, generierte Spalte displaySecurity)
+	DisplaySecurity float64 `json:"display_security"`
+	SecurityZone    string  `json:"security_zone"`
+}
+
+// HighSec prüft ob das System High-Sec ist (angezeigte Security ≥ 0.5)
+func (s System) HighSec() bool {
+	return s.DisplaySecurity >= HighSecMin
+}
+
+// Edge ist eine Stargate-Verbindung
+type Edge struct {
+	FromSystemID int64
+	ToSystemID   int64
+}
+
+// Graph ist das Stargate-Netz im Speicher
+type Graph struct {
+	systems   map[int64]System
+	adjacency map[int64][]int64
+	highSec   map[int64]bool
+}
+
+// NewGraph erstellt einen Graphen aus Systemen und (gerichteten) Kanten.
+// Kanten mit unbekannten Systemen werden ignoriert.
+func NewGraph(systems []System, edges []Edge) *Graph {
+	g := &Graph{
+		systems:   make(map[int64]System, len(systems)),
+		adjacency: make(map[int64][]int64),
+		highSec:   make(map[int64]bool),
+	}
+	for _, s := range systems {
+		g.systems[s.SystemID] = s
+		if s.HighSec() {
+			g.highSec[s.SystemID] = true
+		}
+	}
+
+	seen := make(map[Edge]bool, len(edges))
+	for _, e := range edges {
+		if _, ok := g.systems[e.FromSystemID]; !ok {
+			continue
+		}
+		if _, ok := g.systems[e.ToSystemID]; !ok || e.FromSystemID == e.ToSystemID || seen[e] {
+			continue
+		}
+		seen[e] = true
+		g.adjacency[e.FromSystemID] = append(g.adjacency[e.FromSystemID], e.ToSystemID)
+	}
+
+	// Deterministische Reihenfolge für gleich teure Routen
+	for id := range g.adjacency {
+		adj := g.adjacency[id]
+		sort.Slice(adj, func(i, j int) bool { return adj[i] < adj[j] })
+	}
+	return g
+}
+
+// LoadGraph lädt das Stargate-Netz aus v_stargate_graph und v_system_info
+func LoadGraph(db *sql.DB) (*Graph, error) {
+	rows, err := db.Query(`SELECT system_id, COALESCE(system_name, ''), COALESCE(region_id, 0),
+		COALESCE(region_name, ''), COALESCE(security_status, 0), COALESCE(display_security, 0),
+		COALESCE(security_zone, '') FROM v_system_info`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query systems: %w", err)
+	}
+	var systems []System
+	for rows.Next() {
+		var s System
+		if err := rows.Scan(&s.SystemID, &s.Name, &s.RegionID, &s.RegionName, &s.SecurityStatus, &s.DisplaySecurity,
+			&s.SecurityZone); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan system: %w", err)
+		}
+		systems = append(systems, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(`SELECT from_system_id, to_system_id FROM v_stargate_graph`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stargate graph: %w", err)
+	}
+	defer rows.Close()
+
+	var edges []Edge
+	for rows.Next() {
+		var e Edge
+		if err := rows.Scan(&e.FromSystemID, &e.ToSystemID); err != nil {
+			return nil, fmt.Errorf("failed to scan edge: %w", err)
+		}
+		edges = append(edges, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewGraph(systems, edges), nil
+}
+
+// System liefert die Daten eines Systems
+func (g *Graph) System(id int64) (System, bool) {
+	s, ok := g.systems[id]
+	return s, ok
+}
+
+// Neighbors liefert die direkt per Stargate verbundenen Systeme
+func (g *Graph) Neighbors(id int64) []int64 {
+	return g.adjacency[id]
+}
@@ -0,0 +1,239 @@
+package navigation
+
+import (
+	"container/heap"
+	"database/sql"
+	"fmt"
+	"math"
+)
+
+// Jump-Fatigue Regeln (seit 2016): Fatigue nach einem Sprung = max(Fatigue, 10 min) × (1 + effektive LY),
+// maximal 5 Stunden; Reaktivierungs-Timer = max(1 + effektive LY, Fatigue vor dem Sprung / 10) Minuten,
+// maximal 30 Minuten
+const (
+	MinFatigueMinutes = 10.0
+	MaxFatigueMinutes = 300.0
+
+	// CooldownBaseMinutes: Mindest-Reaktivierungs-Timer ohne Fatigue, zuzüglich 1 Minute je effektivem LY
+	CooldownBaseMinutes = 1.0
+	MaxCooldownMinutes  = 30.0
+)
+
+// JumpShip beschreibt die Sprungfähigkeit eines Schiffs
+type JumpShip struct {
+	// RangeLY: Maximale Sprungreichweite inkl. Skills (z.B. 7.0 für Carrier mit JDC V)
+	RangeLY float64
+	// FatigueReduction: Reduktion der für Fatigue zählenden Distanz (0.9 = 90 %, z.B. Jump Freighter)
+	FatigueReduction float64
+}
+
+// JumpLeg ist ein einzelner Sprung
+type JumpLeg struct {
+	From        System  `json:"from"`
+	To          System  `json:"to"`
+	DistanceLY  float64 `json:"distance_ly"`
+	EffectiveLY float64 `json:"effective_ly"`
+	// WaitMinutes: Wartezeit vor dem Sprung (Reaktivierungs-Timer des vorherigen Sprungs)
+	WaitMinutes float64 `json:"wait_minutes"`
+	// FatigueMinutes: Jump Fatigue nach dem Sprung
+	FatigueMinutes float64 `json:"fatigue_minutes"`
+	// CooldownMinutes: Reaktivierungs-Timer nach dem Sprung
+	CooldownMinutes float64 `json:"cooldown_minutes"`
+}
+
+// JumpRoute ist eine Jump-Drive Route
+type JumpRoute struct {
+	Jumps          int       `json:"jumps"`
+	DistanceLY     float64   `json:"distance_ly"`
+	Legs           []JumpLeg `json:"legs"`
+	FatigueMinutes float64   `json:"fatigue_minutes"`
+	WaitMinutes    float64   `json:"wait_minutes"`
+}
+
+// JumpRange ist ein per Jump-Drive erreichbares Systempaar
+type JumpRange struct {
+	FromSystemID int64
+	ToSystemID   int64
+	DistanceLY   float64
+}
+
+// JumpGraph enthält alle Systempaare innerhalb der vorberechneten Reichweite
+type JumpGraph struct {
+	systems    map[int64]System
+	ranges     map[int64][]JumpRange
+	maxRangeLY float64
+}
+
+// NewJumpGraph erstellt einen Jump-Graphen; maxRangeLY ist die Reichweite, bis zu der ranges vollständig sind
+func NewJumpGraph(systems []System, ranges []JumpRange, maxRangeLY float64) *JumpGraph {
+	g := &JumpGraph{
+		systems:    make(map[int64]System, len(systems)),
+		ranges:     make(map[int64][]JumpRange),
+		maxRangeLY: maxRangeLY,
+	}
+	for _, s := range systems {
+		g.systems[s.SystemID] = s
+	}
+	for _, r := range ranges {
+		g.ranges[r.FromSystemID] = append(g.ranges[r.FromSystemID], r)
+	}
+	return g
+}
+
+// LoadJumpGraph lädt jump_ranges (erstellt von sde-to-sqlite) und die Systemdaten aus v_system_info
+func LoadJumpGraph(db *sql.DB) (*JumpGraph, error) {
+	var maxRange float64
+	if err := db.QueryRow(`SELECT max_range_ly FROM jump_range_settings`).Scan(&maxRange); err != nil {
+		return nil, fmt.Errorf("failed to read jump range settings: %w", err)
+	}
+
+	g, err := LoadGraph(db)
+	if err != nil {
+		return nil, err
+	}
+	systems := make([]System, 0, len(g.systems))
+	for _, s := range g.systems {
+		systems = append(systems, s)
+	}
+
+	rows, err := db.Query(`SELECT from_system_id, to_system_id, distance_ly FROM jump_ranges`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jump ranges: %w", err)
+	}
+	defer rows.Close()
+
+	var ranges []JumpRange
+	for rows.Next() {
+		var r JumpRange
+		if err := rows.Scan(&r.FromSystemID, &r.ToSystemID, &r.DistanceLY); err != nil {
+			return nil, fmt.Errorf("failed to scan jump range: %w", err)
+		}
+		ranges = append(ranges, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewJumpGraph(systems, ranges, maxRange), nil
+}
+
+// InRange liefert alle Systeme innerhalb der Reichweite eines Schiffs
+func (g *JumpGraph) InRange(from int64, rangeLY float64) ([]JumpRange, error) {
+	if rangeLY > g.maxRangeLY {
+		return nil, fmt.Errorf("range %.2f LY exceeds precomputed maximum of %.2f LY", rangeLY, g.maxRangeLY)
+	}
+	var result []JumpRange
+	for _, r := range g.ranges[from] {
+		if r.DistanceLY <= rangeLY {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// fatigueWeight begrenzt die Fatigue-Kosten aller Sprünge unter einen einzelnen Sprung,
+// sodass zuerst Sprünge und dann Fatigue minimiert werden
+const fatigueWeight = 1000.0
+
+// Route sucht die Jump-Drive Route mit den wenigsten Sprüngen und, bei Gleichstand,
+// der geringsten Fatigue (Produkt der Faktoren 1 + effektive LY)
+func (g *JumpGraph) Route(from, to int64, ship JumpShip) (*JumpRoute, error) {
+	if ship.RangeLY <= 0 || ship.RangeLY > g.maxRangeLY {
+		return nil, fmt.Errorf("jump range %.2f LY outside of (0, %.2f]", ship.RangeLY, g.maxRangeLY)
+	}
+	if ship.FatigueReduction < 0 || ship.FatigueReduction >= 1 {
+		return nil, fmt.Errorf("fatigue reduction %.2f outside of [0, 1)", ship.FatigueReduction)
+	}
+	for _, id := range []int64{from, to} {
+		if _, ok := g.systems[id]; !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownSystem, id)
+		}
+	}
+
+	effective := func(ly float64) float64 { return ly * (1 - ship.FatigueReduction) }
+
+	cost := map[int64]float64{from: 0}
+	prev := make(map[int64]JumpRange)
+	pq := &floatQueue{{system: from}}
+
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(floatItem)
+		if cur.cost != cost[cur.system] {
+			continue
+		}
+		if cur.system == to {
+			break
+		}
+		for _, r := range g.ranges[cur.system] {
+			if r.DistanceLY > ship.RangeLY {
+				continue
+			}
+			c := cur.cost + fatigueWeight + math.Log1p(effective(r.DistanceLY))
+			if old, ok := cost[r.ToSystemID]; !ok || c < old {
+				cost[r.ToSystemID] = c
+				prev[r.ToSystemID] = r
+				heap.Push(pq, floatItem{system: r.ToSystemID, cost: c})
+			}
+		}
+	}
+
+	if _, ok := cost[to]; !ok {
+		return nil, fmt.Errorf("%w: %d -> %d within %.2f LY", ErrNoRoute, from, to, ship.RangeLY)
+	}
+
+	var path []JumpRange
+	for cur := to; cur != from; cur = prev[cur].FromSystemID {
+		path = append([]JumpRange{prev[cur]}, path...)
+	}
+
+	route := &JumpRoute{Jumps: len(path)}
+	fatigue, cooldown := 0.0, 0.0
+	for _, r := range path {
+		eff := effective(r.DistanceLY)
+
+		// Fatigue baut sich während der Wartezeit ab
+		wait := cooldown
+		before := math.Max(0, fatigue-wait)
+		fatigue = math.Min(MaxFatigueMinutes, math.Max(before, MinFatigueMinutes)*(1+eff))
+		cooldown = math.Min(MaxCooldownMinutes, math.Max(CooldownBaseMinutes+eff, before/10))
+
+		route.Legs = append(route.Legs, JumpLeg{
+			From:            g.systems[r.FromSystemID],
+			To:              g.systems[r.ToSystemID],
+			DistanceLY:      r.DistanceLY,
+			EffectiveLY:     eff,
+			WaitMinutes:     wait,
+			FatigueMinutes:  fatigue,
+			CooldownMinutes: cooldown,
+		})
+		route.DistanceLY += r.DistanceLY
+		route.WaitMinutes += wait
+	}
+	route.FatigueMinutes = fatigue
+	return route, nil
+}
+
+// floatItem ist ein Eintrag der Prioritätswarteschlange mit Gleitkomma-Kosten
+type floatItem struct {
+	system int64
+	cost   float64
+}
+
+// floatQueue implementiert heap.Interface (kleinste Kosten zuerst, dann kleinste System-ID)
+type floatQueue []floatItem
+
+func (q floatQueue) Len() int { return len(q) }
+func (q floatQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].system < q[j].system
+}
+func (q floatQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *floatQueue) Push(x interface{}) { *q = append(*q, x.(floatItem)) }
+func (q *floatQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,123 @@
+package navigation
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// Jump-Fixture (Distanzen in LY):
+//
+//	S ─5─ M1 ─5─ T
+//	S ─2─ M2 ─8─ T
+//	T ─6─ U
+func jumpFixture() *JumpGraph {
+	systems := []System{
+		{SystemID: 1, Name: "S", SecurityStatus: -0.1},
+		{SystemID: 2, Name: "M1", SecurityStatus: -0.2},
+		{SystemID: 3, Name: "M2", SecurityStatus: 0.3},
+		{SystemID: 4, Name: "T", SecurityStatus: -0.4},
+		{SystemID: 5, Name: "U", SecurityStatus: -0.5},
+	}
+	pairs := []JumpRange{
+		{1, 2, 5}, {2, 4, 5},
+		{1, 3, 2}, {3, 4, 8},
+		{4, 5, 6},
+	}
+	var ranges []JumpRange
+	for _, p := range pairs {
+		ranges = append(ranges, p, JumpRange{p.ToSystemID, p.FromSystemID, p.DistanceLY})
+	}
+	return NewJumpGraph(systems, ranges, 10)
+}
+
+func jumpPath(r *JumpRoute) []string {
+	names := []string{r.Legs[0].From.Name}
+	for _, leg := range r.Legs {
+		names = append(names, leg.To.Name)
+	}
+	return names
+}
+
+func TestJumpRoute_MinimisesFatigue(t *testing.T) {
+	g := jumpFixture()
+
+	route, err := g.Route(1, 4, JumpShip{RangeLY: 8})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	// Beide Routen haben 2 Sprünge; (1+2)(1+8)=27 < (1+5)(1+5)=36
+	if got := jumpPath(route); !reflect.DeepEqual(got, []string{"S", "M2", "T"}) {
+		t.Errorf("path = %v, want S M2 T", got)
+	}
+
+	// Sprung 1: max(0,10)×3 = 30 min, Cooldown 1+2 = 3; Sprung 2: (30-3)×9 = 243 min, Cooldown max(1+8, 27/10) = 9
+	leg := route.Legs[1]
+	if math.Abs(leg.WaitMinutes-3) > 1e-9 || math.Abs(leg.FatigueMinutes-243) > 1e-9 || math.Abs(leg.CooldownMinutes-9) > 1e-9 {
+		t.Errorf("leg 2 = %+v, want wait 3, fatigue 243, cooldown 9", leg)
+	}
+	if route.Jumps != 2 || route.DistanceLY != 10 || route.FatigueMinutes != 243 {
+		t.Errorf("route = %+v", route)
+	}
+}
+
+func TestJumpRoute_FatigueAndCooldown(t *testing.T) {
+	g := jumpFixture()
+
+	route, err := g.Route(1, 5, JumpShip{RangeLY: 6})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if got := jumpPath(route); !reflect.DeepEqual(got, []string{"S", "M1", "T", "U"}) {
+		t.Fatalf("path = %v, want S M1 T U", got)
+	}
+
+	// Sprung 1 (5 LY): Fatigue 10×6 = 60, Cooldown max(1+5, 0) = 6
+	// Sprung 2 (5 LY): vorher 60-6 = 54, Fatigue 54×6 = 324 → 300, Cooldown max(6, 5.4) = 6
+	// Sprung 3 (6 LY): vorher 300-6 = 294, Fatigue 294×7 → 300, Cooldown max(7, 29.4) = 29.4
+	want := []struct{ wait, fatigue, cooldown float64 }{
+		{0, 60, 6},
+		{6, 300, 6},
+		{6, 300, 29.4},
+	}
+	for i, w := range want {
+		leg := route.Legs[i]
+		if math.Abs(leg.WaitMinutes-w.wait) > 1e-9 || math.Abs(leg.FatigueMinutes-w.fatigue) > 1e-9 ||
+			math.Abs(leg.CooldownMinutes-w.cooldown) > 1e-9 {
+			t.Errorf("leg %d = wait %v, fatigue %v, cooldown %v; want %v, %v, %v", i+1,
+				leg.WaitMinutes, leg.FatigueMinutes, leg.CooldownMinutes, w.wait, w.fatigue, w.cooldown)
+		}
+	}
+	if route.WaitMinutes != 12 || route.FatigueMinutes != MaxFatigueMinutes {
+		t.Errorf("route wait = %v, fatigue = %v; want 12, %v", route.WaitMinutes, route.FatigueMinutes, MaxFatigueMinutes)
+	}
+}
+
+func TestJumpRoute_Range(t *testing.T) {
+	g := jumpFixture()
+
+	// Mit 5 LY ist nur S-M1-T möglich
+	route, err := g.Route(1, 4, JumpShip{RangeLY: 5, FatigueReduction: 0.9})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if got := jumpPath(route); !reflect.DeepEqual(got, []string{"S", "M1", "T"}) {
+		t.Errorf("path = %v, want S M1 T", got)
+	}
+	if math.Abs(route.Legs[0].EffectiveLY-0.5) > 1e-9 {
+		t.Errorf("effective LY = %v, want 0.5 with 90%% reduction", route.Legs[0].EffectiveLY)
+	}
+
+	if _, err := g.Route(1, 5, JumpShip{RangeLY: 5}); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("err = %v, want ErrNoRoute (T-U needs 6 LY)", err)
+	}
+	if _, err := g.Route(1, 4, JumpShip{RangeLY: 12}); err == nil {
+		t.Error("expected error for range above precomputed maximum")
+	}
+
+	inRange, err := g.InRange(1, 4)
+	if err != nil || len(inRange) != 1 || inRange[0].ToSystemID != 3 {
+		t.Errorf("InRange = %v, %v; want only M2", inRange, err)
+	}
+}
@@ -0,0 +1,263 @@
+package navigation
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/hubs"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/planetary"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/views"
+)
+
+// Fixture-Graph (Region in Klammern):
+//
+//	A(1.0) ── B(0.5, R2) ── C(0.9) ── R(0.46) ── S(0.9)
+//	 │  \        │           │
+//	 │   F(-0.2) ── H(0.1) ──┘
+//	 │           L(0.3)
+//	 │           │
+//	 D(0.8) ── E(0.7) ── K(0.6) ── X(0.9)
+//
+// Kanten: A-B, B-C, A-F, F-H, H-C, B-L, L-X, A-D, D-E, E-K, K-X, C-R, R-S
+// R wird als 0.5 angezeigt (Rundungsgrenze für MinSecurity und High-Sec)
+const (
+	sysA int64 = 30000001 + iota
+	sysB
+	sysC
+	sysD
+	sysE
+	sysF
+	sysH
+	sysK
+	sysL
+	sysX
+	sysR
+	sysS
+)
+
+func fixtureSystems() []System {
+	sec := map[int64]float64{
+		sysA: 1.0, sysB: 0.5, sysC: 0.9, sysD: 0.8, sysE: 0.7,
+		sysF: -0.2, sysH: 0.1, sysK: 0.6, sysL: 0.3, sysX: 0.9,
+		sysR: 0.46, sysS: 0.9,
+	}
+	// Angezeigter Status wie displaySecurity (TestLoadGraph gleicht ab)
+	display := map[int64]float64{sysR: 0.5}
+	names := map[int64]string{
+		sysA: "A", sysB: "B", sysC: "C", sysD: "D", sysE: "E",
+		sysF: "F", sysH: "H", sysK: "K", sysL: "L", sysX: "X",
+		sysR: "R", sysS: "S",
+	}
+
+	var systems []System
+	for id := sysA; id <= sysS; id++ {
+		region := int64(10000001)
+		if id == sysB {
+			region = 10000002
+		}
+		d, ok := display[id]
+		if !ok {
+			d = sec[id]
+		}
+		systems = append(systems, System{SystemID: id, Name: names[id], RegionID: region, SecurityStatus: sec[id],
+			DisplaySecurity: d})
+	}
+	return systems
+}
+
+func fixtureEdges() []Edge {
+	pairs := [][2]int64{
+		{sysA, sysB}, {sysB, sysC}, {sysA, sysF}, {sysF, sysH}, {sysH, sysC},
+		{sysB, sysL}, {sysL, sysX}, {sysA, sysD}, {sysD, sysE}, {sysE, sysK}, {sysK, sysX},
+		{sysC, sysR}, {sysR, sysS},
+	}
+	var edges []Edge
+	for _, p := range pairs {
+		edges = append(edges, Edge{p[0], p[1]}, Edge{p[1], p[0]})
+	}
+	return edges
+}
+
+func fixtureGraph() *Graph {
+	return NewGraph(fixtureSystems(), fixtureEdges())
+}
+
+func path(t *testing.T, g *Graph, from, to int64, opts RouteOptions) []string {
+	t.Helper()
+	route, err := g.Route(from, to, opts)
+	if err != nil {
+		t.Fatalf("Route(%d, %d) failed: %v", from, to, err)
+	}
+	names := make([]string, len(route.Hops))
+	for i, h := range route.Hops {
+		names[i] = h.Name
+	}
+	return names
+}
+
+func TestRoute_Policies(t *testing.T) {
+	g := fixtureGraph()
+	minSec, highSec := 0.0, 0.5
+
+	tests := []struct {
+		name     string
+		from, to int64
+		opts     RouteOptions
+		want     []string
+	}{
+		{"shortest", sysA, sysC, RouteOptions{}, []string{"A", "B", "C"}},
+		{"less-secure", sysA, sysC, RouteOptions{Policy: PolicyLessSecure}, []string{"A", "F", "H", "C"}},
+		{"shortest via low-sec", sysA, sysX, RouteOptions{Policy: PolicyShortest}, []string{"A", "B", "L", "X"}},
+		{"safer", sysA, sysX, RouteOptions{Policy: PolicySafer}, []string{"A", "D", "E", "K", "X"}},
+		{"avoid system", sysA, sysC, RouteOptions{AvoidSystems: []int64{sysB}}, []string{"A", "F", "H", "C"}},
+		{"avoid region", sysA, sysC, RouteOptions{AvoidRegions: []int64{10000002}}, []string{"A", "F", "H", "C"}},
+		{"avoided destination", sysA, sysB, RouteOptions{AvoidSystems: []int64{sysB}}, []string{"A", "B"}},
+		{"min security", sysA, sysX, RouteOptions{MinSecurity: &minSec}, []string{"A", "B", "L", "X"}},
+		// R (0.46) wird als 0.5 angezeigt und liegt damit nicht unter der Grenze
+		{"min security display", sysC, sysS, RouteOptions{MinSecurity: &highSec}, []string{"C", "R", "S"}},
+		{"same system", sysA, sysA, RouteOptions{}, []string{"A"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := path(t, g, tt.from, tt.to, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("route = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoute_SecurityPerHop(t *testing.T) {
+	route, err := fixtureGraph().Route(sysA, sysC, RouteOptions{Policy: PolicyLessSecure})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+
+	if route.Jumps != 3 || route.MinSecurity != -0.2 {
+		t.Errorf("Jumps = %d, MinSecurity = %v; want 3, -0.2", route.Jumps, route.MinSecurity)
+	}
+	want := []float64{1.0, -0.2, 0.1, 0.9}
+	for i, h := range route.Hops {
+		if h.Jump != i || h.SecurityStatus != want[i] {
+			t.Errorf("hop %d = %+v, want jump %d security %v", i, h, i, want[i])
+		}
+	}
+}
+
+func TestRoute_Errors(t *testing.T) {
+	g := fixtureGraph()
+	minSec := 0.0
+
+	_, err := g.Route(sysA, sysC, RouteOptions{AvoidSystems: []int64{sysB}, MinSecurity: &minSec})
+	if !errors.Is(err, ErrNoRoute) {
+		t.Errorf("err = %v, want ErrNoRoute", err)
+	}
+	if _, err := g.Route(sysA, 30009999, RouteOptions{}); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("err = %v, want ErrUnknownSystem", err)
+	}
+	if _, err := g.Route(sysA, sysC, RouteOptions{Policy: "fastest"}); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
+
+func TestOrderStops(t *testing.T) {
+	g := fixtureGraph()
+
+	// Nearest Neighbour wählt A→C→H→X (7 Sprünge), 2-opt verbessert zu A→H→C→X (6)
+	mr, err := g.OrderStops(sysA, []int64{sysX, sysC, sysH, sysC}, RouteOptions{}, false)
+	if err != nil {
+		t.Fatalf("OrderStops failed: %v", err)
+	}
+	if want := []int64{sysA, sysH, sysC, sysX}; !reflect.DeepEqual(mr.Order, want) {
+		t.Errorf("Order = %v, want %v", mr.Order, want)
+	}
+	if mr.Jumps != 6 || len(mr.Legs) != 3 {
+		t.Errorf("Jumps = %d, Legs = %d; want 6, 3", mr.Jumps, len(mr.Legs))
+	}
+
+	round, err := g.OrderStops(sysA, []int64{sysX, sysC}, RouteOptions{}, true)
+	if err != nil {
+		t.Fatalf("OrderStops failed: %v", err)
+	}
+	if round.Order[0] != sysA || round.Order[len(round.Order)-1] != sysA {
+		t.Errorf("Order = %v, want round trip from A", round.Order)
+	}
+}
+
+// fixtureDB legt die Map-Tabellen der Fixture an und erstellt die Navigation-Views
+func fixtureDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	// Weitere Tabellen für die Views, die von mapSolarSystems abhängen (Wormhole, Reprocessing, PI)
+	db := sqlitetest.Open(t, "mapRegions", "mapConstellations", "mapSolarSystems", "mapStargates", "mapStars",
+		"types", "groups", "typeDogma", "dogmaAttributes", "npcStations", "mapPlanets", "planetSchematics")
+	if _, err := db.Exec(`INSERT INTO mapRegions (_key, name) VALUES (10000001, '{"en":"Region One"}'), (10000002, '{"en":"Region Two"}')`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	if _, err := planetary.BuildTables(db, nil); err != nil {
+		t.Fatalf("BuildTables failed: %v", err)
+	}
+
+	for _, s := range fixtureSystems() {
+		_, err := db.Exec(`INSERT INTO mapSolarSystems (_key, name, securityStatus, regionID) VALUES (?, ?, ?, ?)`,
+			s.SystemID, fmt.Sprintf(`{"en":%q}`, s.Name), s.SecurityStatus, s.RegionID)
+		if err != nil {
+			t.Fatalf("Insert system failed: %v", err)
+		}
+	}
+	// Gates nur in eine Richtung, v_stargate_graph ergänzt die Rückrichtung
+	for i, e := range fixtureEdges() {
+		if i%2 == 1 {
+			continue
+		}
+		dest := fmt.Sprintf(`{"solarSystemID": %d}`, e.ToSystemID)
+		if _, err := db.Exec(`INSERT INTO mapStargates (_key, solarSystemID, destination, typeID) VALUES (?, ?, ?, 16)`,
+			50000000+i, e.FromSystemID, dest); err != nil {
+			t.Fatalf("Insert gate failed: %v", err)
+		}
+	}
+
+	if _, err := hubs.WriteHubs(db, []hubs.Hub{{Name: "Hub A", SystemID: sysA, Tier: hubs.TierPrimary}}); err != nil {
+		t.Fatalf("WriteHubs failed: %v", err)
+	}
+
+	registry, err := views.DefaultRegistry()
+	if err != nil {
+		t.Fatalf("DefaultRegistry failed: %v", err)
+	}
+	if _, err := registry.Rebuild(db, "mapSolarSystems", "mapStargates", "mapRegions"); err != nil {
+		t.Fatalf("Rebuild views failed: %v", err)
+	}
+	return db
+}
+
+func TestLoadGraph(t *testing.T) {
+	db := fixtureDB(t)
+
+	g, err := LoadGraph(db)
+	if err != nil {
+		t.Fatalf("LoadGraph failed: %v", err)
+	}
+
+	route, err := g.Route(sysA, sysX, RouteOptions{Policy: PolicySafer})
+	if err != nil {
+		t.Fatalf("Route failed: %v", err)
+	}
+	if !reflect.DeepEqual(route.SystemIDs(), []int64{sysA, sysD, sysE, sysK, sysX}) {
+		t.Errorf("route = %v", route.SystemIDs())
+	}
+	if h := route.Hops[0]; h.Name != "A" || h.RegionName != "Region One" || h.SecurityZone != "High-Sec" {
+		t.Errorf("hop 0 = %+v", h)
+	}
+
+	// displaySecurity aus der Datenbank entspricht der Fixture
+	for _, want := range fixtureSystems() {
+		if s, _ := g.System(want.SystemID); s.DisplaySecurity != want.DisplaySecurity || s.HighSec() != want.HighSec() {
+			t.Errorf("system %s: display = %v, want %v", want.Name, s.DisplaySecurity, want.DisplaySecurity)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package navigation
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// Policy bestimmt, welche Route gewählt wird (analog zum EVE-Autopiloten)
+type Policy string
+
+const (
+	// PolicyShortest: Minimale Anzahl Sprünge
+	PolicyShortest Policy = "shortest"
+	// PolicySafer: Möglichst wenige Low-/Null-Sec Systeme, dann minimale Sprünge
+	PolicySafer Policy = "safer"
+	// PolicyLessSecure: Möglichst wenige High-Sec Systeme, dann minimale Sprünge
+	PolicyLessSecure Policy = "less-secure"
+)
+
+// ParsePolicy wandelt einen Namen in eine Policy
+func ParsePolicy(s string) (Policy, error) {
+	switch Policy(s) {
+	case PolicyShortest, PolicySafer, PolicyLessSecure:
+		return Policy(s), nil
+	default:
+		return "", fmt.Errorf("unknown route policy %q", s)
+	}
+}
+
+// RouteOptions steuert die Routenberechnung.
+// Vermeidungen gelten für alle Systeme außer Start und Ziel.
+type RouteOptions struct {
+	// Policy: Routen-Policy (leer = PolicyShortest)
+	Policy Policy
+	// AvoidSystems: Systeme, die nicht durchflogen werden
+	AvoidSystems []int64
+	// AvoidRegions: Regionen, die nicht durchflogen werden
+	AvoidRegions []int64
+	// MinSecurity: Systeme, deren angezeigter Security-Status (System.DisplaySecurity, z.B. 0.46 → 0.5)
+	// darunter liegt, werden nicht durchflogen (nil = keine Grenze)
+	MinSecurity *float64
+}
+
+// Hop ist ein System auf einer Route
+type Hop struct {
+	System
+	// Jump: Position auf der Route (0 = Start)
+	Jump int `json:"jump"`
+}
+
+// Route ist das Ergebnis einer Routenberechnung
+type Route struct {
+	FromSystemID int64  `json:"from_system_id"`
+	ToSystemID   int64  `json:"to_system_id"`
+	Policy       Policy `json:"policy"`
+	Jumps        int    `json:"jumps"`
+	Hops         []Hop  `json:"hops"`
+	// MinSecurity: Niedrigster Security-Status auf der Route
+	MinSecurity float64 `json:"min_security"`
+}
+
+// SystemIDs liefert die System-IDs der Route in Reihenfolge
+func (r *Route) SystemIDs() []int64 {
+	ids := make([]int64, len(r.Hops))
+	for i, h := range r.Hops {
+		ids[i] = h.SystemID
+	}
+	return ids
+}
+
+// policyPenalty gewichtet ein unerwünschtes System höher als jede mögliche Sprunganzahl
+const policyPenalty = 1 << 20
+
+// Route berechnet die Route von from nach to
+func (g *Graph) Route(from, to int64, opts RouteOptions) (*Route, error) {
+	if _, ok := g.systems[from]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSystem, from)
+	}
+	if _, ok := g.systems[to]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownSystem, to)
+	}
+
+	search, err := g.search(from, opts, map[int64]bool{to: true})
+	if err != nil {
+		return nil, err
+	}
+	route := search.route(to)
+	if route == nil {
+		return nil, fmt.Errorf("%w: %d -> %d", ErrNoRoute, from, to)
+	}
+	return route, nil
+}
+
+// searchResult enthält die Vorgänger eines Dijkstra-Laufs ab einem Startsystem
+type searchResult struct {
+	g      *Graph
+	from   int64
+	policy Policy
+	cost   map[int64]int64
+	prev   map[int64]int64
+}
+
+// search führt Dijkstra mit Policy-Kosten aus. Systeme in targets dürfen trotz
+// Vermeidungen betreten, aber nicht durchflogen werden.
+func (g *Graph) search(from int64, opts RouteOptions, targets map[int64]bool) (*searchResult, error) {
+	policy := opts.Policy
+	if policy == "" {
+		policy = PolicyShortest
+	}
+	if _, err := ParsePolicy(string(policy)); err != nil {
+		return nil, err
+	}
+
+	avoidSystems := make(map[int64]bool, len(opts.AvoidSystems))
+	for _, id := range opts.AvoidSystems {
+		avoidSystems[id] = true
+	}
+	avoidRegions := make(map[int64]bool, len(opts.AvoidRegions))
+	for _, id := range opts.AvoidRegions {
+		avoidRegions[id] = true
+	}
+
+	avoided := func(s System) bool {
+		if avoidSystems[s.SystemID] || avoidRegions[s.RegionID] {
+			return true
+		}
+		return opts.MinSecurity != nil && s.DisplaySecurity < *opts.MinSecurity
+	}
+
+	stepCost := func(s System) int64 {
+		switch {
+		case policy == PolicySafer && !g.highSec[s.SystemID]:
+			return 1 + policyPenalty
+		case policy == PolicyLessSecure && g.highSec[s.SystemID]:
+			return 1 + policyPenalty
+		default:
+			return 1
+		}
+	}
+
+	res := &searchResult{
+		g:      g,
+		from:   from,
+		policy: policy,
+		cost:   map[int64]int64{from: 0},
+		prev:   make(map[int64]int64),
+	}
+
+	pq := &costQueue{{system: from}}
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(costItem)
+		if cur.cost != res.cost[cur.system] {
+			continue
+		}
+		// Vermiedene Ziele werden erreicht, aber nicht durchflogen
+		if cur.system != from && avoided(g.systems[cur.system]) {
+			continue
+		}
+
+		for _, next := range g.adjacency[cur.system] {
+			s := g.systems[next]
+			if avoided(s) && !targets[next] {
+				continue
+			}
+			c := cur.cost + stepCost(s)
+			if old, ok := res.cost[next]; !ok || c < old {
+				res.cost[next] = c
+				res.prev[next] = cur.system
+				heap.Push(pq, costItem{system: next, cost: c})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// route rekonstruiert die Route zum Ziel (nil = nicht erreichbar)
+func (r *searchResult) route(to int64) *Route {
+	if _, ok := r.cost[to]; !ok {
+		return nil
+	}
+
+	path := []int64{to}
+	for cur := to; cur != r.from; {
+		cur = r.prev[cur]
+		path = append(path, cur)
+	}
+
+	route := &Route{
+		FromSystemID: r.from,
+		ToSystemID:   to,
+		Policy:       r.policy,
+		Jumps:        len(path) - 1,
+		Hops:         make([]Hop, len(path)),
+	}
+	for i := range path {
+		s := r.g.systems[path[len(path)-1-i]]
+		route.Hops[i] = Hop{System: s, Jump: i}
+		if i == 0 || s.SecurityStatus < route.MinSecurity {
+			route.MinSecurity = s.SecurityStatus
+		}
+	}
+	return route
+}
+
+// costItem ist ein Eintrag der Prioritätswarteschlange
+type costItem struct {
+	system int64
+	cost   int64
+}
+
+// costQueue implementiert heap.Interface (kleinste Kosten zuerst, dann kleinste System-ID)
+type costQueue []costItem
+
+func (q costQueue) Len() int { return len(q) }
+func (q costQueue) Less(i, j int) bool {
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].system < q[j].system
+}
+func (q costQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *costQueue) Push(x interface{}) { *q = append(*q, x.(costItem)) }
+func (q *costQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,120 @@
+package navigation
+
+import "fmt"
+
+// MultiRoute ist eine Rundreise über mehrere Stopps (z.B. Hauling-Contracts)
+type MultiRoute struct {
+	// Order: Start gefolgt von den Stopps in Flugreihenfolge (bei Rückkehr endet sie mit dem Start)
+	Order []int64 `json:"order"`
+	// Legs: Teilrouten zwischen aufeinanderfolgenden Systemen in Order
+	Legs  []*Route `json:"legs"`
+	Jumps int      `json:"jumps"`
+}
+
+// OrderStops ordnet die Stopps heuristisch (Nearest Neighbour + 2-opt), sodass die
+// Summe der Policy-Kosten minimal wird. Mit returnToStart endet die Tour am Start.
+func (g *Graph) OrderStops(start int64, stops []int64, opts RouteOptions, returnToStart bool) (*MultiRoute, error) {
+	// Knoten: Start + eindeutige Stopps
+	nodes := []int64{start}
+	seen := map[int64]bool{start: true}
+	for _, id := range stops {
+		if !seen[id] {
+			seen[id] = true
+			nodes = append(nodes, id)
+		}
+	}
+	for _, id := range nodes {
+		if _, ok := g.systems[id]; !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownSystem, id)
+		}
+	}
+
+	// Kostenmatrix aus je einem Dijkstra-Lauf pro Knoten
+	searches := make([]*searchResult, len(nodes))
+	cost := make([][]int64, len(nodes))
+	for i, from := range nodes {
+		s, err := g.search(from, opts, seen)
+		if err != nil {
+			return nil, err
+		}
+		searches[i] = s
+		cost[i] = make([]int64, len(nodes))
+		for j, to := range nodes {
+			c, ok := s.cost[to]
+			if !ok {
+				return nil, fmt.Errorf("%w: %d -> %d", ErrNoRoute, from, to)
+			}
+			cost[i][j] = c
+		}
+	}
+
+	tour := nearestNeighbour(cost)
+	tour = twoOpt(tour, cost, returnToStart)
+	if returnToStart && len(tour) > 1 {
+		tour = append(tour, 0)
+	}
+
+	result := &MultiRoute{Order: make([]int64, len(tour))}
+	for i, idx := range tour {
+		result.Order[i] = nodes[idx]
+	}
+	for i := 1; i < len(tour); i++ {
+		leg := searches[tour[i-1]].route(nodes[tour[i]])
+		result.Legs = append(result.Legs, leg)
+		result.Jumps += leg.Jumps
+	}
+	return result, nil
+}
+
+// nearestNeighbour baut eine Tour ab Knoten 0, indem stets der günstigste offene Knoten folgt
+func nearestNeighbour(cost [][]int64) []int {
+	visited := make([]bool, len(cost))
+	tour := []int{0}
+	visited[0] = true
+
+	for len(tour) < len(cost) {
+		cur := tour[len(tour)-1]
+		best := -1
+		for j := range cost {
+			if !visited[j] && (best < 0 || cost[cur][j] < cost[cur][best]) {
+				best = j
+			}
+		}
+		visited[best] = true
+		tour = append(tour, best)
+	}
+	return tour
+}
+
+// twoOpt verbessert die Tour durch Umkehren von Teilstücken, solange die Kosten sinken.
+// Der Start (Position 0) bleibt fest; Kosten werden vollständig neu berechnet,
+// da Policy-Kosten nicht symmetrisch sein müssen.
+func twoOpt(tour []int, cost [][]int64, closed bool) []int {
+	tourCost := func(t []int) int64 {
+		var total int64
+		for i := 1; i < len(t); i++ {
+			total += cost[t[i-1]][t[i]]
+		}
+		if closed && len(t) > 1 {
+			total += cost[t[len(t)-1]][t[0]]
+		}
+		return total
+	}
+
+	best := tourCost(tour)
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < len(tour)-1; i++ {
+			for j := i + 1; j < len(tour); j++ {
+				candidate := append([]int(nil), tour...)
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					candidate[a], candidate[b] = candidate[b], candidate[a]
+				}
+				if c := tourCost(candidate); c < best {
+					tour, best, improved = candidate, c, true
+				}
+			}
+		}
+	}
+	return tour
+}
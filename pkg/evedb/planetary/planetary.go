@@ -0,0 +1,304 @@
+// Package planetary löst Planetary-Interaction-Ketten (P0 → P4) einer eve-sde Datenbank auf:
+// Input-Baum je Stunde und Planeten eines Systems, die ein Produkt herstellen können
+package planetary
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrNotPI: Der Typ ist weder Rohstoff noch Produkt einer PI-Schematic
+	ErrNotPI = errors.New("type is not a PI commodity")
+	// ErrInvalidRate: Die Produktionsrate ist nicht positiv
+	ErrInvalidRate = errors.New("invalid production rate")
+	// ErrCycle: Die Schematics bilden einen Kreis
+	ErrCycle = errors.New("schematic cycle")
+)
+
+// Item ist ein Input bzw. Output einer Schematic je Zyklus
+type Item struct {
+	TypeID   int64  `json:"type_id"`
+	Name     string `json:"name,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+// Schematic ist ein PI-Rezept
+type Schematic struct {
+	SchematicID int64  `json:"schematic_id"`
+	Name        string `json:"name,omitempty"`
+	// CycleTime: Zyklusdauer in Sekunden
+	CycleTime int64  `json:"cycle_time"`
+	Output    Item   `json:"output"`
+	Inputs    []Item `json:"inputs"`
+}
+
+// Node ist ein Knoten im Input-Baum mit Rate je Stunde
+type Node struct {
+	TypeID  int64   `json:"type_id"`
+	Name    string  `json:"name,omitempty"`
+	Level   int     `json:"level"`
+	PerHour float64 `json:"per_hour"`
+	// SchematicID 0 markiert Rohstoffe (P0)
+	SchematicID int64 `json:"schematic_id,omitempty"`
+	// Factories: Parallel laufende Fabriken für die Rate
+	Factories float64 `json:"factories,omitempty"`
+	Inputs    []*Node `json:"inputs,omitempty"`
+}
+
+// Rate ist ein Bedarf je Stunde
+type Rate struct {
+	TypeID  int64   `json:"type_id"`
+	Name    string  `json:"name,omitempty"`
+	PerHour float64 `json:"per_hour"`
+}
+
+// Tree ist der vollständige Input-Baum eines Produkts
+type Tree struct {
+	Root *Node `json:"root"`
+	// Raw: Summierter Rohstoffbedarf (P0) je Stunde
+	Raw []Rate `json:"raw"`
+}
+
+// Chains enthält alle Schematics und die förderbaren Rohstoffe je Planetentyp
+type Chains struct {
+	byOutput  map[int64]Schematic
+	levels    map[int64]int
+	names     map[int64]string
+	resources map[int64][]int64 // Planetentyp → Rohstoffe
+}
+
+// NewChains erstellt die Ketten und berechnet die Stufen (P0 = von keiner Schematic hergestellt)
+func NewChains(schematics []Schematic, planetResources map[int64][]int64, names map[int64]string) (*Chains, error) {
+	c := &Chains{
+		byOutput:  make(map[int64]Schematic, len(schematics)),
+		levels:    make(map[int64]int),
+		names:     make(map[int64]string, len(names)),
+		resources: make(map[int64][]int64, len(planetResources)),
+	}
+	for id, name := range names {
+		c.names[id] = name
+	}
+	for _, s := range schematics {
+		if prev, ok := c.byOutput[s.Output.TypeID]; !ok || s.SchematicID < prev.SchematicID {
+			c.byOutput[s.Output.TypeID] = s
+		}
+	}
+	for planet, res := range planetResources {
+		c.resources[planet] = append([]int64(nil), res...)
+	}
+
+	visiting := make(map[int64]bool)
+	var level func(int64) (int, error)
+	level = func(id int64) (int, error) {
+		if l, ok := c.levels[id]; ok {
+			return l, nil
+		}
+		s, ok := c.byOutput[id]
+		if !ok {
+			c.levels[id] = 0
+			return 0, nil
+		}
+		if visiting[id] {
+			return 0, fmt.Errorf("%w at %d", ErrCycle, id)
+		}
+		visiting[id] = true
+		l := 0
+		for _, in := range s.Inputs {
+			il, err := level(in.TypeID)
+			if err != nil {
+				return 0, err
+			}
+			l = max(l, il+1)
+		}
+		visiting[id] = false
+		c.levels[id] = l
+		return l, nil
+	}
+	for _, s := range schematics {
+		if _, err := level(s.Output.TypeID); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// LoadChains lädt Schematics (v_pi_schematics, v_pi_schematic_inputs) und Rohstoffe je Planetentyp
+// (v_planet_resources)
+func LoadChains(db *sql.DB) (*Chains, error) {
+	names := make(map[int64]string)
+	byID := make(map[int64]*Schematic)
+	var ids []int64
+
+	rows, err := db.Query(`SELECT schematic_id, COALESCE(schematic_name, ''), cycle_time, output_type_id,
+		COALESCE(output_name, ''), output_quantity FROM v_pi_schematics ORDER BY schematic_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PI schematics: %w", err)
+	}
+	for rows.Next() {
+		var s Schematic
+		if err := rows.Scan(&s.SchematicID, &s.Name, &s.CycleTime, &s.Output.TypeID, &s.Output.Name, &s.Output.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan PI schematic: %w", err)
+		}
+		names[s.Output.TypeID] = s.Output.Name
+		byID[s.SchematicID] = &s
+		ids = append(ids, s.SchematicID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(`SELECT schematic_id, input_type_id, COALESCE(input_name, ''), quantity
+		FROM v_pi_schematic_inputs ORDER BY schematic_id, input_type_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PI schematic inputs: %w", err)
+	}
+	for rows.Next() {
+		var id int64
+		var in Item
+		if err := rows.Scan(&id, &in.TypeID, &in.Name, &in.Quantity); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan PI schematic input: %w", err)
+		}
+		names[in.TypeID] = in.Name
+		if s, ok := byID[id]; ok {
+			s.Inputs = append(s.Inputs, in)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resources := make(map[int64][]int64)
+	rows, err = db.Query(`SELECT planet_type_id, resource_type_id, COALESCE(resource_name, '')
+		FROM v_planet_resources ORDER BY planet_type_id, resource_type_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query planet resources: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var planet, resource int64
+		var name string
+		if err := rows.Scan(&planet, &resource, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan planet resource: %w", err)
+		}
+		names[resource] = name
+		resources[planet] = append(resources[planet], resource)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	schematics := make([]Schematic, 0, len(ids))
+	for _, id := range ids {
+		schematics = append(schematics, *byID[id])
+	}
+	return NewChains(schematics, resources, names)
+}
+
+// Level liefert die Stufe eines Typs (0 = P0 ... 4 = P4)
+func (c *Chains) Level(typeID int64) (int, bool) {
+	l, ok := c.levels[typeID]
+	return l, ok
+}
+
+// Schematic liefert die Schematic, die einen Typ herstellt
+func (c *Chains) Schematic(typeID int64) (Schematic, bool) {
+	s, ok := c.byOutput[typeID]
+	return s, ok
+}
+
+// Tree löst den vollständigen Input-Baum für perHour Einheiten je Stunde auf
+func (c *Chains) Tree(typeID int64, perHour float64) (*Tree, error) {
+	if _, ok := c.levels[typeID]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNotPI, typeID)
+	}
+	if perHour <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRate, perHour)
+	}
+
+	raw := make(map[int64]float64)
+	root := c.expand(typeID, perHour, raw)
+
+	t := &Tree{Root: root}
+	for id, rate := range raw {
+		t.Raw = append(t.Raw, Rate{TypeID: id, Name: c.names[id], PerHour: rate})
+	}
+	sort.Slice(t.Raw, func(i, j int) bool { return t.Raw[i].TypeID < t.Raw[j].TypeID })
+	return t, nil
+}
+
+// expand erstellt einen Knoten und summiert Rohstoffe in raw
+func (c *Chains) expand(typeID int64, perHour float64, raw map[int64]float64) *Node {
+	n := &Node{TypeID: typeID, Name: c.names[typeID], Level: c.levels[typeID], PerHour: perHour}
+	s, ok := c.byOutput[typeID]
+	if !ok {
+		raw[typeID] += perHour
+		return n
+	}
+
+	cyclesPerHour := perHour / float64(s.Output.Quantity)
+	n.SchematicID = s.SchematicID
+	n.Factories = cyclesPerHour * float64(s.CycleTime) / 3600
+	for _, in := range s.Inputs {
+		n.Inputs = append(n.Inputs, c.expand(in.TypeID, cyclesPerHour*float64(in.Quantity), raw))
+	}
+	return n
+}
+
+// Resources liefert alle Rohstoffe (P0) in der Kette eines Typs
+func (c *Chains) Resources(typeID int64) ([]int64, error) {
+	if _, ok := c.levels[typeID]; !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNotPI, typeID)
+	}
+	seen := make(map[int64]bool)
+	var walk func(int64)
+	walk = func(id int64) {
+		s, ok := c.byOutput[id]
+		if !ok {
+			seen[id] = true
+			return
+		}
+		for _, in := range s.Inputs {
+			walk(in.TypeID)
+		}
+	}
+	walk(typeID)
+
+	ids := make([]int64, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// PlanetTypes liefert die Planetentypen, auf denen alle Rohstoffe eines Typs förderbar sind
+func (c *Chains) PlanetTypes(typeID int64) ([]int64, error) {
+	needed, err := c.Resources(typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var planets []int64
+	for planet, res := range c.resources {
+		available := make(map[int64]bool, len(res))
+		for _, r := range res {
+			available[r] = true
+		}
+		ok := true
+		for _, r := range needed {
+			ok = ok && available[r]
+		}
+		if ok {
+			planets = append(planets, planet)
+		}
+	}
+	sort.Slice(planets, func(i, j int) bool { return planets[i] < planets[j] })
+	return planets, nil
+}
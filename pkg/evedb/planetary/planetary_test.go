@@ -0,0 +1,162 @@
+package planetary
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/planetary"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/views"
+)
+
+const (
+	aqueousLiquids int64 = 2268
+	microorganisms int64 = 2073
+	baseMetals     int64 = 2267
+	bacteria       int64 = 2393
+	coolant        int64 = 9832
+	nanites        int64 = 2463
+	broadcastNode  int64 = 2867
+	temperate      int64 = 11
+	barren         int64 = 2016
+	jita           int64 = 30000142
+)
+
+// fixtureDB legt eine PI-Kette P0 → P4 auf einem Temperate- und einem Barren-Planeten in Jita an
+func fixtureDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t, "types", "planetSchematics", "mapSolarSystems", "mapPlanets")
+
+	if _, err := db.Exec(`
+		INSERT INTO types (_key, name) VALUES
+			(11, '{"en":"Planet (Temperate)"}'), (2016, '{"en":"Planet (Barren)"}'),
+			(2268, '{"en":"Aqueous Liquids"}'), (2073, '{"en":"Microorganisms"}'), (2267, '{"en":"Base Metals"}'),
+			(3645, '{"en":"Water"}'), (2393, '{"en":"Bacteria"}'), (2389, '{"en":"Reactive Metals"}'),
+			(9832, '{"en":"Coolant"}'), (2463, '{"en":"Nanites"}'), (2867, '{"en":"Broadcast Node"}'),
+			(2481, '{"en":"Temperate Basic Industry Facility"}'), (2473, '{"en":"Barren Basic Industry Facility"}'),
+			(2474, '{"en":"Barren Advanced Industry Facility"}'), (2475, '{"en":"Barren High-Tech Production Plant"}'),
+			(2848, '{"en":"Barren Extractor Control Unit"}'), (3068, '{"en":"Temperate Extractor Control Unit"}');
+		INSERT INTO planetSchematics (_key, cycleTime, name, pins, types) VALUES
+			(121, 1800, '{"en":"Water"}', '[2481,2473]', '[{"_key":2268,"isInput":true,"quantity":3000},{"_key":3645,"isInput":false,"quantity":20}]'),
+			(131, 1800, '{"en":"Bacteria"}', '[2481,2473]', '[{"_key":2073,"isInput":true,"quantity":3000},{"_key":2393,"isInput":false,"quantity":20}]'),
+			(134, 1800, '{"en":"Reactive Metals"}', '[2473]', '[{"_key":2267,"isInput":true,"quantity":3000},{"_key":2389,"isInput":false,"quantity":20}]'),
+			(66, 3600, '{"en":"Coolant"}', '[2474]', '[{"_key":3645,"isInput":true,"quantity":40},{"_key":2393,"isInput":true,"quantity":40},{"_key":9832,"isInput":false,"quantity":5}]'),
+			(80, 3600, '{"en":"Nanites"}', '[2474]', '[{"_key":9832,"isInput":true,"quantity":10},{"_key":2389,"isInput":true,"quantity":10},{"_key":2463,"isInput":false,"quantity":3}]'),
+			(100, 3600, '{"en":"Broadcast Node"}', '[2475]', '[{"_key":2463,"isInput":true,"quantity":6},{"_key":2393,"isInput":true,"quantity":40},{"_key":2867,"isInput":false,"quantity":1}]');
+		INSERT INTO mapSolarSystems (_key, name) VALUES (30000142, '{"en":"Jita"}');
+		INSERT INTO mapPlanets (_key, solarSystemID, typeID, celestialIndex) VALUES (40009077, 30000142, 11, 1), (40009078, 30000142, 2016, 2);
+	`); err != nil {
+		t.Fatalf("Failed to create planetary fixture: %v", err)
+	}
+
+	planets := []planetary.Planet{
+		{Planet: "Temperate", Resources: []string{"Aqueous Liquids", "Microorganisms"}},
+		{Planet: "Barren", Resources: []string{"Aqueous Liquids", "Base Metals", "Microorganisms"}},
+	}
+	if _, err := planetary.BuildTables(db, planets); err != nil {
+		t.Fatalf("BuildTables failed: %v", err)
+	}
+	if err := views.InitializePlanetaryViews(db); err != nil {
+		t.Fatalf("InitializePlanetaryViews failed: %v", err)
+	}
+	return db
+}
+
+func loadChains(t *testing.T) (*sql.DB, *Chains) {
+	t.Helper()
+	db := fixtureDB(t)
+	c, err := LoadChains(db)
+	if err != nil {
+		t.Fatalf("LoadChains failed: %v", err)
+	}
+	return db, c
+}
+
+func TestTree(t *testing.T) {
+	_, c := loadChains(t)
+
+	if l, ok := c.Level(broadcastNode); !ok || l != 4 {
+		t.Errorf("Level(broadcastNode) = %d, %v; want 4", l, ok)
+	}
+
+	tree, err := c.Tree(broadcastNode, 1)
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+	root := tree.Root
+	if root.SchematicID != 100 || root.Factories != 1 || len(root.Inputs) != 2 {
+		t.Fatalf("root = %+v", root)
+	}
+	// 6 Nanites/h bei 3 je Stunden-Zyklus → 2 Fabriken
+	if n := root.Inputs[1]; n.TypeID != nanites || n.PerHour != 6 || n.Factories != 2 || n.Level != 3 {
+		t.Errorf("nanites = %+v", n)
+	}
+
+	// Aqueous: 160 Water → 8 Zyklen × 3000; Microorganisms: (160 + 40) Bacteria → 10 Zyklen × 3000
+	want := map[int64]float64{aqueousLiquids: 24000, microorganisms: 30000, baseMetals: 3000}
+	if len(tree.Raw) != len(want) {
+		t.Fatalf("Raw = %+v, want %v", tree.Raw, want)
+	}
+	for _, r := range tree.Raw {
+		if math.Abs(r.PerHour-want[r.TypeID]) > 1e-9 {
+			t.Errorf("raw %d (%s) = %v, want %v", r.TypeID, r.Name, r.PerHour, want[r.TypeID])
+		}
+	}
+
+	if _, err := c.Tree(34, 1); !errors.Is(err, ErrNotPI) {
+		t.Errorf("Tree(34): err = %v, want ErrNotPI", err)
+	}
+	if _, err := c.Tree(broadcastNode, 0); !errors.Is(err, ErrInvalidRate) {
+		t.Errorf("Tree(rate 0): err = %v, want ErrInvalidRate", err)
+	}
+}
+
+func TestPlanets(t *testing.T) {
+	db, c := loadChains(t)
+
+	res, err := c.Resources(broadcastNode)
+	if err != nil || !reflect.DeepEqual(res, []int64{microorganisms, baseMetals, aqueousLiquids}) {
+		t.Errorf("Resources(broadcastNode) = %v, %v", res, err)
+	}
+
+	tests := []struct {
+		typeID  int64
+		planets []int64
+	}{
+		{broadcastNode, []int64{40009078}},      // Base Metals nur auf Barren
+		{coolant, []int64{40009077, 40009078}},  // Aqueous Liquids + Microorganisms überall
+		{bacteria, []int64{40009077, 40009078}}, // P1
+		{baseMetals, []int64{40009078}},         // P0 direkt
+	}
+	for _, tt := range tests {
+		planets, err := c.PlanetsInSystem(db, jita, tt.typeID)
+		if err != nil {
+			t.Fatalf("PlanetsInSystem(%d) failed: %v", tt.typeID, err)
+		}
+		var ids []int64
+		for _, p := range planets {
+			ids = append(ids, p.PlanetID)
+		}
+		if !reflect.DeepEqual(ids, tt.planets) {
+			t.Errorf("PlanetsInSystem(%d) = %v, want %v", tt.typeID, ids, tt.planets)
+		}
+	}
+
+	if types, _ := c.PlanetTypes(coolant); !reflect.DeepEqual(types, []int64{temperate, barren}) {
+		t.Errorf("PlanetTypes(coolant) = %v", types)
+	}
+}
+
+func TestNewChains_Cycle(t *testing.T) {
+	_, err := NewChains([]Schematic{
+		{SchematicID: 1, Output: Item{TypeID: 10, Quantity: 1}, Inputs: []Item{{TypeID: 20, Quantity: 1}}},
+		{SchematicID: 2, Output: Item{TypeID: 20, Quantity: 1}, Inputs: []Item{{TypeID: 10, Quantity: 1}}},
+	}, nil, nil)
+	if !errors.Is(err, ErrCycle) {
+		t.Errorf("err = %v, want ErrCycle", err)
+	}
+}
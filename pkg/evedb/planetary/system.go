@@ -0,0 +1,46 @@
+package planetary
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Planet ist ein Planet eines Sonnensystems
+type Planet struct {
+	PlanetID       int64  `json:"planet_id"`
+	CelestialIndex int64  `json:"celestial_index"`
+	PlanetTypeID   int64  `json:"planet_type_id"`
+	PlanetClass    string `json:"planet_class"`
+}
+
+// PlanetsInSystem liefert die Planeten eines Systems, die einen Typ allein herstellen können
+// (alle Rohstoffe der Kette auf dem Planeten förderbar; v_system_planet_resources)
+func (c *Chains) PlanetsInSystem(db *sql.DB, systemID, typeID int64) ([]Planet, error) {
+	types, err := c.PlanetTypes(typeID)
+	if err != nil {
+		return nil, err
+	}
+	suitable := make(map[int64]bool, len(types))
+	for _, id := range types {
+		suitable[id] = true
+	}
+
+	rows, err := db.Query(`SELECT DISTINCT planet_id, COALESCE(celestial_index, 0), planet_type_id, planet_class
+		FROM v_system_planet_resources WHERE system_id = ? ORDER BY celestial_index, planet_id`, systemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query system planets: %w", err)
+	}
+	defer rows.Close()
+
+	var planets []Planet
+	for rows.Next() {
+		var p Planet
+		if err := rows.Scan(&p.PlanetID, &p.CelestialIndex, &p.PlanetTypeID, &p.PlanetClass); err != nil {
+			return nil, fmt.Errorf("failed to scan system planet: %w", err)
+		}
+		if suitable[p.PlanetTypeID] {
+			planets = append(planets, p)
+		}
+	}
+	return planets, rows.Err()
+}
@@ -0,0 +1,228 @@
+// Package reprocessing berechnet die Ausbeute beim Reprocessing von Erz, Eis und Items aus typeMaterials
+// unter Stations- bzw. Strukturbasis, Skills und Implantaten
+package reprocessing
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrNotReprocessable: Der Typ hat keine typeMaterials
+	ErrNotReprocessable = errors.New("type is not reprocessable")
+	// ErrUnknownStation: Die Station existiert nicht oder bietet kein Reprocessing
+	ErrUnknownStation = errors.New("unknown reprocessing station")
+	// ErrInvalidOptions: Ungültige Menge, Basisausbeute, Skill-Stufe oder Implantat
+	ErrInvalidOptions = errors.New("invalid reprocessing options")
+)
+
+// Skills, die für alle Erze und Eis gelten bzw. Items ohne erzspezifischen Skill
+const (
+	SkillReprocessing           int64 = 3385
+	SkillReprocessingEfficiency int64 = 3389
+	SkillScrapmetalProcessing   int64 = 12196
+)
+
+// DefaultBaseYield ist die Basisausbeute einer NPC-Station
+const DefaultBaseYield = 0.5
+
+// Rig-, Security- und Struktur-Modifikatoren für StructureBaseYield
+const (
+	RigNone = 0.0
+	RigT1   = 1.0
+	RigT2   = 3.0
+
+	SecurityHigh = 0.0
+	SecurityLow  = 0.06
+	SecurityNull = 0.12 // auch Wormhole-Space
+
+	StructureNone    = 0.0
+	StructureAthanor = 0.02
+	StructureTatara  = 0.055
+)
+
+// Options beschreibt Ort, Skills und Implantat
+type Options struct {
+	// BaseYield: Basisausbeute von Station bzw. Struktur (0 = DefaultBaseYield)
+	BaseYield float64
+	// Skills: Skill-TypeID → Stufe (fehlend = 0), erzspezifische Skills laut reprocessingSkillType
+	Skills map[int64]int
+	// Implant: Bonus des Reprocessing-Implantats (0.04 = 4 %), nur für Erz und Eis
+	Implant float64
+}
+
+// Material ist ein Reprocessing-Ergebnis je Portion bei 100 % Ausbeute
+type Material struct {
+	TypeID   int64  `json:"type_id"`
+	Name     string `json:"name,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+// Type ist ein reprocessbarer Typ
+type Type struct {
+	TypeID      int64  `json:"type_id"`
+	Name        string `json:"name,omitempty"`
+	PortionSize int64  `json:"portion_size"`
+	// SkillTypeID: Erzspezifischer Skill (0 = Item, Scrapmetal Processing)
+	SkillTypeID int64      `json:"skill_type_id,omitempty"`
+	Materials   []Material `json:"materials"`
+}
+
+// Output ist die Menge eines Materials nach Reprocessing
+type Output struct {
+	TypeID int64  `json:"type_id"`
+	Name   string `json:"name,omitempty"`
+	// Perfect: Menge bei 100 % Ausbeute, Quantity: tatsächliche Menge (abgerundet)
+	Perfect  int64 `json:"perfect"`
+	Quantity int64 `json:"quantity"`
+}
+
+// Result ist das Ergebnis eines Reprocessing-Vorgangs
+type Result struct {
+	TypeID   int64 `json:"type_id"`
+	Quantity int64 `json:"quantity"`
+	Portions int64 `json:"portions"`
+	// Leftover: Einheiten unterhalb einer Portion, die unverarbeitet zurückbleiben
+	Leftover  int64    `json:"leftover"`
+	Yield     float64  `json:"yield"`
+	Materials []Output `json:"materials"`
+}
+
+// Station ist eine NPC-Station mit Reprocessing
+type Station struct {
+	StationID     int64   `json:"station_id"`
+	SolarSystemID int64   `json:"solar_system_id"`
+	BaseYield     float64 `json:"base_yield"`
+	// Take: Abgabe der Station (heute als ISK-Steuer auf den Materialwert erhoben)
+	Take float64 `json:"take"`
+}
+
+// StructureBaseYield berechnet die Basisausbeute einer Struktur: (50 + Rig) × (1 + Security) × (1 + Struktur) / 100
+func StructureBaseYield(rig, security, structure float64) float64 {
+	return (50 + rig) * (1 + security) * (1 + structure) / 100
+}
+
+// Yield berechnet die Ausbeute für einen Typ.
+// Erz und Eis: base × (1 + 0.03 × Reprocessing) × (1 + 0.02 × Efficiency) × (1 + 0.02 × Erz-Skill) × (1 + Implantat);
+// Items: base × (1 + 0.02 × Scrapmetal Processing)
+func Yield(t Type, opts Options) float64 {
+	base := opts.BaseYield
+	if base == 0 {
+		base = DefaultBaseYield
+	}
+	level := func(id int64) float64 { return float64(opts.Skills[id]) }
+
+	if t.SkillTypeID == 0 {
+		return math.Min(1, base*(1+0.02*level(SkillScrapmetalProcessing)))
+	}
+	y := base *
+		(1 + 0.03*level(SkillReprocessing)) *
+		(1 + 0.02*level(SkillReprocessingEfficiency)) *
+		(1 + 0.02*level(t.SkillTypeID)) *
+		(1 + opts.Implant)
+	return math.Min(1, y)
+}
+
+// Calculate berechnet das Reprocessing von quantity Einheiten. Nur volle Portionen werden verarbeitet,
+// jede Materialmenge wird abgerundet.
+func Calculate(t Type, quantity int64, opts Options) (Result, error) {
+	if err := validate(quantity, opts); err != nil {
+		return Result{}, err
+	}
+	portion := max(1, t.PortionSize)
+
+	r := Result{
+		TypeID:   t.TypeID,
+		Quantity: quantity,
+		Portions: quantity / portion,
+		Leftover: quantity % portion,
+		Yield:    Yield(t, opts),
+	}
+	for _, m := range t.Materials {
+		perfect := r.Portions * m.Quantity
+		r.Materials = append(r.Materials, Output{
+			TypeID:   m.TypeID,
+			Name:     m.Name,
+			Perfect:  perfect,
+			Quantity: int64(math.Floor(float64(perfect)*r.Yield + 1e-9)),
+		})
+	}
+	return r, nil
+}
+
+// validate prüft Menge, Basisausbeute, Skill-Stufen und Implantat
+func validate(quantity int64, opts Options) error {
+	switch {
+	case quantity < 0:
+		return fmt.Errorf("%w: quantity %d", ErrInvalidOptions, quantity)
+	case opts.BaseYield < 0 || opts.BaseYield > 1:
+		return fmt.Errorf("%w: base yield %v", ErrInvalidOptions, opts.BaseYield)
+	case opts.Implant < 0 || opts.Implant > 1:
+		return fmt.Errorf("%w: implant %v", ErrInvalidOptions, opts.Implant)
+	}
+	for id, level := range opts.Skills {
+		if level < 0 || level > 5 {
+			return fmt.Errorf("%w: skill %d level %d", ErrInvalidOptions, id, level)
+		}
+	}
+	return nil
+}
+
+// LoadType lädt Portionsgröße, Materialien und erzspezifischen Skill eines Typs
+// (v_reprocessing_materials, v_asteroid_types)
+func LoadType(db *sql.DB, typeID int64) (Type, error) {
+	rows, err := db.Query(`SELECT COALESCE(type_name, ''), portion_size, material_type_id, COALESCE(material_name, ''), quantity
+		FROM v_reprocessing_materials WHERE type_id = ? ORDER BY material_type_id`, typeID)
+	if err != nil {
+		return Type{}, fmt.Errorf("failed to query reprocessing materials: %w", err)
+	}
+	defer rows.Close()
+
+	t := Type{TypeID: typeID}
+	for rows.Next() {
+		var m Material
+		if err := rows.Scan(&t.Name, &t.PortionSize, &m.TypeID, &m.Name, &m.Quantity); err != nil {
+			return Type{}, fmt.Errorf("failed to scan reprocessing material: %w", err)
+		}
+		t.Materials = append(t.Materials, m)
+	}
+	if err := rows.Err(); err != nil {
+		return Type{}, err
+	}
+	if len(t.Materials) == 0 {
+		return Type{}, fmt.Errorf("%w: %d", ErrNotReprocessable, typeID)
+	}
+
+	var skill sql.NullInt64
+	err = db.QueryRow(`SELECT skill_type_id FROM v_asteroid_types WHERE type_id = ?`, typeID).Scan(&skill)
+	if err != nil && err != sql.ErrNoRows {
+		return Type{}, fmt.Errorf("failed to query reprocessing skill: %w", err)
+	}
+	t.SkillTypeID = skill.Int64
+	return t, nil
+}
+
+// Reprocess lädt einen Typ und berechnet das Reprocessing von quantity Einheiten
+func Reprocess(db *sql.DB, typeID, quantity int64, opts Options) (Result, error) {
+	t, err := LoadType(db, typeID)
+	if err != nil {
+		return Result{}, err
+	}
+	return Calculate(t, quantity, opts)
+}
+
+// LoadStation lädt Basisausbeute und Abgabe einer NPC-Station (v_station_reprocessing)
+func LoadStation(db *sql.DB, stationID int64) (Station, error) {
+	s := Station{StationID: stationID}
+	err := db.QueryRow(`SELECT solar_system_id, reprocessing_efficiency, COALESCE(stations_take, 0)
+		FROM v_station_reprocessing WHERE station_id = ?`, stationID).Scan(&s.SolarSystemID, &s.BaseYield, &s.Take)
+	switch {
+	case err == sql.ErrNoRows:
+		return Station{}, fmt.Errorf("%w: %d", ErrUnknownStation, stationID)
+	case err != nil:
+		return Station{}, fmt.Errorf("failed to query station %d: %w", stationID, err)
+	}
+	return s, nil
+}
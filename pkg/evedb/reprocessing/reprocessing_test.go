@@ -0,0 +1,135 @@
+package reprocessing
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/views"
+)
+
+const (
+	tritanium          int64 = 34
+	veldspar           int64 = 1230
+	compressedVeldspar int64 = 28432
+	veldsparProcessing int64 = 12180
+	autocannon         int64 = 486
+)
+
+// fixtureDB legt Veldspar, Compressed Veldspar, ein Modul und eine Station an
+func fixtureDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t, "groups", "types", "typeMaterials", "typeDogma", "dogmaAttributes", "npcStations", "mapSolarSystems")
+
+	if _, err := db.Exec(`
+		INSERT INTO groups (_key, name, categoryID) VALUES (462, '{"en":"Veldspar"}', 25), (18, '{"en":"Mineral"}', 4), (55, '{"en":"Projectile Weapon"}', 7);
+		INSERT INTO types (_key, name, groupID, portionSize, volume, published) VALUES
+			(1230, '{"en":"Veldspar"}', 462, 100, 0.1, 1),
+			(28432, '{"en":"Compressed Veldspar"}', 462, 1, 0.001, 1),
+			(34, '{"en":"Tritanium"}', 18, 1, 0.01, 1),
+			(486, '{"en":"125mm Gatling AutoCannon I"}', 55, 1, 5, 1);
+		INSERT INTO typeMaterials (_key, materials) VALUES
+			(1230, '[{"materialTypeID":34,"quantity":400}]'),
+			(28432, '[{"materialTypeID":34,"quantity":400}]'),
+			(486, '[{"materialTypeID":34,"quantity":250}]');
+		INSERT INTO dogmaAttributes (_key, name) VALUES (790, 'reprocessingSkillType');
+		INSERT INTO typeDogma (_key, dogmaAttributes) VALUES (1230, '[{"attributeID":790,"value":12180}]'), (28432, '[{"attributeID":790,"value":12180}]');
+		INSERT INTO mapSolarSystems (_key, name, securityStatus) VALUES (30000142, '{"en":"Jita"}', 0.946);
+		INSERT INTO npcStations (_key, solarSystemID, reprocessingEfficiency, reprocessingStationsTake) VALUES (60003760, 30000142, 0.5, 0.05);
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	if err := views.InitializeReprocessingViews(db); err != nil {
+		t.Fatalf("InitializeReprocessingViews failed: %v", err)
+	}
+	return db
+}
+
+func TestYield(t *testing.T) {
+	ore := Type{TypeID: veldspar, SkillTypeID: veldsparProcessing}
+	maxed := map[int64]int{SkillReprocessing: 5, SkillReprocessingEfficiency: 5, veldsparProcessing: 5, SkillScrapmetalProcessing: 5}
+
+	tests := []struct {
+		name string
+		t    Type
+		opts Options
+		want float64
+	}{
+		{"station ohne Skills", ore, Options{}, 0.5},
+		{"station, Skills V, RX-804", ore, Options{Skills: maxed, Implant: 0.04}, 0.5 * 1.15 * 1.1 * 1.1 * 1.04},
+		{"Tatara T2 Nullsec", ore, Options{BaseYield: StructureBaseYield(RigT2, SecurityNull, StructureTatara), Skills: maxed},
+			0.53 * 1.12 * 1.055 * 1.15 * 1.1 * 1.1},
+		{"Item mit Scrapmetal V", Type{TypeID: autocannon}, Options{Skills: maxed, Implant: 0.04}, 0.55},
+		{"Obergrenze", ore, Options{BaseYield: 0.9, Skills: maxed}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Yield(tt.t, tt.opts); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Yield = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	ore := Type{TypeID: veldspar, PortionSize: 100, SkillTypeID: veldsparProcessing,
+		Materials: []Material{{TypeID: tritanium, Name: "Tritanium", Quantity: 400}}}
+
+	r, err := Calculate(ore, 1050, Options{Skills: map[int64]int{SkillReprocessing: 5, SkillReprocessingEfficiency: 5, veldsparProcessing: 5}, Implant: 0.04})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	// 10 Portionen × 400 × 0.72358 = 2894.32
+	if r.Portions != 10 || r.Leftover != 50 || len(r.Materials) != 1 || r.Materials[0].Perfect != 4000 || r.Materials[0].Quantity != 2894 {
+		t.Errorf("Calculate = %+v", r)
+	}
+
+	for _, opts := range []Options{{BaseYield: 1.5}, {Implant: -0.1}, {Skills: map[int64]int{SkillReprocessing: 6}}} {
+		if _, err := Calculate(ore, 100, opts); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Calculate(%+v): err = %v, want ErrInvalidOptions", opts, err)
+		}
+	}
+	if _, err := Calculate(ore, -1, Options{}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Calculate(-1): err = %v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestReprocessFromDB(t *testing.T) {
+	db := fixtureDB(t)
+
+	station, err := LoadStation(db, 60003760)
+	if err != nil {
+		t.Fatalf("LoadStation failed: %v", err)
+	}
+	if station.SolarSystemID != 30000142 || station.BaseYield != 0.5 || station.Take != 0.05 {
+		t.Errorf("LoadStation = %+v", station)
+	}
+
+	// Komprimiertes Erz: Portion 1, gleicher Skill wie das Basiserz
+	r, err := Reprocess(db, compressedVeldspar, 3, Options{BaseYield: station.BaseYield})
+	if err != nil {
+		t.Fatalf("Reprocess failed: %v", err)
+	}
+	if r.Portions != 3 || r.Yield != 0.5 || r.Materials[0].Quantity != 600 || r.Materials[0].Name != "Tritanium" {
+		t.Errorf("Reprocess(compressed) = %+v", r)
+	}
+
+	ore, err := LoadType(db, veldspar)
+	if err != nil || ore.PortionSize != 100 || ore.SkillTypeID != veldsparProcessing {
+		t.Errorf("LoadType(veldspar) = %+v, %v", ore, err)
+	}
+	item, err := LoadType(db, autocannon)
+	if err != nil || item.SkillTypeID != 0 {
+		t.Errorf("LoadType(autocannon) = %+v, %v", item, err)
+	}
+
+	if _, err := LoadType(db, tritanium); !errors.Is(err, ErrNotReprocessable) {
+		t.Errorf("LoadType(tritanium): err = %v, want ErrNotReprocessable", err)
+	}
+	if _, err := LoadStation(db, 1); !errors.Is(err, ErrUnknownStation) {
+		t.Errorf("LoadStation(1): err = %v, want ErrUnknownStation", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Package skills löst Skill-Voraussetzungen einer eve-sde Datenbank rekursiv auf und berechnet Skillpunkte
+// und Trainingszeit aus den Charakterattributen
+package skills
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrUnknownSkill: Eine Voraussetzung verweist auf einen Typ, der kein Skill ist
+	ErrUnknownSkill = errors.New("unknown skill")
+	// ErrInvalidLevel: Skill-Stufe außerhalb 0–5
+	ErrInvalidLevel = errors.New("invalid skill level")
+	// ErrCycle: Die Voraussetzungen bilden einen Kreis
+	ErrCycle = errors.New("skill requirement cycle")
+	// ErrInvalidAttributes: Primär- und Sekundärattribut ergeben keine positive Trainingsrate
+	ErrInvalidAttributes = errors.New("invalid character attributes")
+)
+
+// MaxLevel ist die höchste Skill-Stufe
+const MaxLevel = 5
+
+// Requirement ist ein benötigter Skill mit Stufe
+type Requirement struct {
+	SkillTypeID int64 `json:"skill_type_id"`
+	Level       int   `json:"level"`
+}
+
+// Skill ist ein Skill mit Rang (skillTimeConstant), Trainingsattributen und direkten Voraussetzungen
+type Skill struct {
+	TypeID int64   `json:"type_id"`
+	Name   string  `json:"name,omitempty"`
+	Rank   float64 `json:"rank"`
+	// Primary, Secondary: Attributnamen aus characterAttributes ("Perception", ...)
+	Primary      string        `json:"primary"`
+	Secondary    string        `json:"secondary"`
+	Requirements []Requirement `json:"requirements,omitempty"`
+}
+
+// Attribute sind die Metadaten eines Charakterattributs (characterAttributes)
+type Attribute struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	ShortDescription string `json:"short_description,omitempty"`
+	Description      string `json:"description,omitempty"`
+}
+
+// Attributes sind die Attributwerte eines Charakters (Attributname → Wert, Groß-/Kleinschreibung egal)
+type Attributes map[string]float64
+
+// DefaultAttributes sind die Attribute eines neuen Charakters ohne Remap und Implantate
+var DefaultAttributes = Attributes{"Charisma": 19, "Intelligence": 20, "Memory": 20, "Perception": 20, "Willpower": 20}
+
+// Value liefert den Wert eines Attributs
+func (a Attributes) Value(name string) float64 {
+	if v, ok := a[name]; ok {
+		return v
+	}
+	for n, v := range a {
+		if strings.EqualFold(n, name) {
+			return v
+		}
+	}
+	return 0
+}
+
+// SPPerMinute liefert die Trainingsrate eines Skills: Primärattribut + Sekundärattribut / 2
+func (a Attributes) SPPerMinute(primary, secondary string) float64 {
+	return a.Value(primary) + a.Value(secondary)/2
+}
+
+// SkillPoints liefert die kumulierten Skillpunkte einer Stufe: ceil(250 × Rang × √32^(Stufe-1))
+func SkillPoints(rank float64, level int) int64 {
+	if level <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(250 * rank * math.Pow(32, float64(level-1)/2)))
+}
+
+// Data enthält alle Skills, die direkten Voraussetzungen aller Typen und die Charakterattribute
+type Data struct {
+	skills       map[int64]*Skill
+	requirements map[int64][]Requirement
+	attributes   []Attribute
+}
+
+// NewData erstellt die Daten; requirements enthält die direkten Voraussetzungen aller Typen (auch der Skills)
+func NewData(skills []Skill, requirements map[int64][]Requirement, attributes []Attribute) *Data {
+	d := &Data{
+		skills:       make(map[int64]*Skill, len(skills)),
+		requirements: make(map[int64][]Requirement, len(requirements)),
+		attributes:   append([]Attribute(nil), attributes...),
+	}
+	for id, reqs := range requirements {
+		d.requirements[id] = append([]Requirement(nil), reqs...)
+	}
+	for _, s := range skills {
+		s.Requirements = d.requirements[s.TypeID]
+		d.skills[s.TypeID] = &s
+	}
+	return d
+}
+
+// LoadData lädt Skills (v_skills), Voraussetzungen (v_skill_requirements) und characterAttributes
+func LoadData(db *sql.DB) (*Data, error) {
+	var skills []Skill
+	rows, err := db.Query(`SELECT skill_type_id, COALESCE(skill_name, ''), rank,
+		COALESCE(primary_attribute, ''), COALESCE(secondary_attribute, '') FROM v_skills ORDER BY skill_type_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skills: %w", err)
+	}
+	for rows.Next() {
+		var s Skill
+		if err := rows.Scan(&s.TypeID, &s.Name, &s.Rank, &s.Primary, &s.Secondary); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan skill: %w", err)
+		}
+		skills = append(skills, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	requirements := make(map[int64][]Requirement)
+	rows, err = db.Query(`SELECT type_id, skill_type_id, level FROM v_skill_requirements ORDER BY type_id, slot`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query skill requirements: %w", err)
+	}
+	for rows.Next() {
+		var typeID int64
+		var r Requirement
+		if err := rows.Scan(&typeID, &r.SkillTypeID, &r.Level); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan skill requirement: %w", err)
+		}
+		requirements[typeID] = append(requirements[typeID], r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var attributes []Attribute
+	rows, err = db.Query(`SELECT _key, COALESCE(json_extract(name, '$.en'), ''), COALESCE(shortDescription, ''),
+		COALESCE(description, '') FROM characterAttributes ORDER BY _key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query character attributes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var a Attribute
+		if err := rows.Scan(&a.ID, &a.Name, &a.ShortDescription, &a.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan character attribute: %w", err)
+		}
+		attributes = append(attributes, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewData(skills, requirements, attributes), nil
+}
+
+// Skill liefert einen Skill
+func (d *Data) Skill(typeID int64) (*Skill, bool) {
+	s, ok := d.skills[typeID]
+	return s, ok
+}
+
+// Requirements liefert die direkten Skill-Voraussetzungen eines Typs
+func (d *Data) Requirements(typeID int64) []Requirement {
+	return d.requirements[typeID]
+}
+
+// Attributes liefert die Metadaten der Charakterattribute
+func (d *Data) Attributes() []Attribute {
+	return d.attributes
+}
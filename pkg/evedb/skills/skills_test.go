@@ -0,0 +1,206 @@
+package skills
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Sternrassler/eve-sde/internal/sqlite/sqlitetest"
+	"github.com/Sternrassler/eve-sde/internal/sqlite/views"
+)
+
+// Skills der Fixture
+const (
+	gunnery          int64 = 3300
+	smallHybrid      int64 = 3301
+	surgicalStrike   int64 = 3315
+	spaceshipCommand int64 = 3327
+	caldariFrigate   int64 = 3330
+	merlin           int64 = 603
+	railgun          int64 = 561
+)
+
+func setupTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t, "types", "groups", "typeDogma", "dogmaAttributes", "characterAttributes")
+
+	if _, err := db.Exec(`
+		INSERT INTO groups (_key, name, categoryID) VALUES (255, '{"en":"Gunnery"}', 16), (257, '{"en":"Spaceship Command"}', 16),
+			(25, '{"en":"Frigate"}', 6), (74, '{"en":"Hybrid Weapon"}', 7);
+		INSERT INTO types (_key, name, groupID, published) VALUES
+			(3300, '{"en":"Gunnery"}', 255, 1), (3301, '{"en":"Small Hybrid Turret"}', 255, 1),
+			(3315, '{"en":"Surgical Strike"}', 255, 1), (3327, '{"en":"Spaceship Command"}', 257, 1),
+			(3330, '{"en":"Caldari Frigate"}', 257, 1), (603, '{"en":"Merlin"}', 25, 1),
+			(561, '{"en":"75mm Gatling Rail I"}', 74, 1);
+		INSERT INTO dogmaAttributes (_key, name) VALUES
+			(182, 'requiredSkill1'), (183, 'requiredSkill2'), (277, 'requiredSkill1Level'), (278, 'requiredSkill2Level'),
+			(275, 'skillTimeConstant'), (180, 'primaryAttribute'), (181, 'secondaryAttribute'),
+			(164, 'charisma'), (165, 'intelligence'), (166, 'memory'), (167, 'perception'), (168, 'willpower');
+		INSERT INTO characterAttributes (_key, name, shortDescription, description) VALUES
+			(1, '{"en":"Perception"}', 'PER', 'Spatial awareness'), (2, '{"en":"Memory"}', 'MEM', ''),
+			(3, '{"en":"Willpower"}', 'WIL', ''), (4, '{"en":"Intelligence"}', 'INT', ''), (5, '{"en":"Charisma"}', 'CHA', '');
+		INSERT INTO typeDogma (_key, dogmaAttributes, dogmaEffects) VALUES
+			(3300, '[{"attributeID":275,"value":1},{"attributeID":180,"value":167},{"attributeID":181,"value":168}]', '[]'),
+			(3301, '[{"attributeID":275,"value":1},{"attributeID":180,"value":167},{"attributeID":181,"value":168},
+				{"attributeID":182,"value":3300},{"attributeID":277,"value":1}]', '[]'),
+			(3315, '[{"attributeID":275,"value":4},{"attributeID":180,"value":167},{"attributeID":181,"value":168},
+				{"attributeID":182,"value":3300},{"attributeID":277,"value":4}]', '[]'),
+			(3327, '[{"attributeID":275,"value":1},{"attributeID":180,"value":167},{"attributeID":181,"value":168}]', '[]'),
+			(3330, '[{"attributeID":275,"value":2},{"attributeID":180,"value":165},{"attributeID":181,"value":166},
+				{"attributeID":182,"value":3327},{"attributeID":277,"value":1}]', '[]'),
+			(603, '[{"attributeID":182,"value":3330},{"attributeID":277,"value":1}]', '[]'),
+			(561, '[{"attributeID":182,"value":3301},{"attributeID":277,"value":4},
+				{"attributeID":183,"value":3315},{"attributeID":278,"value":2}]', '[]');
+	`); err != nil {
+		t.Fatalf("Failed to create fixture: %v", err)
+	}
+	if err := views.InitializeSkillsViews(db); err != nil {
+		t.Fatalf("InitializeSkillsViews failed: %v", err)
+	}
+	return db
+}
+
+func loadData(t *testing.T) *Data {
+	t.Helper()
+	d, err := LoadData(setupTestDB(t))
+	if err != nil {
+		t.Fatalf("LoadData failed: %v", err)
+	}
+	return d
+}
+
+func TestSkillPoints(t *testing.T) {
+	want := []int64{0, 250, 1415, 8000, 45255, 256000}
+	for level, w := range want {
+		if got := SkillPoints(1, level); got != w {
+			t.Errorf("SkillPoints(1, %d) = %d, want %d", level, got, w)
+		}
+	}
+	if got := SkillPoints(3, 2); got != 4243 {
+		t.Errorf("SkillPoints(3, 2) = %d, want 4243", got)
+	}
+}
+
+func TestLoadData(t *testing.T) {
+	d := loadData(t)
+
+	s, ok := d.Skill(caldariFrigate)
+	if !ok {
+		t.Fatal("Caldari Frigate missing")
+	}
+	want := &Skill{TypeID: caldariFrigate, Name: "Caldari Frigate", Rank: 2, Primary: "Intelligence", Secondary: "Memory",
+		Requirements: []Requirement{{SkillTypeID: spaceshipCommand, Level: 1}}}
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("skill = %+v, want %+v", s, want)
+	}
+
+	if got := d.Requirements(railgun); !reflect.DeepEqual(got, []Requirement{{smallHybrid, 4}, {surgicalStrike, 2}}) {
+		t.Errorf("requirements = %+v", got)
+	}
+	attrs := d.Attributes()
+	if len(attrs) != 5 || attrs[0].Name != "Perception" || attrs[0].ShortDescription != "PER" {
+		t.Errorf("attributes = %+v", attrs)
+	}
+}
+
+func TestTree(t *testing.T) {
+	d := loadData(t)
+
+	tree, err := d.Tree(railgun)
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+	if len(tree.Requirements) != 2 || tree.Requirements[0].Prerequisites[0].SkillTypeID != gunnery {
+		t.Errorf("requirements = %+v", tree.Requirements)
+	}
+
+	// Gunnery zuerst (Voraussetzung), Stufe IV über Surgical Strike
+	var got []Requirement
+	for _, s := range tree.Steps {
+		got = append(got, Requirement{s.SkillTypeID, s.Level})
+	}
+	if want := []Requirement{{gunnery, 4}, {smallHybrid, 4}, {surgicalStrike, 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("steps = %+v, want %+v", got, want)
+	}
+	if tree.TotalSP != 45255+45255+5657 {
+		t.Errorf("total SP = %d", tree.TotalSP)
+	}
+
+	// Übereinstimmung mit v_type_skill_points
+	db := setupTestDB(t)
+	var viewSP int64
+	if err := db.QueryRow(`SELECT total_sp FROM v_type_skill_points WHERE type_id = ?`, railgun).Scan(&viewSP); err != nil {
+		t.Fatalf("Query v_type_skill_points failed: %v", err)
+	}
+	if viewSP != tree.TotalSP {
+		t.Errorf("view total SP = %d, Go = %d", viewSP, tree.TotalSP)
+	}
+
+	empty, err := d.Tree(gunnery)
+	if err != nil || len(empty.Steps) != 0 || empty.TotalSP != 0 {
+		t.Errorf("Tree(gunnery) = %+v, %v", empty, err)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	d := loadData(t)
+
+	_, err := d.Plan(Requirement{surgicalStrike, 5}, Requirement{merlin, 0})
+	if !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("Plan with ship: err = %v, want ErrUnknownSkill", err)
+	}
+
+	tree, err := d.Plan(Requirement{surgicalStrike, 5})
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if tree.TotalSP != 45255+4*256000 || len(tree.Steps) != 2 {
+		t.Errorf("plan = %+v", tree)
+	}
+
+	if _, err := d.Plan(Requirement{gunnery, 6}); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("level 6: err = %v, want ErrInvalidLevel", err)
+	}
+}
+
+func TestTraining(t *testing.T) {
+	d := loadData(t)
+
+	tree, err := d.Tree(railgun)
+	if err != nil {
+		t.Fatalf("Tree failed: %v", err)
+	}
+
+	// Gunnery III bereits trainiert; Perception 20 + Willpower 20 / 2 = 30 SP/min
+	tr, err := tree.Training(map[int64]int{gunnery: 3}, DefaultAttributes)
+	if err != nil {
+		t.Fatalf("Training failed: %v", err)
+	}
+	if tr.Missing != 45255-8000+45255+5657 {
+		t.Errorf("missing SP = %d", tr.Missing)
+	}
+	if tr.Steps[0].From != 3 || tr.Steps[0].Missing != 37255 {
+		t.Errorf("first step = %+v", tr.Steps[0])
+	}
+	want := time.Duration(float64(tr.Missing) / 30 * float64(time.Minute))
+	if diff := tr.Duration - want; diff < -time.Millisecond || diff > time.Millisecond {
+		t.Errorf("duration = %v, want %v", tr.Duration, want)
+	}
+
+	// Alles trainiert
+	tr, err = tree.Training(map[int64]int{gunnery: 5, smallHybrid: 4, surgicalStrike: 3}, DefaultAttributes)
+	if err != nil || len(tr.Steps) != 0 || tr.Duration != 0 {
+		t.Errorf("trained = %+v, %v", tr, err)
+	}
+
+	// Groß-/Kleinschreibung der Attributnamen egal, fehlende Attribute sind ein Fehler
+	if _, err := tree.Training(nil, Attributes{"perception": 27, "willpower": 21}); err != nil {
+		t.Errorf("lower-case attributes: %v", err)
+	}
+	if _, err := tree.Training(nil, Attributes{"Memory": 20}); !errors.Is(err, ErrInvalidAttributes) {
+		t.Errorf("missing attributes: err = %v, want ErrInvalidAttributes", err)
+	}
+}
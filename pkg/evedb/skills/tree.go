@@ -0,0 +1,151 @@
+package skills
+
+import (
+	"fmt"
+	"time"
+)
+
+// Node ist ein benötigter Skill mit seinen eigenen Voraussetzungen
+type Node struct {
+	SkillTypeID   int64   `json:"skill_type_id"`
+	Name          string  `json:"name,omitempty"`
+	Level         int     `json:"level"`
+	Prerequisites []*Node `json:"prerequisites,omitempty"`
+}
+
+// Step ist ein Skill des Baums mit der höchsten benötigten Stufe
+type Step struct {
+	SkillTypeID int64   `json:"skill_type_id"`
+	Name        string  `json:"name,omitempty"`
+	Level       int     `json:"level"`
+	Rank        float64 `json:"rank"`
+	Primary     string  `json:"primary"`
+	Secondary   string  `json:"secondary"`
+	// SP: Kumulierte Skillpunkte der Stufe
+	SP int64 `json:"sp"`
+}
+
+// Tree ist der vollständige Voraussetzungsbaum eines Typs
+type Tree struct {
+	// TypeID: 0 für Pläne aus Data.Plan
+	TypeID       int64   `json:"type_id,omitempty"`
+	Requirements []*Node `json:"requirements"`
+	// Steps: Jeder Skill einmal, Voraussetzungen vor den Skills, die sie benötigen
+	Steps   []Step `json:"steps"`
+	TotalSP int64  `json:"total_sp"`
+}
+
+// Tree löst alle Skill-Voraussetzungen eines Typs rekursiv auf; Typen ohne Voraussetzungen liefern einen
+// leeren Baum
+func (d *Data) Tree(typeID int64) (*Tree, error) {
+	t, err := d.resolve(d.requirements[typeID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve skills of type %d: %w", typeID, err)
+	}
+	t.TypeID = typeID
+	return t, nil
+}
+
+// Plan löst Ziel-Skills mit Stufe samt aller Voraussetzungen auf (die Ziele selbst sind Teil des Baums)
+func (d *Data) Plan(targets ...Requirement) (*Tree, error) {
+	for _, r := range targets {
+		if r.Level < 0 || r.Level > MaxLevel {
+			return nil, fmt.Errorf("%w: skill %d level %d", ErrInvalidLevel, r.SkillTypeID, r.Level)
+		}
+	}
+	return d.resolve(targets)
+}
+
+// resolve baut die Knoten und die Schritte (Post-Order, höchste Stufe je Skill)
+func (d *Data) resolve(reqs []Requirement) (*Tree, error) {
+	t := &Tree{Requirements: []*Node{}, Steps: []Step{}}
+	index := make(map[int64]int)
+	visiting := make(map[int64]bool)
+
+	var build func(r Requirement) (*Node, error)
+	build = func(r Requirement) (*Node, error) {
+		s, ok := d.skills[r.SkillTypeID]
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownSkill, r.SkillTypeID)
+		}
+		if visiting[s.TypeID] {
+			return nil, fmt.Errorf("%w at %d", ErrCycle, s.TypeID)
+		}
+		visiting[s.TypeID] = true
+		n := &Node{SkillTypeID: s.TypeID, Name: s.Name, Level: r.Level}
+		for _, pre := range s.Requirements {
+			child, err := build(pre)
+			if err != nil {
+				return nil, err
+			}
+			n.Prerequisites = append(n.Prerequisites, child)
+		}
+		visiting[s.TypeID] = false
+
+		if i, ok := index[s.TypeID]; ok {
+			t.Steps[i].Level = max(t.Steps[i].Level, r.Level)
+		} else {
+			index[s.TypeID] = len(t.Steps)
+			t.Steps = append(t.Steps, Step{SkillTypeID: s.TypeID, Name: s.Name, Level: r.Level, Rank: s.Rank,
+				Primary: s.Primary, Secondary: s.Secondary})
+		}
+		return n, nil
+	}
+
+	for _, r := range reqs {
+		n, err := build(r)
+		if err != nil {
+			return nil, err
+		}
+		t.Requirements = append(t.Requirements, n)
+	}
+	for i := range t.Steps {
+		t.Steps[i].SP = SkillPoints(t.Steps[i].Rank, t.Steps[i].Level)
+		t.TotalSP += t.Steps[i].SP
+	}
+	return t, nil
+}
+
+// TrainingStep ist ein noch zu trainierender Skill
+type TrainingStep struct {
+	Step
+	// From: Bereits trainierte Stufe
+	From int `json:"from"`
+	// Missing: Fehlende Skillpunkte bis Step.Level
+	Missing  int64         `json:"missing"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Training ist die Trainingszeit der noch fehlenden Skills eines Baums
+type Training struct {
+	Steps    []TrainingStep `json:"steps"`
+	Missing  int64          `json:"missing"`
+	Duration time.Duration  `json:"duration"`
+}
+
+// Training berechnet fehlende Skillpunkte und Trainingszeit (SP / (Primär + Sekundär / 2) Minuten);
+// trained: Skill → bereits trainierte Stufe (nil = nichts trainiert)
+func (t *Tree) Training(trained map[int64]int, attrs Attributes) (*Training, error) {
+	tr := &Training{Steps: []TrainingStep{}}
+	for _, s := range t.Steps {
+		from := trained[s.SkillTypeID]
+		if from >= s.Level {
+			continue
+		}
+		rate := attrs.SPPerMinute(s.Primary, s.Secondary)
+		if rate <= 0 {
+			return nil, fmt.Errorf("%w: %s/%s for skill %d", ErrInvalidAttributes, s.Primary, s.Secondary, s.SkillTypeID)
+		}
+		missing := s.SP - SkillPoints(s.Rank, from)
+		step := TrainingStep{
+			Step:     s,
+			From:     from,
+			Missing:  missing,
+			Duration: time.Duration(float64(missing) / rate * float64(time.Minute)),
+		}
+		tr.Steps = append(tr.Steps, step)
+		tr.Missing += missing
+		tr.Duration += step.Duration
+	}
+	return tr, nil
+}